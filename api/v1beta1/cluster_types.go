@@ -71,6 +71,11 @@ type ClusterSpec struct {
 	// this feature is highly experimental, and parts of it might still be not implemented.
 	// +optional
 	Topology *Topology `json:"topology,omitempty"`
+
+	// RemediationBudget limits the remediation of the Machines of the Cluster
+	// across all the MachineHealthChecks targeting the Cluster.
+	// +optional
+	RemediationBudget *RemediationBudget `json:"remediationBudget,omitempty"`
 }
 
 // Topology encapsulates the information of the managed resources.
@@ -78,6 +83,16 @@ type Topology struct {
 	// The name of the ClusterClass object to create the topology.
 	Class string `json:"class"`
 
+	// ClassRevision is the name of the ClusterClass revision the topology is pinned to.
+	// If set, the Cluster is reconciled using the ClusterClass as recorded in the revision,
+	// until the Cluster is rebased by changing or unsetting this field.
+	// If not set, the Cluster is reconciled using the current ClusterClass.
+	// NOTE: ClusterClass revisions are stored as ControllerRevisions in the namespace of the
+	// ClusterClass, labeled with the name of the ClusterClass; the current revision is reported
+	// in the ClusterClass status.
+	// +optional
+	ClassRevision string `json:"classRevision,omitempty"`
+
 	// The Kubernetes version of the cluster.
 	Version string `json:"version"`
 
@@ -142,6 +157,11 @@ type ControlPlaneTopology struct {
 	// Defaults to 10 seconds.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// NodeDrainPolicy defines how the controller drains the node before a Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// +optional
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
 }
 
 // WorkersTopology represents the different sets of worker nodes in the cluster.
@@ -208,6 +228,11 @@ type MachineDeploymentTopology struct {
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
 
+	// NodeDrainPolicy defines how the controller drains the node before a Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// +optional
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
+
 	// Minimum number of seconds for which a newly created machine should
 	// be ready.
 	// Defaults to 0 (machine will be considered available as soon as it
@@ -285,6 +310,11 @@ type MachinePoolTopology struct {
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
 
+	// NodeDrainPolicy defines how the controller drains the node before a Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// +optional
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
+
 	// Minimum number of seconds for which a newly created machine pool should
 	// be ready.
 	// Defaults to 0 (machine will be considered available as soon as it
@@ -341,6 +371,27 @@ type MachinePoolVariables struct {
 	Overrides []ClusterVariable `json:"overrides,omitempty"`
 }
 
+// RemediationBudget limits the remediation of the Machines of a Cluster, preventing
+// cascading remediations e.g. during a network partition.
+// NOTE: The budget is enforced by the MachineHealthCheck controller on a best effort basis,
+// given that the MachineHealthChecks targeting the Cluster are reconciled concurrently.
+type RemediationBudget struct {
+	// MaxInFlight is the maximum number of Machines of the Cluster which can be remediated at the same time.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
+
+	// MaxPerWindow is the maximum number of remediations of Machines of the Cluster
+	// which can be started within Window.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPerWindow *int32 `json:"maxPerWindow,omitempty"`
+
+	// Window is the time window used to enforce MaxPerWindow.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
 // ANCHOR_END: ClusterSpec
 
 // ANCHOR: ClusterNetwork
@@ -28,6 +28,10 @@ import (
 // ClusterClassKind represents the Kind of ClusterClass.
 const ClusterClassKind = "ClusterClass"
 
+// ClusterClassRevisionFinalizer is the finalizer added by the ClusterClass controller to the templates referenced
+// by the revisions Clusters are pinned to, so the templates are not deleted until all the Clusters are rebased.
+const ClusterClassRevisionFinalizer = "revision.clusterclass.cluster.x-k8s.io"
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=clusterclasses,shortName=cc,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
@@ -69,6 +73,17 @@ type ClusterClassSpec struct {
 	// +optional
 	Variables []ClusterClassVariable `json:"variables,omitempty"`
 
+	// DerivedVariables defines variables which are computed from other variables
+	// and builtin variables, and can then be used in inline patches.
+	// Note: DerivedVariables are computed in the order of the array, so a derived
+	// variable can use the derived variables defined before it.
+	// Note: DerivedVariables are computed for each template from the same variables
+	// the inline patches get for the template, including the template-specific builtin
+	// variables, e.g. builtin.controlPlane, and the MachineDeployment and MachinePool
+	// variable overrides.
+	// +optional
+	DerivedVariables []ClusterClassDerivedVariable `json:"derivedVariables,omitempty"`
+
 	// Patches defines the patches which are applied to customize
 	// referenced templates of a ClusterClass.
 	// Note: Patches will be applied in the order of the array.
@@ -129,6 +144,12 @@ type ControlPlaneClass struct {
 	// NOTE: This value can be overridden while defining a Cluster.Topology.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// NodeDrainPolicy defines how the controller drains the node before a Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// NOTE: This value can be overridden while defining a Cluster.Topology.
+	// +optional
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
 }
 
 // ControlPlaneClassNamingStrategy defines the naming strategy for control plane objects.
@@ -203,6 +224,12 @@ type MachineDeploymentClass struct {
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
 
+	// NodeDrainPolicy defines how the controller drains the node before a Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// NOTE: This value can be overridden while defining a Cluster.Topology.
+	// +optional
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
+
 	// Minimum number of seconds for which a newly created machine should
 	// be ready.
 	// Defaults to 0 (machine will be considered available as soon as it
@@ -326,6 +353,12 @@ type MachinePoolClass struct {
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
 
+	// NodeDrainPolicy defines how the controller drains the node before a Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// NOTE: This value can be overridden while defining a Cluster.Topology.
+	// +optional
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
+
 	// Minimum number of seconds for which a newly created machine pool should
 	// be ready.
 	// Defaults to 0 (machine will be considered available as soon as it
@@ -518,6 +551,57 @@ type JSONSchemaProps struct {
 	// NOTE: Can be set for all types.
 	// +optional
 	Default *apiextensionsv1.JSON `json:"default,omitempty"`
+
+	// XValidations describes a list of validation rules written in the CEL expression language.
+	// +optional
+	// +listType=map
+	// +listMapKey=rule
+	XValidations []ValidationRule `json:"x-kubernetes-validations,omitempty"`
+}
+
+// ValidationRule describes a validation rule written in the CEL expression language.
+type ValidationRule struct {
+	// Rule represents the expression which will be evaluated by CEL.
+	// The `self` variable in the CEL expression is bound to the scoped value.
+	// Example: rule: "self.ha == false || self.replicas >= 3"
+	// ref: https://github.com/google/cel-spec
+	Rule string `json:"rule"`
+
+	// Message represents the message displayed when validation fails. The message is required if the Rule contains
+	// line breaks. The message must not contain line breaks.
+	// If unset, the message is "failed rule: {Rule}".
+	// e.g. "must be at least 3 replicas if ha is enabled"
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// MessageExpression declares a CEL expression that evaluates to the validation failure message that is returned
+	// when this rule fails. Since messageExpression is used as a failure message, it must evaluate to a string.
+	// If both message and messageExpression are present on a rule, then messageExpression will be used if validation
+	// fails. If messageExpression results in a runtime error, the validation failure message is produced
+	// as if the messageExpression field were unset.
+	// Example: messageExpression: "'replicas must be at least 3, got ' + string(self.replicas)"
+	// +optional
+	MessageExpression string `json:"messageExpression,omitempty"`
+}
+
+// ClusterClassDerivedVariable defines a variable which is computed from
+// other variables and builtin variables, either via a template or a CEL expression.
+// Note: Exactly one of Template or Expression must be set.
+type ClusterClassDerivedVariable struct {
+	// Name of the derived variable.
+	Name string `json:"name"`
+
+	// Template is the Go template used to compute the value of the variable.
+	// The template has access to the ClusterClass variables and the builtin variables
+	// of the Cluster, e.g. "{{ .builtin.cluster.name }}-{{ .region }}".
+	// +optional
+	Template *string `json:"template,omitempty"`
+
+	// Expression is the CEL expression used to compute the value of the variable.
+	// Variables are accessible in the expression by their name,
+	// e.g. "builtin.cluster.name + '-' + region".
+	// +optional
+	Expression *string `json:"expression,omitempty"`
 }
 
 // ClusterClassPatch defines a patch which is applied to customize the referenced templates.
@@ -556,7 +640,20 @@ type PatchDefinition struct {
 	// JSONPatches defines the patches which should be applied on the templates
 	// matching the selector.
 	// Note: Patches will be applied in the order of the array.
-	JSONPatches []JSONPatch `json:"jsonPatches"`
+	// Note: Exactly one of JSONPatches or MergePatchTemplate must be set.
+	// +optional
+	JSONPatches []JSONPatch `json:"jsonPatches,omitempty"`
+
+	// MergePatchTemplate is the Go template to be used to calculate a partial object, which is
+	// applied as a JSON merge patch (RFC 7386) on the templates matching the selector.
+	// A template can reference variables defined in .spec.variables and builtin variables.
+	// Note: The template must evaluate to a valid YAML or JSON object, e.g. `spec: {template: {spec: {...}}}`.
+	// Note: Lists are merged with the lists of the templates instead of being replaced: list items which are
+	// objects with the same name (or the same path, if they have no name) are merged, items equal to an existing
+	// list item are ignored, and all the other items are appended.
+	// Note: Exactly one of JSONPatches or MergePatchTemplate must be set.
+	// +optional
+	MergePatchTemplate *string `json:"mergePatchTemplate,omitempty"`
 }
 
 // PatchSelector defines on which templates the patch should be applied.
@@ -698,6 +795,11 @@ type ClusterClassStatus struct {
 	// +optional
 	Variables []ClusterClassStatusVariable `json:"variables,omitempty"`
 
+	// CurrentRevision is the name of the revision recording the current ClusterClass.
+	// Clusters can be pinned to a revision by setting spec.topology.classRevision.
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
 	// Conditions defines current observed state of the ClusterClass.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
@@ -32,6 +32,10 @@ const (
 	// ClusterTopologyOwnedLabel is the label set on all the object which are managed as part of a ClusterTopology.
 	ClusterTopologyOwnedLabel = "topology.cluster.x-k8s.io/owned"
 
+	// ClusterClassNameLabel is the label set on ClusterClass revisions to track the name of the
+	// ClusterClass they have been created for.
+	ClusterClassNameLabel = "topology.cluster.x-k8s.io/cluster-class-name"
+
 	// ClusterTopologyMachineDeploymentNameLabel is the label set on the generated  MachineDeployment objects
 	// to track the name of the MachineDeployment topology it represents.
 	ClusterTopologyMachineDeploymentNameLabel = "topology.cluster.x-k8s.io/deployment-name"
@@ -124,6 +128,23 @@ const (
 	// MachineSkipRemediationAnnotation is the annotation used to mark the machines that should not be considered for remediation by MachineHealthCheck reconciler.
 	MachineSkipRemediationAnnotation = "cluster.x-k8s.io/skip-remediation"
 
+	// ReplacementForAnnotation is the annotation set by owners like MachineSets and KubeadmControlPlanes on Machines
+	// created as the replacement of a remediated Machine, and its value is the name of the remediated Machine.
+	// It is used by the MachineHealthCheck controller to record the replacement in its remediation history.
+	ReplacementForAnnotation = "cluster.x-k8s.io/replacement-for"
+
+	// RemediationActionAnnotation is the annotation set by the MachineHealthCheck controller on unhealthy Machines
+	// to request the infrastructure provider to perform a soft remediation action before the Machine is replaced,
+	// e.g. "Reboot". Infrastructure providers supporting the action should perform it once for each value of the
+	// RemediationActionTimestampAnnotation.
+	RemediationActionAnnotation = "cluster.x-k8s.io/remediation-action"
+
+	// RemediationActionTimestampAnnotation is the annotation set by the MachineHealthCheck controller on unhealthy Machines
+	// together with the RemediationActionAnnotation, storing the time when the action has been requested in RFC3339 format.
+	// This annotation is kept when the Machine becomes healthy again, so the action is not requested again for Machines
+	// failing repeatedly in a short period of time; such Machines are replaced instead.
+	RemediationActionTimestampAnnotation = "cluster.x-k8s.io/remediation-action-timestamp"
+
 	// MachineSetSkipPreflightChecksAnnotation is the annotation used to provide a comma-separated list of
 	// preflight checks that should be skipped during the MachineSet reconciliation.
 	// Supported items are:
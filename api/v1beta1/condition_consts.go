@@ -157,6 +157,12 @@ const (
 
 	// UnhealthyNodeConditionReason is the reason used when a machine's node has one of the MachineHealthCheck's unhealthy conditions.
 	UnhealthyNodeConditionReason = "UnhealthyNode"
+
+	// UnhealthyMachineConditionReason is the reason used when a machine has one of the MachineHealthCheck's unhealthy machine conditions.
+	UnhealthyMachineConditionReason = "UnhealthyMachine"
+
+	// UnhealthyNodeTaintReason is the reason used when a machine's node has one of the MachineHealthCheck's unhealthy node taints.
+	UnhealthyNodeTaintReason = "UnhealthyNodeTaint"
 )
 
 const (
@@ -219,6 +225,10 @@ const (
 	// TooManyUnhealthyReason is the reason used when too many Machines are unhealthy and the MachineHealthCheck is blocked
 	// from making any further remediations.
 	TooManyUnhealthyReason = "TooManyUnhealthy"
+
+	// RemediationBudgetExhaustedReason is the reason used when the remediation budget of the Cluster is exhausted and
+	// the MachineHealthCheck is blocked from remediating some of the unhealthy Machines.
+	RemediationBudgetExhaustedReason = "RemediationBudgetExhausted"
 )
 
 // Conditions and condition Reasons for  MachineDeployments.
@@ -19,6 +19,8 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
@@ -132,10 +134,69 @@ type MachineSpec struct {
 	// Defaults to 10 seconds.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// NodeDrainPolicy defines how the controller drains the node before the Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// +optional
+	NodeDrainPolicy *NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
 }
 
 // ANCHOR_END: MachineSpec
 
+// NodeDrainMode defines how the pods are removed from a node being drained.
+type NodeDrainMode string
+
+const (
+	// NodeDrainModeEvict evicts the pods using the Eviction API, respecting PodDisruptionBudgets.
+	NodeDrainModeEvict = NodeDrainMode("Evict")
+
+	// NodeDrainModeDelete deletes the pods, ignoring PodDisruptionBudgets.
+	NodeDrainModeDelete = NodeDrainMode("Delete")
+)
+
+// NodeDrainPolicy defines how the node of a Machine is drained.
+type NodeDrainPolicy struct {
+	// SkipPodSelector selects the pods that are not removed from the node being drained,
+	// e.g. pods which are not disrupted by the node going away.
+	// NOTE: DaemonSet pods and static pods are always skipped.
+	// +optional
+	SkipPodSelector *metav1.LabelSelector `json:"skipPodSelector,omitempty"`
+
+	// EvictionOrder defines the order in which the pods are removed from the node being drained,
+	// e.g. to drain stateless workloads before stateful ones. Pods matching the first selector are
+	// removed first, and the pods matching the next selector are removed only after those are gone;
+	// pods not matching any selector are removed last.
+	// +optional
+	EvictionOrder []metav1.LabelSelector `json:"evictionOrder,omitempty"`
+
+	// Mode defines how the pods are removed from the node being drained, either evicting them
+	// respecting PodDisruptionBudgets or deleting them. Defaults to Evict.
+	// +kubebuilder:validation:Enum=Evict;Delete
+	// +optional
+	Mode NodeDrainMode `json:"mode,omitempty"`
+
+	// GracePeriodSeconds overrides the termination grace period of the pods removed from the node
+	// being drained. If not set, the termination grace period of each pod is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// Validate validates the label selectors in NodeDrainPolicy.
+func (p *NodeDrainPolicy) Validate(parent *field.Path) field.ErrorList {
+	if p == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if p.SkipPodSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(p.SkipPodSelector, metav1validation.LabelSelectorValidationOptions{}, parent.Child("skipPodSelector"))...)
+	}
+	for i := range p.EvictionOrder {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&p.EvictionOrder[i], metav1validation.LabelSelectorValidationOptions{}, parent.Child("evictionOrder").Index(i))...)
+	}
+	return allErrs
+}
+
 // ANCHOR: MachineStatus
 
 // MachineStatus defines the observed state of Machine.
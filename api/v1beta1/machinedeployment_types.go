@@ -38,6 +38,11 @@ const (
 	// OnDeleteMachineDeploymentStrategyType replaces old MachineSets when the deletion of the associated machines are completed.
 	OnDeleteMachineDeploymentStrategyType MachineDeploymentStrategyType = "OnDelete"
 
+	// BlueGreenMachineDeploymentStrategyType replaces the old MachineSets by a new one using a blue/green rollout
+	// i.e. scale up the new MachineSet to the desired number of replicas and, once all its machines are available
+	// and the cut-over gate, if any, has passed, scale down the old MachineSets to zero in one step.
+	BlueGreenMachineDeploymentStrategyType MachineDeploymentStrategyType = "BlueGreen"
+
 	// RevisionAnnotation is the revision annotation of a machine deployment's machine sets which records its rollout sequence.
 	RevisionAnnotation = "machinedeployment.clusters.x-k8s.io/revision"
 
@@ -54,6 +59,16 @@ const (
 	// proportions in case the deployment has surge replicas.
 	MaxReplicasAnnotation = "machinedeployment.clusters.x-k8s.io/max-replicas"
 
+	// CutOverAnnotation is the annotation which has to be set on a MachineDeployment using the BlueGreen strategy
+	// with an Annotation cut-over gate in order to allow the cut-over to the new MachineSet; the value of the annotation
+	// must be the name of the new MachineSet.
+	CutOverAnnotation = "machinedeployment.clusters.x-k8s.io/cut-over"
+
+	// RolloutResumeAnnotation is the annotation which can be set on a MachineDeployment in order to resume a rollout
+	// paused at a step, or to restart an aborted rollout; the value of the annotation must be the index of the current
+	// step of the rollout, as reported in status.rollout.currentStepIndex.
+	RolloutResumeAnnotation = "machinedeployment.clusters.x-k8s.io/resume-rollout"
+
 	// MachineDeploymentUniqueLabel is used to uniquely identify the Machines of a MachineSet.
 	// The MachineDeployment controller will set this label on a MachineSet when it is created.
 	// The label is also applied to the Machines of the MachineSet and used in the MachineSet selector.
@@ -152,9 +167,9 @@ type MachineDeploymentSpec struct {
 // MachineDeploymentStrategy describes how to replace existing machines
 // with new ones.
 type MachineDeploymentStrategy struct {
-	// Type of deployment. Allowed values are RollingUpdate and OnDelete.
+	// Type of deployment. Allowed values are RollingUpdate, OnDelete and BlueGreen.
 	// The default is RollingUpdate.
-	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete;BlueGreen
 	// +optional
 	Type MachineDeploymentStrategyType `json:"type,omitempty"`
 
@@ -162,10 +177,86 @@ type MachineDeploymentStrategy struct {
 	// MachineDeploymentStrategyType = RollingUpdate.
 	// +optional
 	RollingUpdate *MachineRollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+
+	// Blue/green config params. Present only if
+	// MachineDeploymentStrategyType = BlueGreen.
+	// +optional
+	BlueGreen *MachineBlueGreenDeployment `json:"blueGreen,omitempty"`
+
+	// Steps define a progressive rollout, e.g. replacing a percentage of the machines and then
+	// pausing before continuing; once all the steps have been completed, the rollout continues
+	// until all the machines are replaced.
+	// If any machine of the new MachineSet is reported unhealthy by a MachineHealthCheck before
+	// all the steps have been completed, the rollout is aborted and the machines of the new
+	// MachineSet are replaced back with machines of the old MachineSets.
+	// Steps can be used only if MachineDeploymentStrategyType = RollingUpdate.
+	// +optional
+	Steps []MachineDeploymentRolloutStep `json:"steps,omitempty"`
 }
 
 // ANCHOR_END: MachineDeploymentStrategy
 
+// ANCHOR: MachineDeploymentRolloutStep
+
+// MachineDeploymentRolloutStep defines a step of a progressive rollout.
+// Exactly one of SetWeight and Pause must be set.
+type MachineDeploymentRolloutStep struct {
+	// SetWeight is the percentage of the desired replicas which have to be replaced
+	// with available machines of the new MachineSet before moving to the next step.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SetWeight *int32 `json:"setWeight,omitempty"`
+
+	// Pause pauses the rollout before moving to the next step.
+	// +optional
+	Pause *MachineDeploymentRolloutPause `json:"pause,omitempty"`
+}
+
+// MachineDeploymentRolloutPause defines a pause of a progressive rollout.
+type MachineDeploymentRolloutPause struct {
+	// Duration of the pause. If not set, the rollout is paused until it is resumed,
+	// e.g. using `clusterctl alpha rollout resume`.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// ANCHOR_END: MachineDeploymentRolloutStep
+
+// ANCHOR: MachineBlueGreenDeployment
+
+// MachineBlueGreenDeployment is used to control the desired behavior of blue/green rollouts.
+type MachineBlueGreenDeployment struct {
+	// CutOverGate is an optional gate which has to pass before the old MachineSets are scaled down,
+	// once all the machines of the new MachineSet are available.
+	// If not set, the cut-over happens as soon as all the machines of the new MachineSet are available.
+	// +optional
+	CutOverGate *MachineDeploymentCutOverGate `json:"cutOverGate,omitempty"`
+}
+
+// MachineDeploymentCutOverGateType defines the type of gate for the cut-over of a blue/green rollout.
+type MachineDeploymentCutOverGateType string
+
+const (
+	// AnnotationCutOverGateType allows the cut-over once the MachineDeployment has the
+	// "machinedeployment.clusters.x-k8s.io/cut-over" annotation set to the name of the new MachineSet.
+	AnnotationCutOverGateType MachineDeploymentCutOverGateType = "Annotation"
+
+	// RuntimeHookCutOverGateType allows the cut-over once all the Runtime Extensions
+	// implementing the BeforeMachineDeploymentCutOver hook allow it.
+	// NOTE: This gate type requires the RuntimeSDK feature flag to be enabled.
+	RuntimeHookCutOverGateType MachineDeploymentCutOverGateType = "RuntimeHook"
+)
+
+// MachineDeploymentCutOverGate defines a gate for the cut-over of a blue/green rollout.
+type MachineDeploymentCutOverGate struct {
+	// Type of the gate. Allowed values are Annotation and RuntimeHook.
+	// +kubebuilder:validation:Enum=Annotation;RuntimeHook
+	Type MachineDeploymentCutOverGateType `json:"type"`
+}
+
+// ANCHOR_END: MachineBlueGreenDeployment
+
 // ANCHOR: MachineRollingUpdateDeployment
 
 // MachineRollingUpdateDeployment is used to control the desired behavior of rolling update.
@@ -256,6 +347,10 @@ type MachineDeploymentStatus struct {
 	// +optional
 	Phase string `json:"phase,omitempty"`
 
+	// Rollout reports the progress of a rollout using steps.
+	// +optional
+	Rollout *MachineDeploymentRolloutStatus `json:"rollout,omitempty"`
+
 	// Conditions defines current service state of the MachineDeployment.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
@@ -263,6 +358,25 @@ type MachineDeploymentStatus struct {
 
 // ANCHOR_END: MachineDeploymentStatus
 
+// MachineDeploymentRolloutStatus defines the observed state of a rollout using steps.
+type MachineDeploymentRolloutStatus struct {
+	// MachineSetName is the name of the new MachineSet being rolled out.
+	MachineSetName string `json:"machineSetName"`
+
+	// CurrentStepIndex is the index of the current step of the rollout;
+	// it is equal to the number of steps once all the steps have been completed.
+	CurrentStepIndex int32 `json:"currentStepIndex"`
+
+	// PausedAt is the time when the current pause step started.
+	// +optional
+	PausedAt *metav1.Time `json:"pausedAt,omitempty"`
+
+	// Aborted is true if the rollout has been aborted because machines of the new MachineSet
+	// have been reported unhealthy.
+	// +optional
+	Aborted bool `json:"aborted,omitempty"`
+}
+
 // MachineDeploymentPhase indicates the progress of the machine deployment.
 type MachineDeploymentPhase string
 
@@ -50,6 +50,28 @@ type MachineHealthCheckSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions"`
 
+	// UnhealthyMachineConditions contains a list of the Machine conditions that determine
+	// whether a machine is considered unhealthy, e.g. conditions reporting hardware faults
+	// surfaced by the infrastructure provider.
+	// Machine conditions are evaluated only once the Machine has a Node.
+	// +optional
+	UnhealthyMachineConditions []UnhealthyMachineCondition `json:"unhealthyMachineConditions,omitempty"`
+
+	// UnhealthyNodeTaints contains a list of the Node taints that determine
+	// whether a node is considered unhealthy.
+	// +optional
+	UnhealthyNodeTaints []UnhealthyNodeTaint `json:"unhealthyNodeTaints,omitempty"`
+
+	// UnhealthyChecksOperator defines how UnhealthyConditions, UnhealthyMachineConditions
+	// and UnhealthyNodeTaints are combined.
+	// With Or, a machine is unhealthy if any of the checks is met; with And, a machine is
+	// unhealthy only if at least one check of each kind is met, i.e. the entries of each list
+	// are still combined with Or, and lists which are not set are ignored.
+	// If not set, this value is defaulted to Or.
+	// +kubebuilder:validation:Enum=Or;And
+	// +optional
+	UnhealthyChecksOperator UnhealthyChecksOperator `json:"unhealthyChecksOperator,omitempty"`
+
 	// Any further remediation is only allowed if at most "MaxUnhealthy" machines selected by
 	// "selector" are not healthy.
 	// +optional
@@ -79,10 +101,44 @@ type MachineHealthCheckSpec struct {
 	// a controller that lives outside of Cluster API.
 	// +optional
 	RemediationTemplate *corev1.ObjectReference `json:"remediationTemplate,omitempty"`
+
+	// SoftRemediation is an optional remediation action to be attempted before replacing unhealthy Machines,
+	// e.g. rebooting the Machine.
+	//
+	// When set, the MachineHealthCheck controller requests the action to the infrastructure provider by annotating
+	// unhealthy Machines; if a Machine is still unhealthy after the configured timeout, it is replaced.
+	// This field cannot be used together with RemediationTemplate.
+	// +optional
+	SoftRemediation *SoftRemediation `json:"softRemediation,omitempty"`
 }
 
 // ANCHOR_END: MachineHealthCHeckSpec
 
+// ANCHOR: SoftRemediation
+
+// SoftRemediationAction is the type of action requested to the infrastructure provider to remediate an unhealthy Machine.
+type SoftRemediationAction string
+
+const (
+	// RebootSoftRemediationAction requests the infrastructure provider to reboot an unhealthy Machine.
+	RebootSoftRemediationAction SoftRemediationAction = "Reboot"
+)
+
+// SoftRemediation defines a remediation action delegated to the infrastructure provider, which is attempted
+// before replacing an unhealthy Machine.
+type SoftRemediation struct {
+	// Action is the action requested to the infrastructure provider through the
+	// "cluster.x-k8s.io/remediation-action" annotation on unhealthy Machines.
+	// +kubebuilder:validation:Enum=Reboot
+	Action SoftRemediationAction `json:"action"`
+
+	// Timeout is the duration to wait for an unhealthy Machine to become healthy after
+	// the action has been requested, before replacing the Machine.
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// ANCHOR_END: SoftRemediation
+
 // ANCHOR: UnhealthyCondition
 
 // UnhealthyCondition represents a Node condition type and value with a timeout
@@ -102,6 +158,59 @@ type UnhealthyCondition struct {
 
 // ANCHOR_END: UnhealthyCondition
 
+// ANCHOR: UnhealthyMachineCondition
+
+// UnhealthyMachineCondition represents a Machine condition type and value with a timeout
+// specified as a duration.  When the named condition has been in the given
+// status for at least the timeout value, a machine is considered unhealthy.
+type UnhealthyMachineCondition struct {
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MinLength=1
+	Type ConditionType `json:"type"`
+
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MinLength=1
+	Status corev1.ConditionStatus `json:"status"`
+
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// ANCHOR_END: UnhealthyMachineCondition
+
+// ANCHOR: UnhealthyNodeTaint
+
+// UnhealthyNodeTaint represents a Node taint with an optional timeout specified as a duration.
+// When the taint has been applied for at least the timeout value, a node is considered unhealthy.
+type UnhealthyNodeTaint struct {
+	// Key is the taint key to be matched.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Effect is the taint effect to be matched; if not set, taints with any effect are matched.
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +optional
+	Effect corev1.TaintEffect `json:"effect,omitempty"`
+
+	// Timeout is the duration the taint must be applied for before a node is considered unhealthy.
+	// NOTE: Kubernetes reports when a taint has been added only for NoExecute taints;
+	// nodes with other taints are considered unhealthy as soon as the taint is matched.
+	// +optional
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ANCHOR_END: UnhealthyNodeTaint
+
+// UnhealthyChecksOperator defines how the checks of a MachineHealthCheck are combined.
+type UnhealthyChecksOperator string
+
+const (
+	// OrUnhealthyChecksOperator considers a machine unhealthy if any of the checks is met.
+	OrUnhealthyChecksOperator UnhealthyChecksOperator = "Or"
+
+	// AndUnhealthyChecksOperator considers a machine unhealthy only if at least one check of each kind is met.
+	AndUnhealthyChecksOperator UnhealthyChecksOperator = "And"
+)
+
 // ANCHOR: MachineHealthCheckStatus
 
 // MachineHealthCheckStatus defines the observed state of MachineHealthCheck.
@@ -130,11 +239,65 @@ type MachineHealthCheckStatus struct {
 	// +optional
 	Targets []string `json:"targets,omitempty"`
 
+	// RemediationBudget reports the remediation budget of the Cluster, which is shared
+	// by all the MachineHealthChecks targeting the Cluster.
+	// +optional
+	RemediationBudget *RemediationBudgetStatus `json:"remediationBudget,omitempty"`
+
+	// RemediationHistory is the history of the most recent remediations triggered by the MachineHealthCheck,
+	// from the oldest to the most recent.
+	// +optional
+	RemediationHistory []RemediationHistoryEntry `json:"remediationHistory,omitempty"`
+
 	// Conditions defines current service state of the MachineHealthCheck.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
 }
 
+// RemediationBudgetStatus reports the remediation budget of a Cluster.
+type RemediationBudgetStatus struct {
+	// InFlight is the number of Machines of the Cluster being remediated.
+	// +optional
+	InFlight int32 `json:"inFlight"`
+
+	// StartedInWindow is the number of remediations of Machines of the Cluster started within the window.
+	// +optional
+	StartedInWindow int32 `json:"startedInWindow,omitempty"`
+
+	// Remaining is the number of further remediations which can be started before
+	// the remediation budget of the Cluster is exhausted.
+	// +optional
+	Remaining int32 `json:"remaining"`
+
+	// StartTimes are the times when the remediations started by this MachineHealthCheck
+	// within the window have been started.
+	// +optional
+	StartTimes []metav1.Time `json:"startTimes,omitempty"`
+}
+
+// RemediationHistoryEntry records the remediation of a Machine.
+type RemediationHistoryEntry struct {
+	// Machine is the name of the remediated Machine.
+	Machine string `json:"machine"`
+
+	// Reason is the reason of the failed health check which triggered the remediation.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable message about the failed health check which triggered the remediation.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Timestamp is when the remediation has been triggered.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// ReplacedBy is the name of the Machine which replaced the remediated Machine, if known.
+	// MachineSets record the Machines they create after remediations as the replacements of the remediated Machines,
+	// from the oldest to the most recent remediation.
+	// +optional
+	ReplacedBy string `json:"replacedBy,omitempty"`
+}
+
 // ANCHOR_END: MachineHealthCheckStatus
 
 // +kubebuilder:object:root=true
@@ -168,6 +168,10 @@ type MachineSetStatus struct {
 	FailureReason *capierrors.MachineSetStatusError `json:"failureReason,omitempty"`
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
+	// RemediationHistory is the history of the most recent remediations of unhealthy Machines
+	// performed by the MachineSet, from the oldest to the most recent.
+	// +optional
+	RemediationHistory []RemediationHistoryEntry `json:"remediationHistory,omitempty"`
 	// Conditions defines current service state of the MachineSet.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
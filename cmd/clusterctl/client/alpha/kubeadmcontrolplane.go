@@ -18,15 +18,20 @@ package alpha
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 )
 
 // getKubeadmControlPlane retrieves the KubeadmControlPlane object corresponding to the name and namespace specified.
@@ -73,3 +78,67 @@ func patchKubeadmControlPlane(ctx context.Context, proxy cluster.Proxy, name, na
 	}
 	return nil
 }
+
+// getControllerRevisionsForKubeadmControlPlane returns the ControllerRevisions recording the revision history of a KubeadmControlPlane.
+func getControllerRevisionsForKubeadmControlPlane(ctx context.Context, proxy cluster.Proxy, kcp *controlplanev1.KubeadmControlPlane) ([]*appsv1.ControllerRevision, error) {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	revisionList := &appsv1.ControllerRevisionList{}
+	if err := c.List(ctx, revisionList, client.InNamespace(kcp.Namespace), client.MatchingLabels{clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(kcp.Name)}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list ControllerRevisions for KubeadmControlPlane %s/%s", kcp.Namespace, kcp.Name)
+	}
+
+	revisions := make([]*appsv1.ControllerRevision, 0, len(revisionList.Items))
+	for i := range revisionList.Items {
+		// Skip this ControllerRevision if its controller ref is not pointing to this KubeadmControlPlane.
+		if !metav1.IsControlledBy(&revisionList.Items[i], kcp) {
+			continue
+		}
+		revisions = append(revisions, &revisionList.Items[i])
+	}
+	return revisions, nil
+}
+
+// findKubeadmControlPlaneRevision finds the specific revision in the ControllerRevisions; if toRevision is 0,
+// the revision previous to the current one is returned.
+func findKubeadmControlPlaneRevision(toRevision int64, revisions []*appsv1.ControllerRevision) (*appsv1.ControllerRevision, error) {
+	var (
+		latest   *appsv1.ControllerRevision
+		previous *appsv1.ControllerRevision
+	)
+	for _, revision := range revisions {
+		if toRevision > 0 {
+			if revision.Revision == toRevision {
+				return revision, nil
+			}
+			continue
+		}
+		if latest == nil || latest.Revision < revision.Revision {
+			previous = latest
+			latest = revision
+		} else if previous == nil || previous.Revision < revision.Revision {
+			previous = revision
+		}
+	}
+
+	if toRevision > 0 {
+		return nil, errors.Errorf("unable to find specified KubeadmControlPlane revision: %v", toRevision)
+	}
+
+	if previous == nil {
+		return nil, errors.Errorf("no rollout history found for KubeadmControlPlane")
+	}
+	return previous, nil
+}
+
+// kubeadmControlPlaneFromRevision returns the KubeadmControlPlane recorded in a ControllerRevision;
+// only the fields recorded in the revision history are set.
+func kubeadmControlPlaneFromRevision(revision *appsv1.ControllerRevision) (*controlplanev1.KubeadmControlPlane, error) {
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := json.Unmarshal(revision.Data.Raw, kcp); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal ControllerRevision %s", revision.Name)
+	}
+	return kcp, nil
+}
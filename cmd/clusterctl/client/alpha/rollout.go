@@ -38,6 +38,7 @@ var validResourceTypes = []string{
 
 var validRollbackResourceTypes = []string{
 	MachineDeployment,
+	KubeadmControlPlane,
 }
 
 // Rollout defines the behavior of a rollout implementation.
@@ -46,6 +47,33 @@ type Rollout interface {
 	ObjectPauser(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectResumer(context.Context, cluster.Proxy, corev1.ObjectReference) error
 	ObjectRollbacker(context.Context, cluster.Proxy, corev1.ObjectReference, int64) error
+	ObjectHistoryViewer(context.Context, cluster.Proxy, corev1.ObjectReference) ([]RolloutRevision, error)
+	ObjectStatusViewer(context.Context, cluster.Proxy, corev1.ObjectReference) (*RolloutStatus, error)
+}
+
+// RolloutStatus describes the status of the rollout of a cluster-api resource.
+type RolloutStatus struct {
+	// Message describes the progress of the rollout.
+	Message string
+
+	// Done is true if the rollout is complete.
+	Done bool
+}
+
+// RolloutRevision describes a revision in the rollout history of a cluster-api resource.
+type RolloutRevision struct {
+	// Revision is the revision number.
+	Revision int64
+
+	// Name is the name of the object recording the revision, i.e. a MachineSet for a MachineDeployment
+	// and a ControllerRevision for a KubeadmControlPlane.
+	Name string
+
+	// Version is the Kubernetes version of the revision.
+	Version string
+
+	// InfrastructureRef is the reference to the infrastructure machine template of the revision.
+	InfrastructureRef corev1.ObjectReference
 }
 
 var _ Rollout = &rollout{}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// ObjectHistoryViewer returns the rollout history of the specified cluster-api resource, sorted by revision.
+func (r *rollout) ObjectHistoryViewer(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) ([]RolloutRevision, error) {
+	revisions := []RolloutRevision{}
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to get %v/%v", ref.Kind, ref.Name)
+		}
+		msList, err := getMachineSetsForDeployment(ctx, proxy, deployment)
+		if err != nil {
+			return nil, err
+		}
+		for _, ms := range msList {
+			v, err := revision(ms)
+			if err != nil {
+				continue
+			}
+			rev := RolloutRevision{
+				Revision:          v,
+				Name:              ms.Name,
+				InfrastructureRef: ms.Spec.Template.Spec.InfrastructureRef,
+			}
+			if ms.Spec.Template.Spec.Version != nil {
+				rev.Version = *ms.Spec.Template.Spec.Version
+			}
+			revisions = append(revisions, rev)
+		}
+	case KubeadmControlPlane:
+		kcp, err := getKubeadmControlPlane(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || kcp == nil {
+			return nil, errors.Wrapf(err, "failed to get %v/%v", ref.Kind, ref.Name)
+		}
+		controllerRevisions, err := getControllerRevisionsForKubeadmControlPlane(ctx, proxy, kcp)
+		if err != nil {
+			return nil, err
+		}
+		for _, controllerRevision := range controllerRevisions {
+			revisionKCP, err := kubeadmControlPlaneFromRevision(controllerRevision)
+			if err != nil {
+				return nil, err
+			}
+			revisions = append(revisions, RolloutRevision{
+				Revision:          controllerRevision.Revision,
+				Name:              controllerRevision.Name,
+				Version:           revisionKCP.Spec.Version,
+				InfrastructureRef: revisionKCP.Spec.MachineTemplate.InfrastructureRef,
+			})
+		}
+	default:
+		return nil, errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validRollbackResourceTypes)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
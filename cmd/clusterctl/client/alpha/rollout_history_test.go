@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+func Test_ObjectHistoryViewer(t *testing.T) {
+	deployment := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "MachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-md-0",
+			Namespace: "default",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "test",
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+			},
+		},
+	}
+	machineSet := func(name, revision, version, infraTemplate string) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "MachineSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(deployment, clusterv1.GroupVersion.WithKind("MachineDeployment")),
+				},
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+				Annotations: map[string]string{
+					clusterv1.RevisionAnnotation: revision,
+				},
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: pointer.String(version),
+						InfrastructureRef: corev1.ObjectReference{
+							Kind: "InfrastructureMachineTemplate",
+							Name: infraTemplate,
+						},
+					},
+				},
+			},
+		}
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-kcp",
+			Namespace: "default",
+			UID:       "test-kcp-uid",
+		},
+	}
+	otherKCP := kcp.DeepCopy()
+	otherKCP.Name = "other-kcp"
+	otherKCP.UID = "other-kcp-uid"
+
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		ref     corev1.ObjectReference
+		want    []RolloutRevision
+		wantErr bool
+	}{
+		{
+			name: "should return the history of a machinedeployment",
+			objs: []client.Object{
+				deployment,
+				machineSet("ms-rev-2", "2", "v1.19.3", "md-template-2"),
+				machineSet("ms-rev-1", "1", "v1.19.1", "md-template-1"),
+			},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "test-md-0",
+				Namespace: "default",
+			},
+			want: []RolloutRevision{
+				{Revision: 1, Name: "ms-rev-1", Version: "v1.19.1", InfrastructureRef: corev1.ObjectReference{Kind: "InfrastructureMachineTemplate", Name: "md-template-1"}},
+				{Revision: 2, Name: "ms-rev-2", Version: "v1.19.3", InfrastructureRef: corev1.ObjectReference{Kind: "InfrastructureMachineTemplate", Name: "md-template-2"}},
+			},
+		},
+		{
+			name: "should return the history of a kubeadmcontrolplane",
+			objs: []client.Object{
+				kcp,
+				kcpRevision(kcp, "kcp-rev-3", 3, "v1.19.3", "kcp-template-3"),
+				kcpRevision(kcp, "kcp-rev-1", 1, "v1.19.1", "kcp-template-1"),
+				kcpRevision(otherKCP, "other-kcp-rev-2", 2, "v1.19.1", "kcp-template-2"),
+			},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "test-kcp",
+				Namespace: "default",
+			},
+			want: []RolloutRevision{
+				{Revision: 1, Name: "kcp-rev-1", Version: "v1.19.1", InfrastructureRef: corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "InfrastructureMachineTemplate", Name: "kcp-template-1"}},
+				{Revision: 3, Name: "kcp-rev-3", Version: "v1.19.3", InfrastructureRef: corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "InfrastructureMachineTemplate", Name: "kcp-template-3"}},
+			},
+		},
+		{
+			name: "should return an empty history for a kubeadmcontrolplane without revisions",
+			objs: []client.Object{
+				kcp,
+			},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "test-kcp",
+				Namespace: "default",
+			},
+			want: []RolloutRevision{},
+		},
+		{
+			name: "should fail for a missing kubeadmcontrolplane",
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "test-kcp",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			got, err := r.ObjectHistoryViewer(context.Background(), proxy, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -39,6 +39,12 @@ func (r *rollout) ObjectResumer(ctx context.Context, proxy cluster.Proxy, ref co
 		if err != nil || deployment == nil {
 			return errors.Wrapf(err, "failed to fetch %v/%v", ref.Kind, ref.Name)
 		}
+		if rollout := deployment.Status.Rollout; !deployment.Spec.Paused && rollout != nil && (rollout.PausedAt != nil || rollout.Aborted) {
+			if err := resumeMachineDeploymentRollout(ctx, proxy, ref.Name, ref.Namespace, rollout.CurrentStepIndex); err != nil {
+				return err
+			}
+			return nil
+		}
 		if !deployment.Spec.Paused {
 			return errors.Errorf("MachineDeployment is not currently paused: %v/%v\n", ref.Kind, ref.Name) //nolint:revive // MachineDeployment is intentionally capitalized.
 		}
@@ -69,6 +75,14 @@ func resumeMachineDeployment(ctx context.Context, proxy cluster.Proxy, name, nam
 	return patchMachineDeployment(ctx, proxy, name, namespace, patch)
 }
 
+// resumeMachineDeploymentRollout sets the resume annotation for the current step of a rollout using steps,
+// which is either paused at a pause step or aborted.
+func resumeMachineDeploymentRollout(ctx context.Context, proxy cluster.Proxy, name, namespace string, currentStepIndex int32) error {
+	patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf("{\"metadata\":{\"annotations\":{%q:\"%d\"}}}", clusterv1.RolloutResumeAnnotation, currentStepIndex)))
+
+	return patchMachineDeployment(ctx, proxy, name, namespace, patch)
+}
+
 // resumeKubeadmControlPlane removes paused annotation.
 func resumeKubeadmControlPlane(ctx context.Context, proxy cluster.Proxy, name, namespace string) error {
 	// In the paused annotation we must replace slashes to ~1, see https://datatracker.ietf.org/doc/html/rfc6901#section-3.
@@ -19,6 +19,7 @@ package alpha
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -37,10 +38,11 @@ func Test_ObjectResumer(t *testing.T) {
 		ref  corev1.ObjectReference
 	}
 	tests := []struct {
-		name       string
-		fields     fields
-		wantErr    bool
-		wantPaused bool
+		name                 string
+		fields               fields
+		wantErr              bool
+		wantPaused           bool
+		wantResumeAnnotation string
 	}{
 		{
 			name: "paused machinedeployment should be unpaused",
@@ -94,6 +96,97 @@ func Test_ObjectResumer(t *testing.T) {
 			wantErr:    true,
 			wantPaused: false,
 		},
+		{
+			name: "machinedeployment with a rollout paused at a pause step should be resumed",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachineDeployment{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachineDeployment",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "md-1",
+						},
+						Status: clusterv1.MachineDeploymentStatus{
+							Rollout: &clusterv1.MachineDeploymentRolloutStatus{
+								MachineSetName:   "ms-1",
+								CurrentStepIndex: 2,
+								PausedAt:         &metav1.Time{Time: time.Now()},
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "md-1",
+					Namespace: "default",
+				},
+			},
+			wantErr:              false,
+			wantPaused:           false,
+			wantResumeAnnotation: "2",
+		},
+		{
+			name: "machinedeployment with an aborted rollout should be resumed",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachineDeployment{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachineDeployment",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "md-1",
+						},
+						Status: clusterv1.MachineDeploymentStatus{
+							Rollout: &clusterv1.MachineDeploymentRolloutStatus{
+								MachineSetName:   "ms-1",
+								CurrentStepIndex: 1,
+								Aborted:          true,
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "md-1",
+					Namespace: "default",
+				},
+			},
+			wantErr:              false,
+			wantPaused:           false,
+			wantResumeAnnotation: "1",
+		},
+		{
+			name: "resuming a machinedeployment with a rollout in progress should return error",
+			fields: fields{
+				objs: []client.Object{
+					&clusterv1.MachineDeployment{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "MachineDeployment",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "default",
+							Name:      "md-1",
+						},
+						Status: clusterv1.MachineDeploymentStatus{
+							Rollout: &clusterv1.MachineDeploymentRolloutStatus{
+								MachineSetName:   "ms-1",
+								CurrentStepIndex: 0,
+							},
+						},
+					},
+				},
+				ref: corev1.ObjectReference{
+					Kind:      MachineDeployment,
+					Name:      "md-1",
+					Namespace: "default",
+				},
+			},
+			wantErr:    true,
+			wantPaused: false,
+		},
 		{
 			name: "paused kubeadmcontrolplane should be unpaused",
 			fields: fields{
@@ -165,6 +258,7 @@ func Test_ObjectResumer(t *testing.T) {
 					err = cl.Get(context.TODO(), key, md)
 					g.Expect(err).ToNot(HaveOccurred())
 					g.Expect(md.Spec.Paused).To(Equal(tt.wantPaused))
+					g.Expect(md.Annotations[clusterv1.RolloutResumeAnnotation]).To(Equal(tt.wantResumeAnnotation))
 				case *controlplanev1.KubeadmControlPlane:
 					kcp := &controlplanev1.KubeadmControlPlane{}
 					err = cl.Get(context.TODO(), key, kcp)
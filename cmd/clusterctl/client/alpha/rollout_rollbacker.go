@@ -25,7 +25,10 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/version"
 )
 
 // ObjectRollbacker will issue a rollback on the specified cluster-api resource.
@@ -42,6 +45,17 @@ func (r *rollout) ObjectRollbacker(ctx context.Context, proxy cluster.Proxy, ref
 		if err := rollbackMachineDeployment(ctx, proxy, deployment, toRevision); err != nil {
 			return err
 		}
+	case KubeadmControlPlane:
+		kcp, err := getKubeadmControlPlane(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || kcp == nil {
+			return errors.Wrapf(err, "failed to get %v/%v", ref.Kind, ref.Name)
+		}
+		if annotations.HasPaused(kcp) {
+			return errors.Errorf("can't rollback a paused KubeadmControlPlane: please run 'clusterctl rollout resume %v/%v' first", ref.Kind, ref.Name)
+		}
+		if err := rollbackKubeadmControlPlane(ctx, proxy, kcp, toRevision); err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validRollbackResourceTypes)
 	}
@@ -80,3 +94,53 @@ func rollbackMachineDeployment(ctx context.Context, proxy cluster.Proxy, md *clu
 	md.Spec.Template = revMSTemplate
 	return patchHelper.Patch(ctx, md)
 }
+
+// rollbackKubeadmControlPlane will rollback to a previous revision recorded in the revision history of a KubeadmControlPlane.
+func rollbackKubeadmControlPlane(ctx context.Context, proxy cluster.Proxy, kcp *controlplanev1.KubeadmControlPlane, toRevision int64) error {
+	log := logf.Log
+	c, err := proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	if toRevision < 0 {
+		return errors.Errorf("revision number cannot be negative: %v", toRevision)
+	}
+	revisions, err := getControllerRevisionsForKubeadmControlPlane(ctx, proxy, kcp)
+	if err != nil {
+		return err
+	}
+	log.V(7).Info("Found ControllerRevisions", "count", len(revisions))
+	revisionForRollback, err := findKubeadmControlPlaneRevision(toRevision, revisions)
+	if err != nil {
+		return err
+	}
+	log.V(7).Info("Found revision", "revision", revisionForRollback.Revision)
+	revisionKCP, err := kubeadmControlPlaneFromRevision(revisionForRollback)
+	if err != nil {
+		return err
+	}
+	// Restoring a revision with a Kubernetes version older than the current one would downgrade the control plane,
+	// which is not supported.
+	currentVersion, err := version.ParseMajorMinorPatchTolerant(kcp.Spec.Version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse version %q of KubeadmControlPlane %s", kcp.Spec.Version, kcp.Name)
+	}
+	revisionVersion, err := version.ParseMajorMinorPatchTolerant(revisionKCP.Spec.Version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse version %q of revision %d", revisionKCP.Spec.Version, revisionForRollback.Revision)
+	}
+	if revisionVersion.LT(currentVersion) {
+		return errors.Errorf("can't rollback KubeadmControlPlane %s to revision %d: the revision has version %s, which is older than the current version %s, and downgrading the control plane is not supported",
+			kcp.Name, revisionForRollback.Revision, revisionKCP.Spec.Version, kcp.Spec.Version)
+	}
+	patchHelper, err := patch.NewHelper(kcp, c)
+	if err != nil {
+		return err
+	}
+	// Copy the fields recorded in the revision into the KubeadmControlPlane.
+	kcp.Spec.Version = revisionKCP.Spec.Version
+	kcp.Spec.MachineTemplate.InfrastructureRef = revisionKCP.Spec.MachineTemplate.InfrastructureRef
+	kcp.Spec.KubeadmConfigSpec = revisionKCP.Spec.KubeadmConfigSpec
+	return patchHelper.Patch(ctx, kcp)
+}
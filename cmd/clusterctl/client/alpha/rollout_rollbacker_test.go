@@ -18,16 +18,21 @@ package alpha
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 )
 
 func Test_ObjectRollbacker(t *testing.T) {
@@ -259,3 +264,210 @@ func Test_ObjectRollbacker(t *testing.T) {
 		})
 	}
 }
+
+func Test_ObjectRollbacker_KubeadmControlPlane(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-kcp",
+			Namespace: "default",
+			UID:       "test-kcp-uid",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.19.3",
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+					Kind:       "InfrastructureMachineTemplate",
+					Name:       "kcp-template-3",
+				},
+			},
+		},
+	}
+	pausedKCP := kcp.DeepCopy()
+	pausedKCP.Annotations = map[string]string{clusterv1.PausedAnnotation: "true"}
+
+	type fields struct {
+		objs       []client.Object
+		ref        corev1.ObjectReference
+		toRevision int64
+	}
+	tests := []struct {
+		name              string
+		fields            fields
+		wantErr           bool
+		wantVersion       string
+		wantInfraTemplate string
+		wantFiles         []bootstrapv1.File
+	}{
+		{
+			name: "kubeadmcontrolplane should rollback to the previous revision",
+			fields: fields{
+				objs: []client.Object{
+					kcp,
+					kcpRevision(kcp, "kcp-rev-1", 1, "v1.19.1", "kcp-template-1"),
+					kcpRevision(kcp, "kcp-rev-2", 2, "v1.19.3", "kcp-template-2"),
+					kcpRevision(kcp, "kcp-rev-3", 3, "v1.19.3", "kcp-template-3"),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      KubeadmControlPlane,
+					Name:      "test-kcp",
+					Namespace: "default",
+				},
+			},
+			wantErr:           false,
+			wantVersion:       "v1.19.3",
+			wantInfraTemplate: "kcp-template-2",
+			wantFiles:         []bootstrapv1.File{{Path: "/etc/kcp-template-2"}},
+		},
+		{
+			name: "kubeadmcontrolplane should rollback to revision=1",
+			fields: fields{
+				objs: []client.Object{
+					kcp,
+					kcpRevision(kcp, "kcp-rev-1", 1, "v1.19.3", "kcp-template-1"),
+					kcpRevision(kcp, "kcp-rev-2", 2, "v1.19.3", "kcp-template-2"),
+					kcpRevision(kcp, "kcp-rev-3", 3, "v1.19.3", "kcp-template-3"),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      KubeadmControlPlane,
+					Name:      "test-kcp",
+					Namespace: "default",
+				},
+				toRevision: int64(1),
+			},
+			wantErr:           false,
+			wantVersion:       "v1.19.3",
+			wantInfraTemplate: "kcp-template-1",
+			wantFiles:         []bootstrapv1.File{{Path: "/etc/kcp-template-1"}},
+		},
+		{
+			name: "kubeadmcontrolplane should fail to rollback to a revision with an older version",
+			fields: fields{
+				objs: []client.Object{
+					kcp,
+					kcpRevision(kcp, "kcp-rev-1", 1, "v1.19.1", "kcp-template-1"),
+					kcpRevision(kcp, "kcp-rev-2", 2, "v1.19.3", "kcp-template-2"),
+					kcpRevision(kcp, "kcp-rev-3", 3, "v1.19.3", "kcp-template-3"),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      KubeadmControlPlane,
+					Name:      "test-kcp",
+					Namespace: "default",
+				},
+				toRevision: int64(1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "kubeadmcontrolplane should fail to rollback to an unknown revision",
+			fields: fields{
+				objs: []client.Object{
+					kcp,
+					kcpRevision(kcp, "kcp-rev-1", 1, "v1.19.1", "kcp-template-1"),
+					kcpRevision(kcp, "kcp-rev-3", 3, "v1.19.3", "kcp-template-3"),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      KubeadmControlPlane,
+					Name:      "test-kcp",
+					Namespace: "default",
+				},
+				toRevision: int64(2),
+			},
+			wantErr: true,
+		},
+		{
+			name: "kubeadmcontrolplane should fail to rollback without a previous revision",
+			fields: fields{
+				objs: []client.Object{
+					kcp,
+					kcpRevision(kcp, "kcp-rev-3", 3, "v1.19.3", "kcp-template-3"),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      KubeadmControlPlane,
+					Name:      "test-kcp",
+					Namespace: "default",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "paused kubeadmcontrolplane should fail to rollback",
+			fields: fields{
+				objs: []client.Object{
+					pausedKCP,
+					kcpRevision(kcp, "kcp-rev-1", 1, "v1.19.1", "kcp-template-1"),
+					kcpRevision(kcp, "kcp-rev-3", 3, "v1.19.3", "kcp-template-3"),
+				},
+				ref: corev1.ObjectReference{
+					Kind:      KubeadmControlPlane,
+					Name:      "test-kcp",
+					Namespace: "default",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.fields.objs...)
+			err := r.ObjectRollbacker(context.Background(), proxy, tt.fields.ref, tt.fields.toRevision)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			cl, err := proxy.NewClient()
+			g.Expect(err).ToNot(HaveOccurred())
+			key := client.ObjectKeyFromObject(kcp)
+			got := &controlplanev1.KubeadmControlPlane{}
+			err = cl.Get(context.TODO(), key, got)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Spec.Version).To(Equal(tt.wantVersion))
+			g.Expect(got.Spec.MachineTemplate.InfrastructureRef.Name).To(Equal(tt.wantInfraTemplate))
+			g.Expect(got.Spec.KubeadmConfigSpec.Files).To(Equal(tt.wantFiles))
+		})
+	}
+}
+
+// kcpRevision returns a ControllerRevision recording a revision of a KubeadmControlPlane,
+// the same way the KubeadmControlPlane controller does.
+func kcpRevision(kcp *controlplanev1.KubeadmControlPlane, name string, revision int64, version, infraTemplate string) *appsv1.ControllerRevision {
+	data, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"version": version,
+			"machineTemplate": map[string]interface{}{
+				"infrastructureRef": corev1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+					Kind:       "InfrastructureMachineTemplate",
+					Name:       infraTemplate,
+				},
+			},
+			"kubeadmConfigSpec": bootstrapv1.KubeadmConfigSpec{
+				Files: []bootstrapv1.File{{Path: "/etc/" + infraTemplate}},
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: kcp.Namespace,
+			Labels: map[string]string{
+				clusterv1.MachineControlPlaneNameLabel: kcp.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane")),
+			},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: revision,
+	}
+}
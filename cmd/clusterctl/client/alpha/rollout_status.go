@@ -0,0 +1,110 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+)
+
+// ObjectStatusViewer returns the status of the rollout of the specified cluster-api resource.
+func (r *rollout) ObjectStatusViewer(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) (*RolloutStatus, error) {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to get %v/%v", ref.Kind, ref.Name)
+		}
+		return machineDeploymentRolloutStatus(deployment)
+	case KubeadmControlPlane:
+		kcp, err := getKubeadmControlPlane(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || kcp == nil {
+			return nil, errors.Wrapf(err, "failed to get %v/%v", ref.Kind, ref.Name)
+		}
+		return kubeadmControlPlaneRolloutStatus(kcp)
+	default:
+		return nil, errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validResourceTypes)
+	}
+}
+
+// machineDeploymentRolloutStatus returns the status of the rollout of a MachineDeployment.
+func machineDeploymentRolloutStatus(md *clusterv1.MachineDeployment) (*RolloutStatus, error) {
+	if md.Spec.Paused {
+		return nil, errors.Errorf("MachineDeployment %s/%s is paused: please run 'clusterctl alpha rollout resume machinedeployment/%s' first", md.Namespace, md.Name, md.Name)
+	}
+	if md.Generation > md.Status.ObservedGeneration {
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for MachineDeployment %q spec update to be observed...", md.Name)}, nil
+	}
+	if rollout := md.Status.Rollout; rollout != nil {
+		if rollout.Aborted {
+			return nil, errors.Errorf("rollout of MachineDeployment %s/%s has been aborted at step %d", md.Namespace, md.Name, rollout.CurrentStepIndex)
+		}
+		if rollout.PausedAt != nil {
+			return &RolloutStatus{Message: fmt.Sprintf("Waiting for MachineDeployment %q rollout to be resumed at step %d...", md.Name, rollout.CurrentStepIndex)}, nil
+		}
+	}
+
+	replicas := int32(1)
+	if md.Spec.Replicas != nil {
+		replicas = *md.Spec.Replicas
+	}
+	status := md.Status
+	progress := fmt.Sprintf("%d updated, %d ready, %d available out of %d desired replicas", status.UpdatedReplicas, status.ReadyReplicas, status.AvailableReplicas, replicas)
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for MachineDeployment %q rollout to finish: %s...", md.Name, progress)}, nil
+	case status.Replicas > status.UpdatedReplicas:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for MachineDeployment %q rollout to finish: %d old replicas are pending termination...", md.Name, status.Replicas-status.UpdatedReplicas)}, nil
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for MachineDeployment %q rollout to finish: %s...", md.Name, progress)}, nil
+	}
+	return &RolloutStatus{Message: fmt.Sprintf("MachineDeployment %q successfully rolled out: %s", md.Name, progress), Done: true}, nil
+}
+
+// kubeadmControlPlaneRolloutStatus returns the status of the rollout of a KubeadmControlPlane.
+func kubeadmControlPlaneRolloutStatus(kcp *controlplanev1.KubeadmControlPlane) (*RolloutStatus, error) {
+	if annotations.HasPaused(kcp) {
+		return nil, errors.Errorf("KubeadmControlPlane %s/%s is paused: please run 'clusterctl alpha rollout resume kubeadmcontrolplane/%s' first", kcp.Namespace, kcp.Name, kcp.Name)
+	}
+	if kcp.Generation > kcp.Status.ObservedGeneration {
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for KubeadmControlPlane %q spec update to be observed...", kcp.Name)}, nil
+	}
+
+	replicas := int32(1)
+	if kcp.Spec.Replicas != nil {
+		replicas = *kcp.Spec.Replicas
+	}
+	status := kcp.Status
+	progress := fmt.Sprintf("%d updated, %d ready out of %d desired replicas", status.UpdatedReplicas, status.ReadyReplicas, replicas)
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for KubeadmControlPlane %q rollout to finish: %s...", kcp.Name, progress)}, nil
+	case status.Replicas > status.UpdatedReplicas:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for KubeadmControlPlane %q rollout to finish: %d old replicas are pending termination...", kcp.Name, status.Replicas-status.UpdatedReplicas)}, nil
+	case status.ReadyReplicas < status.UpdatedReplicas || status.UnavailableReplicas > 0:
+		return &RolloutStatus{Message: fmt.Sprintf("Waiting for KubeadmControlPlane %q rollout to finish: %s...", kcp.Name, progress)}, nil
+	}
+	return &RolloutStatus{Message: fmt.Sprintf("KubeadmControlPlane %q successfully rolled out: %s", kcp.Name, progress), Done: true}, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+func Test_ObjectStatusViewer(t *testing.T) {
+	machineDeployment := func(paused bool, status clusterv1.MachineDeploymentStatus) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "MachineDeployment",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "md-1",
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: pointer.Int32(3),
+				Paused:   paused,
+			},
+			Status: status,
+		}
+	}
+	kubeadmControlPlane := func(annotations map[string]string, status controlplanev1.KubeadmControlPlaneStatus) *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "KubeadmControlPlane",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "default",
+				Name:        "kcp",
+				Annotations: annotations,
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Replicas: pointer.Int32(3),
+			},
+			Status: status,
+		}
+	}
+	mdRef := corev1.ObjectReference{
+		Kind:      MachineDeployment,
+		Name:      "md-1",
+		Namespace: "default",
+	}
+	kcpRef := corev1.ObjectReference{
+		Kind:      KubeadmControlPlane,
+		Name:      "kcp",
+		Namespace: "default",
+	}
+
+	tests := []struct {
+		name        string
+		obj         client.Object
+		ref         corev1.ObjectReference
+		wantErr     bool
+		wantDone    bool
+		wantMessage string
+	}{
+		{
+			name:        "machinedeployment with replicas to update should not be done",
+			obj:         machineDeployment(false, clusterv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 1, ReadyReplicas: 3, AvailableReplicas: 3}),
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" rollout to finish: 1 updated, 3 ready, 3 available out of 3 desired replicas...`,
+		},
+		{
+			name:        "machinedeployment with old replicas should not be done",
+			obj:         machineDeployment(false, clusterv1.MachineDeploymentStatus{Replicas: 4, UpdatedReplicas: 3, ReadyReplicas: 4, AvailableReplicas: 4}),
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" rollout to finish: 1 old replicas are pending termination...`,
+		},
+		{
+			name:        "machinedeployment with unavailable replicas should not be done",
+			obj:         machineDeployment(false, clusterv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 2, AvailableReplicas: 2}),
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" rollout to finish: 3 updated, 2 ready, 2 available out of 3 desired replicas...`,
+		},
+		{
+			name:        "machinedeployment paused at a rollout step should not be done",
+			obj:         machineDeployment(false, clusterv1.MachineDeploymentStatus{Rollout: &clusterv1.MachineDeploymentRolloutStatus{CurrentStepIndex: 1, PausedAt: &metav1.Time{Time: time.Now()}}}),
+			ref:         mdRef,
+			wantMessage: `Waiting for MachineDeployment "md-1" rollout to be resumed at step 1...`,
+		},
+		{
+			name:        "machinedeployment with all replicas updated and available should be done",
+			obj:         machineDeployment(false, clusterv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}),
+			ref:         mdRef,
+			wantDone:    true,
+			wantMessage: `MachineDeployment "md-1" successfully rolled out: 3 updated, 3 ready, 3 available out of 3 desired replicas`,
+		},
+		{
+			name:    "paused machinedeployment should return error",
+			obj:     machineDeployment(true, clusterv1.MachineDeploymentStatus{}),
+			ref:     mdRef,
+			wantErr: true,
+		},
+		{
+			name:    "machinedeployment with an aborted rollout should return error",
+			obj:     machineDeployment(false, clusterv1.MachineDeploymentStatus{Rollout: &clusterv1.MachineDeploymentRolloutStatus{Aborted: true}}),
+			ref:     mdRef,
+			wantErr: true,
+		},
+		{
+			name:        "kubeadmcontrolplane with replicas to update should not be done",
+			obj:         kubeadmControlPlane(nil, controlplanev1.KubeadmControlPlaneStatus{Replicas: 4, UpdatedReplicas: 1, ReadyReplicas: 4}),
+			ref:         kcpRef,
+			wantMessage: `Waiting for KubeadmControlPlane "kcp" rollout to finish: 1 updated, 4 ready out of 3 desired replicas...`,
+		},
+		{
+			name:        "kubeadmcontrolplane with unavailable replicas should not be done",
+			obj:         kubeadmControlPlane(nil, controlplanev1.KubeadmControlPlaneStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 2, UnavailableReplicas: 1}),
+			ref:         kcpRef,
+			wantMessage: `Waiting for KubeadmControlPlane "kcp" rollout to finish: 3 updated, 2 ready out of 3 desired replicas...`,
+		},
+		{
+			name:        "kubeadmcontrolplane with all replicas updated and ready should be done",
+			obj:         kubeadmControlPlane(nil, controlplanev1.KubeadmControlPlaneStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3}),
+			ref:         kcpRef,
+			wantDone:    true,
+			wantMessage: `KubeadmControlPlane "kcp" successfully rolled out: 3 updated, 3 ready out of 3 desired replicas`,
+		},
+		{
+			name:    "paused kubeadmcontrolplane should return error",
+			obj:     kubeadmControlPlane(map[string]string{clusterv1.PausedAnnotation: "true"}, controlplanev1.KubeadmControlPlaneStatus{}),
+			ref:     kcpRef,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.obj)
+			status, err := r.ObjectStatusViewer(context.Background(), proxy, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(status.Done).To(Equal(tt.wantDone))
+			g.Expect(status.Message).To(Equal(tt.wantMessage))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// BackupOptions carries the options supported by Backup.
+type BackupOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Archive is the path of the backup archive to be created.
+	Archive string
+}
+
+// RestoreOptions carries the options supported by Restore.
+type RestoreOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the target management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Archive is the path of the backup archive to be restored.
+	Archive string
+}
+
+func (c *clusterctlClient) Backup(ctx context.Context, options BackupOptions) error {
+	if options.Archive == "" {
+		return errors.New("Archive must be set")
+	}
+
+	fromCluster, err := c.getClusterClient(ctx, options.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	return fromCluster.ObjectMover().ToArchive(ctx, options.Archive)
+}
+
+func (c *clusterctlClient) Restore(ctx context.Context, options RestoreOptions) error {
+	if options.Archive == "" {
+		return errors.New("Archive must be set")
+	}
+
+	toCluster, err := c.getClusterClient(ctx, options.Kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(options.Archive); err != nil {
+		return err
+	}
+
+	return toCluster.ObjectMover().FromArchive(ctx, toCluster, options.Archive)
+}
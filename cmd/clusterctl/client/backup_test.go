@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_clusterctlClient_Backup(t *testing.T) {
+	// These tests are checking the Backup scaffolding
+	// The internal library handles the backup logic and tests can be found there
+	tests := []struct {
+		name    string
+		options BackupOptions
+		wantErr bool
+	}{
+		{
+			name: "does not return error if cluster client is found",
+			options: BackupOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Archive:    filepath.Join(t.TempDir(), "backup.tar.gz"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if cluster client is not found",
+			options: BackupOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "does-not-exist"},
+				Archive:    filepath.Join(t.TempDir(), "backup.tar.gz"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the archive is not set",
+			options: BackupOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := fakeClientForMove().Backup(context.Background(), tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func Test_clusterctlClient_Restore(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	if err := os.WriteFile(archive, []byte{}, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// These tests are checking the Restore scaffolding
+	// The internal library handles the restore logic and tests can be found there
+	tests := []struct {
+		name    string
+		options RestoreOptions
+		wantErr bool
+	}{
+		{
+			name: "does not return error if cluster client is found",
+			options: RestoreOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Archive:    archive,
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if cluster client is not found",
+			options: RestoreOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "does-not-exist"},
+				Archive:    archive,
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the archive does not exist",
+			options: RestoreOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Archive:    filepath.Join(t.TempDir(), "does-not-exist.tar.gz"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := fakeClientForMove().Restore(context.Background(), tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
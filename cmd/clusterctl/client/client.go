@@ -56,6 +56,12 @@ type Client interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
 	Move(ctx context.Context, options MoveOptions) error
 
+	// Backup writes all the Cluster API objects existing in a management cluster, and its provider inventory, to a backup archive.
+	Backup(ctx context.Context, options BackupOptions) error
+
+	// Restore restores all the Cluster API objects existing in a backup archive to a management cluster.
+	Restore(ctx context.Context, options RestoreOptions) error
+
 	// PlanUpgrade returns a set of suggested Upgrade plans for the cluster.
 	PlanUpgrade(ctx context.Context, options PlanUpgradeOptions) ([]UpgradePlan, error)
 
@@ -72,6 +78,9 @@ type Client interface {
 	// DescribeCluster returns the object tree representing the status of a Cluster API cluster.
 	DescribeCluster(ctx context.Context, options DescribeClusterOptions) (*tree.ObjectTree, error)
 
+	// DescribeClusters returns a summary of the status of all the Cluster API clusters in a management cluster.
+	DescribeClusters(ctx context.Context, options DescribeClustersOptions) ([]tree.ClusterSummary, error)
+
 	// AlphaClient is an Interface for alpha features in clusterctl
 	AlphaClient
 }
@@ -86,8 +95,14 @@ type AlphaClient interface {
 	RolloutResume(ctx context.Context, options RolloutResumeOptions) error
 	// RolloutUndo provides rollout rollback of cluster-api resources
 	RolloutUndo(ctx context.Context, options RolloutUndoOptions) error
+	// RolloutHistory provides the rollout history of cluster-api resources
+	RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutHistory, error)
+	// RolloutStatus provides the rollout status of cluster-api resources, optionally waiting for the rollout to finish
+	RolloutStatus(ctx context.Context, options RolloutStatusOptions) error
 	// TopologyPlan dry runs the topology reconciler
 	TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*TopologyPlanOutput, error)
+	// TopologyRenderPatches dry runs the topology reconciler and returns the patches generated by the ClusterClass patches
+	TopologyRenderPatches(ctx context.Context, options TopologyRenderPatchesOptions) (*TopologyRenderPatchesOutput, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
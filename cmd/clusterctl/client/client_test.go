@@ -111,6 +111,14 @@ func (f fakeClient) Move(ctx context.Context, options MoveOptions) error {
 	return f.internalClient.Move(ctx, options)
 }
 
+func (f fakeClient) Backup(ctx context.Context, options BackupOptions) error {
+	return f.internalClient.Backup(ctx, options)
+}
+
+func (f fakeClient) Restore(ctx context.Context, options RestoreOptions) error {
+	return f.internalClient.Restore(ctx, options)
+}
+
 func (f fakeClient) PlanUpgrade(ctx context.Context, options PlanUpgradeOptions) ([]UpgradePlan, error) {
 	return f.internalClient.PlanUpgrade(ctx, options)
 }
@@ -135,6 +143,10 @@ func (f fakeClient) DescribeCluster(ctx context.Context, options DescribeCluster
 	return f.internalClient.DescribeCluster(ctx, options)
 }
 
+func (f fakeClient) DescribeClusters(ctx context.Context, options DescribeClustersOptions) ([]tree.ClusterSummary, error) {
+	return f.internalClient.DescribeClusters(ctx, options)
+}
+
 func (f fakeClient) RolloutPause(ctx context.Context, options RolloutPauseOptions) error {
 	return f.internalClient.RolloutPause(ctx, options)
 }
@@ -147,10 +159,22 @@ func (f fakeClient) RolloutUndo(ctx context.Context, options RolloutUndoOptions)
 	return f.internalClient.RolloutUndo(ctx, options)
 }
 
+func (f fakeClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutHistory, error) {
+	return f.internalClient.RolloutHistory(ctx, options)
+}
+
+func (f fakeClient) RolloutStatus(ctx context.Context, options RolloutStatusOptions) error {
+	return f.internalClient.RolloutStatus(ctx, options)
+}
+
 func (f fakeClient) TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*cluster.TopologyPlanOutput, error) {
 	return f.internalClient.TopologyPlan(ctx, options)
 }
 
+func (f fakeClient) TopologyRenderPatches(ctx context.Context, options TopologyRenderPatchesOptions) (*cluster.TopologyRenderPatchesOutput, error) {
+	return f.internalClient.TopologyRenderPatches(ctx, options)
+}
+
 // newFakeClient returns a clusterctl client that allows to execute tests on a set of fake config, fake repositories and fake clusters.
 // you can use WithCluster and WithRepository to prepare for the test case.
 func newFakeClient(ctx context.Context, configClient config.Client) *fakeClient {
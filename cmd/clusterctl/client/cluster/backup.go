@@ -0,0 +1,370 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+	"sigs.k8s.io/cluster-api/version"
+)
+
+const (
+	// backupFormatVersion is the version of the format of the backup archives written by clusterctl.
+	// NOTE: the version must be bumped when introducing changes that can't be read by older versions of clusterctl.
+	backupFormatVersion = "v1"
+
+	// backupManifestFile is the name of the file storing the backup manifest in a backup archive.
+	backupManifestFile = "manifest.yaml"
+
+	// backupObjectsDir is the name of the directory storing the Kubernetes objects in a backup archive.
+	backupObjectsDir = "objects"
+)
+
+// backupManifest describes the content of a backup archive.
+type backupManifest struct {
+	// FormatVersion is the version of the format of the backup archive.
+	FormatVersion string `json:"formatVersion"`
+
+	// ClusterctlVersion is the version of clusterctl used for creating the backup archive.
+	ClusterctlVersion string `json:"clusterctlVersion,omitempty"`
+
+	// CreationTimestamp is the time the backup archive was created.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	// Providers is the provider inventory of the management cluster at the time of the backup.
+	Providers []backupProvider `json:"providers,omitempty"`
+
+	// Namespaces is the list of namespaces with objects in the backup archive.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Clusters is the list of Clusters in the backup archive.
+	Clusters []moveJournalObject `json:"clusters,omitempty"`
+
+	// ClusterClasses is the list of ClusterClasses in the backup archive.
+	ClusterClasses []moveJournalObject `json:"clusterClasses,omitempty"`
+
+	// Objects is the list of objects in the backup archive, in the order they have to be restored.
+	Objects []backupObject `json:"objects,omitempty"`
+}
+
+// backupProvider is an entry of the provider inventory recorded in the backup manifest.
+type backupProvider struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Version   string `json:"version"`
+	Namespace string `json:"namespace"`
+}
+
+// backupObject is a reference to an object recorded in the backup manifest.
+type backupObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+
+	// File is the path of the file storing the object in the backup archive.
+	File string `json:"file"`
+}
+
+func (o *objectMover) ToArchive(ctx context.Context, archive string) error {
+	log := logf.Log
+	log.Info("Backing up to archive...")
+
+	// Gets the object graph for all the namespaces.
+	objectGraph, err := o.getObjectGraph(ctx, "", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to get object graph")
+	}
+
+	providers, err := o.fromProviderInventory.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the provider inventory")
+	}
+
+	return o.toArchive(ctx, objectGraph, providers.Items, archive)
+}
+
+func (o *objectMover) FromArchive(ctx context.Context, toCluster Client, archive string) error {
+	log := logf.Log
+	log.Info("Restoring from archive...")
+
+	manifest, objs, err := readBackupArchive(archive)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read backup archive %s", archive)
+	}
+
+	// Ensures all the providers in the backup are installed in the target management cluster, otherwise the restored objects
+	// can't be reconciled.
+	if err := checkBackupProviders(ctx, manifest, toCluster.ProviderInventory()); err != nil {
+		return err
+	}
+
+	// Build an empty object graph used for the restore sequence not tied to a specific namespace
+	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
+
+	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
+	if err := objectGraph.getDiscoveryTypes(ctx); err != nil {
+		return errors.Wrap(err, "failed to retrieve discovery types")
+	}
+
+	log.Info(fmt.Sprintf("Restoring %d objects created at %s", len(objs), manifest.CreationTimestamp.Format(time.RFC3339)))
+	return o.restoreObjs(ctx, objectGraph, objs, toCluster.Proxy())
+}
+
+func (o *objectMover) toArchive(ctx context.Context, graph *objectGraph, providers []clusterctlv1.Provider, archive string) (reterr error) {
+	log := logf.Log
+
+	clusters := graph.getClusters()
+	log.Info("Starting backup of Cluster API objects", "Clusters", len(clusters))
+
+	clusterClasses := graph.getClusterClasses()
+	log.Info("Backing up Cluster API objects", "ClusterClasses", len(clusterClasses))
+
+	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
+	log.V(1).Info("Pausing the source cluster")
+	if err := setClusterPause(ctx, o.fromProxy, clusters, true, o.dryRun); err != nil {
+		return err
+	}
+
+	log.V(1).Info("Pausing the source ClusterClasses")
+	if err := setClusterClassPause(ctx, o.fromProxy, clusterClasses, true, o.dryRun); err != nil {
+		return errors.Wrap(err, "error pausing ClusterClasses")
+	}
+
+	// Resume the ClusterClasses and the Clusters in the source management cluster once the backup is completed, no matter
+	// if it succeeded or not, because the source management cluster is still the one managing the Clusters.
+	defer func() {
+		log.V(1).Info("Resuming the source ClusterClasses")
+		if err := setClusterClassPause(ctx, o.fromProxy, clusterClasses, false, o.dryRun); err != nil && reterr == nil {
+			reterr = errors.Wrap(err, "error resuming ClusterClasses")
+		}
+
+		log.V(1).Info("Resuming the source cluster")
+		if err := setClusterPause(ctx, o.fromProxy, clusters, false, o.dryRun); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	manifest := &backupManifest{
+		FormatVersion:     backupFormatVersion,
+		ClusterctlVersion: version.Get().GitVersion,
+		CreationTimestamp: metav1.Now(),
+		Clusters:          nodesToJournalObjects(clusters),
+		ClusterClasses:    nodesToJournalObjects(clusterClasses),
+	}
+	for _, p := range providers {
+		manifest.Providers = append(manifest.Providers, backupProvider{
+			Name:      p.ProviderName,
+			Type:      p.Type,
+			Version:   p.Version,
+			Namespace: p.Namespace,
+		})
+	}
+
+	// Define the backup sequence by processing the ownerReference chain, so the objects are recorded in the manifest in the
+	// same order they will be restored, that is an object after its owners.
+	moveSequence := getMoveSequence(graph)
+
+	log.Info(fmt.Sprintf("Saving archive to %s", archive))
+	f, err := os.OpenFile(archive, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create backup archive %s", archive)
+	}
+	defer func() {
+		if reterr != nil {
+			_ = os.Remove(archive)
+		}
+	}()
+	defer f.Close()
+
+	gzipWriter := gzip.NewWriter(f)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	namespaces := sets.Set[string]{}
+	for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+		for _, n := range moveSequence.getGroup(groupIndex) {
+			log.V(1).Info("Saving", n.identity.Kind, n.identity.Name, "Namespace", n.identity.Namespace)
+
+			byObj, err := o.getSourceObjectJSON(ctx, n)
+			if err != nil {
+				return err
+			}
+
+			file := path.Join(backupObjectsDir, n.getFilename())
+			if err := writeArchiveFile(tarWriter, file, byObj); err != nil {
+				return err
+			}
+
+			manifest.Objects = append(manifest.Objects, backupObject{
+				APIVersion: n.identity.APIVersion,
+				Kind:       n.identity.Kind,
+				Namespace:  n.identity.Namespace,
+				Name:       n.identity.Name,
+				File:       file,
+			})
+			if n.identity.Namespace != "" {
+				namespaces.Insert(n.identity.Namespace)
+			}
+		}
+	}
+	manifest.Namespaces = sets.List(namespaces)
+
+	byManifest, err := yaml.Marshal(manifest)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode backup manifest")
+	}
+	if err := writeArchiveFile(tarWriter, backupManifestFile, byManifest); err != nil {
+		return err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write backup archive %s", archive)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write backup archive %s", archive)
+	}
+	return nil
+}
+
+// writeArchiveFile writes a file into a tar archive.
+func writeArchiveFile(w *tar.Writer, name string, content []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}
+	if err := w.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "failed to write %s to the backup archive", name)
+	}
+	if _, err := w.Write(content); err != nil {
+		return errors.Wrapf(err, "failed to write %s to the backup archive", name)
+	}
+	return nil
+}
+
+// readBackupArchive reads the manifest and the objects from a backup archive.
+func readBackupArchive(archive string) (*backupManifest, []unstructured.Unstructured, error) {
+	f, err := os.Open(archive) //nolint:gosec // The archive is provided by the user.
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gzipReader.Close()
+
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, nil, err
+		}
+		files[header.Name] = content
+	}
+
+	rawManifest, ok := files[backupManifestFile]
+	if !ok {
+		return nil, nil, errors.Errorf("%s not found", backupManifestFile)
+	}
+	manifest := &backupManifest{}
+	if err := yaml.UnmarshalStrict(rawManifest, manifest); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to decode %s", backupManifestFile)
+	}
+	if manifest.FormatVersion != backupFormatVersion {
+		return nil, nil, errors.Errorf("unsupported backup format version %q, this version of clusterctl supports only %q", manifest.FormatVersion, backupFormatVersion)
+	}
+
+	rawYAMLs := make([][]byte, 0, len(manifest.Objects))
+	for _, obj := range manifest.Objects {
+		content, ok := files[obj.File]
+		if !ok {
+			return nil, nil, errors.Errorf("file %s for %s %s/%s not found", obj.File, obj.Kind, obj.Namespace, obj.Name)
+		}
+		rawYAMLs = append(rawYAMLs, content)
+	}
+
+	objs, err := utilyaml.ToUnstructured(utilyaml.JoinYaml(rawYAMLs...))
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, objs, nil
+}
+
+// checkBackupProviders checks all the providers recorded in the backup manifest are installed in the target management cluster.
+func checkBackupProviders(ctx context.Context, manifest *backupManifest, toInventory InventoryClient) error {
+	log := logf.Log
+
+	providerList, err := toInventory.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the provider inventory of the target management cluster")
+	}
+
+	missing := []string{}
+	for _, p := range manifest.Providers {
+		found := false
+		for _, installed := range providerList.Items {
+			if installed.ProviderName != p.Name || installed.Type != p.Type {
+				continue
+			}
+			found = true
+			if installed.Version != p.Version {
+				log.Info(fmt.Sprintf("Warning: %s is installed in the target management cluster with version %s, the backup was created with version %s", clusterctlv1.ManifestLabel(p.Name, clusterctlv1.ProviderType(p.Type)), installed.Version, p.Version))
+			}
+		}
+		if !found {
+			missing = append(missing, fmt.Sprintf("%s:%s", clusterctlv1.ManifestLabel(p.Name, clusterctlv1.ProviderType(p.Type)), p.Version))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return errors.Errorf("the following providers must be installed in the target management cluster before restoring the backup, e.g. using clusterctl init: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_objectMover_toArchive_restore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := test.NewFakeCluster("ns1", "foo").Objs()
+	objs = append(objs, test.NewFakeCluster("ns2", "bar").Objs()...)
+
+	graph := getObjectGraphWithObjs(objs)
+	g.Expect(getFakeDiscoveryTypes(ctx, graph)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+	providers, err := graph.providerInventory.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	g.Expect(mover.toArchive(ctx, graph, providers.Items, archive)).To(Succeed())
+
+	// Check the Clusters in the source cluster are not paused after the backup.
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	cluster := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Paused).To(BeFalse())
+
+	// Check the manifest describes the content of the archive.
+	manifest, restoredObjs, err := readBackupArchive(archive)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(manifest.FormatVersion).To(Equal(backupFormatVersion))
+	g.Expect(manifest.Namespaces).To(Equal([]string{"ns1", "ns2"}))
+	g.Expect(manifest.Clusters).To(ConsistOf(moveJournalObject{Namespace: "ns1", Name: "foo"}, moveJournalObject{Namespace: "ns2", Name: "bar"}))
+	g.Expect(manifest.Providers).To(ConsistOf(backupProvider{Name: "infra1", Type: string(clusterctlv1.InfrastructureProviderType), Version: "v1.2.3", Namespace: "infra1-system"}))
+	g.Expect(manifest.Objects).To(HaveLen(len(graph.getMoveNodes())))
+	g.Expect(manifest.Objects[0].Kind).To(Equal("Cluster"))
+	g.Expect(restoredObjs).To(HaveLen(len(manifest.Objects)))
+
+	// Restore the archive into an empty cluster.
+	restoreGraph := getObjectGraph()
+	g.Expect(getFakeDiscoveryTypes(ctx, restoreGraph)).To(Succeed())
+	toProxy := getFakeProxyWithCRDs()
+	g.Expect(mover.restoreObjs(ctx, restoreGraph, restoredObjs, toProxy)).To(Succeed())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, node := range graph.getMoveNodes() {
+		key := client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed(), "%s %v not restored in the target cluster", node.identity.Kind, key)
+	}
+
+	// Check the restored Clusters are not paused.
+	cluster = &clusterv1.Cluster{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns2", Name: "bar"}, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Paused).To(BeFalse())
+}
+
+func Test_readBackupArchive(t *testing.T) {
+	g := NewWithT(t)
+
+	_, _, err := readBackupArchive(filepath.Join(t.TempDir(), "missing.tar.gz"))
+	g.Expect(err).To(HaveOccurred())
+
+	notAnArchive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	g.Expect(os.WriteFile(notAnArchive, []byte("foo"), 0600)).To(Succeed())
+	_, _, err = readBackupArchive(notAnArchive)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func Test_checkBackupProviders(t *testing.T) {
+	manifest := &backupManifest{
+		Providers: []backupProvider{
+			{Name: "cluster-api", Type: string(clusterctlv1.CoreProviderType), Version: "v1.5.0", Namespace: "capi-system"},
+			{Name: "infra1", Type: string(clusterctlv1.InfrastructureProviderType), Version: "v1.2.3", Namespace: "infra1-system"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		proxy   *test.FakeProxy
+		wantErr bool
+	}{
+		{
+			name: "all the providers are installed",
+			proxy: test.NewFakeProxy().
+				WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.5.0", "capi-system").
+				WithProviderInventory("infra1", clusterctlv1.InfrastructureProviderType, "v1.2.4", "infra1-system"),
+			wantErr: false,
+		},
+		{
+			name: "a provider is missing",
+			proxy: test.NewFakeProxy().
+				WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.5.0", "capi-system"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := checkBackupProviders(context.Background(), manifest, newInventoryClient(tt.proxy, fakePollImmediateWaiter))
+			if tt.wantErr {
+				g.Expect(err).To(MatchError(ContainSubstring("infrastructure-infra1:v1.2.3")))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
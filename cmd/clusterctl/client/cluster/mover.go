@@ -28,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -48,16 +49,63 @@ import (
 // ResourceMutatorFunc holds the type for mutators to be applied on resources during a move operation.
 type ResourceMutatorFunc func(u *unstructured.Unstructured) error
 
+// ClusterFilter defines the Clusters to be moved by a selective move.
+// A Cluster is selected if its name is included in Names or if its labels match Selector.
+type ClusterFilter struct {
+	// Names of the Clusters to be moved.
+	Names []string
+
+	// Selector for the labels of the Clusters to be moved.
+	Selector labels.Selector
+}
+
+// IsEmpty returns true if the filter does not select any Cluster, which means that all the Clusters should be moved.
+func (f ClusterFilter) IsEmpty() bool {
+	return len(f.Names) == 0 && (f.Selector == nil || f.Selector.Empty())
+}
+
+// matches returns true if a node referring to a Cluster is selected by the filter.
+func (f ClusterFilter) matches(cluster *node) bool {
+	for _, name := range f.Names {
+		if cluster.identity.Name == name {
+			return true
+		}
+	}
+	if f.Selector == nil || f.Selector.Empty() {
+		return false
+	}
+	clusterLabels, _ := cluster.additionalInfo[clusterLabelsKey].(labels.Set)
+	return f.Selector.Matches(clusterLabels)
+}
+
 // ObjectMover defines methods for moving Cluster API objects to another management cluster.
 type ObjectMover interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
 	Move(ctx context.Context, namespace string, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error
 
+	// MoveClusters moves the Clusters existing in a namespace (or from all the namespaces if empty) selected by a filter, and all the
+	// Cluster API objects belonging to them, to a target management cluster.
+	// Objects shared with other Clusters, e.g. ClusterClasses, and global objects, e.g. global identities, are copied instead of moved.
+	MoveClusters(ctx context.Context, namespace string, filter ClusterFilter, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error
+
 	// ToDirectory writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target directory.
 	ToDirectory(ctx context.Context, namespace string, directory string) error
 
 	// FromDirectory reads all the Cluster API objects existing in a configured directory to a target management cluster.
 	FromDirectory(ctx context.Context, toCluster Client, directory string) error
+
+	// ToArchive writes all the Cluster API objects existing in all the namespaces, and the provider inventory, to a backup archive.
+	ToArchive(ctx context.Context, archive string) error
+
+	// FromArchive restores all the Cluster API objects existing in a backup archive to a target management cluster.
+	FromArchive(ctx context.Context, toCluster Client, archive string) error
+
+	// Resume resumes a move operation that previously failed, picking up from the last step recorded in the move journal.
+	Resume(ctx context.Context, namespace string, toCluster Client, mutators ...ResourceMutatorFunc) error
+
+	// Rollback undoes a move operation that previously failed, deleting the objects already created in the target management
+	// cluster and un-pausing the source objects.
+	Rollback(ctx context.Context, namespace string, toCluster Client, mutators ...ResourceMutatorFunc) error
 }
 
 // objectMover implements the ObjectMover interface.
@@ -65,12 +113,19 @@ type objectMover struct {
 	fromProxy             Proxy
 	fromProviderInventory InventoryClient
 	dryRun                bool
+
+	// journal records the progress of the move; if nil, the progress is not recorded.
+	journal *moveJournal
 }
 
 // ensure objectMover implements the ObjectMover interface.
 var _ ObjectMover = &objectMover{}
 
 func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error {
+	return o.MoveClusters(ctx, namespace, ClusterFilter{}, toCluster, dryRun, mutators...)
+}
+
+func (o *objectMover) MoveClusters(ctx context.Context, namespace string, filter ClusterFilter, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error {
 	log := logf.Log
 	log.Info("Performing move...")
 	o.dryRun = dryRun
@@ -87,7 +142,23 @@ func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Clie
 		}
 	}
 
-	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	// checks there is no move journal left over by a previous move that failed.
+	if !o.dryRun {
+		journal, err := getMoveJournal(ctx, o.fromProxy, namespace)
+		if err != nil {
+			return err
+		}
+		if journal != nil {
+			return errMoveInProgress(namespace, journal)
+		}
+	}
+
+	var isSelected func(cluster *node) bool
+	if !filter.IsEmpty() {
+		isSelected = filter.matches
+	}
+
+	objectGraph, err := o.getObjectGraph(ctx, namespace, isSelected)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
@@ -96,16 +167,87 @@ func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Clie
 	var proxy Proxy
 	if !o.dryRun {
 		proxy = toCluster.Proxy()
+
+		// Starts recording the progress of the move, so it can be resumed or rolled back in case of failures.
+		o.journal, err = newMoveJournal(namespace, proxy, objectGraph.getClusters(), getMovedNodes(objectGraph.getClusterClasses()))
+		if err != nil {
+			return err
+		}
+		o.journal.Selective = isSelected != nil
+
+		// NOTE: the journal is created before moving any object, so concurrent moves for the same namespace,
+		// e.g. moves of different Clusters, are rejected.
+		if err := createMoveJournal(ctx, o.fromProxy, o.journal); err != nil {
+			return err
+		}
 	}
 
 	return o.move(ctx, objectGraph, proxy, mutators...)
 }
 
+func (o *objectMover) Resume(ctx context.Context, namespace string, toCluster Client, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+	log.Info("Resuming move...")
+
+	journal, err := getMoveJournal(ctx, o.fromProxy, namespace)
+	if err != nil {
+		return err
+	}
+	if journal == nil {
+		return errors.Errorf("failed to resume move: there is no move journal for namespace %q", namespace)
+	}
+
+	proxy := toCluster.Proxy()
+	if err := journal.checkTarget(proxy); err != nil {
+		return errors.Wrap(err, "failed to resume move")
+	}
+
+	if err := o.checkTargetProviders(ctx, toCluster.ProviderInventory()); err != nil {
+		return errors.Wrap(err, "failed to check providers in target cluster")
+	}
+
+	o.journal = journal
+	objectGraph, err := o.getObjectGraph(ctx, namespace, journal.clusterSelector())
+	if err != nil {
+		return errors.Wrap(err, "failed to get object graph")
+	}
+
+	return o.move(ctx, objectGraph, proxy, mutators...)
+}
+
+func (o *objectMover) Rollback(ctx context.Context, namespace string, toCluster Client, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+	log.Info("Rolling back move...")
+
+	journal, err := getMoveJournal(ctx, o.fromProxy, namespace)
+	if err != nil {
+		return err
+	}
+	if journal == nil {
+		return errors.Errorf("failed to rollback move: there is no move journal for namespace %q", namespace)
+	}
+	if journal.Phase == moveJournalPhaseDeleting {
+		return errors.New("failed to rollback move: objects are already being deleted from the source cluster; use resume to complete the move")
+	}
+
+	proxy := toCluster.Proxy()
+	if err := journal.checkTarget(proxy); err != nil {
+		return errors.Wrap(err, "failed to rollback move")
+	}
+
+	objectGraph, err := o.getObjectGraph(ctx, namespace, journal.clusterSelector())
+	if err != nil {
+		return errors.Wrap(err, "failed to get object graph")
+	}
+
+	return o.rollback(ctx, objectGraph, journal, proxy, mutators...)
+}
+
 func (o *objectMover) ToDirectory(ctx context.Context, namespace string, directory string) error {
 	log := logf.Log
 	log.Info("Moving to directory...")
 
-	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	objectGraph, err := o.getObjectGraph(ctx, namespace, nil)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
@@ -131,8 +273,14 @@ func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, direc
 		return errors.Wrap(err, "failed to process object files")
 	}
 
+	return o.restoreObjs(ctx, objectGraph, objs, toCluster.Proxy())
+}
+
+// restoreObjs restores objects read from a directory or from an archive to a target management cluster, using an
+// object graph rebuilt from the objects themselves to preserve the move ordering.
+func (o *objectMover) restoreObjs(ctx context.Context, objectGraph *objectGraph, objs []unstructured.Unstructured, toProxy Proxy) error {
 	for i := range objs {
-		if err = objectGraph.addRestoredObj(&objs[i]); err != nil {
+		if err := objectGraph.addRestoredObj(&objs[i]); err != nil {
 			return err
 		}
 	}
@@ -148,9 +296,7 @@ func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, direc
 	objectGraph.checkVirtualNode()
 
 	// Restore the objects to the target cluster.
-	proxy := toCluster.Proxy()
-
-	return o.fromDirectory(ctx, objectGraph, proxy)
+	return o.fromDirectory(ctx, objectGraph, toProxy)
 }
 
 func (o *objectMover) filesToObjs(dir string) ([]unstructured.Unstructured, error) {
@@ -184,7 +330,9 @@ func (o *objectMover) filesToObjs(dir string) ([]unstructured.Unstructured, erro
 	return objs, nil
 }
 
-func (o *objectMover) getObjectGraph(ctx context.Context, namespace string) (*objectGraph, error) {
+// getObjectGraph returns the object graph for a namespace; if isSelected is not nil, the graph is restricted to
+// the Clusters selected by it.
+func (o *objectMover) getObjectGraph(ctx context.Context, namespace string, isSelected func(cluster *node) bool) (*objectGraph, error) {
 	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
 
 	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
@@ -200,6 +348,19 @@ func (o *objectMover) getObjectGraph(ctx context.Context, namespace string) (*ob
 		return nil, errors.Wrap(err, "failed to discover the object graph")
 	}
 
+	// When resuming a move which failed while deleting objects, Clusters and ClusterClasses could be already deleted from the
+	// source cluster, so their tenants are restored from the move journal.
+	if o.journal != nil && o.journal.Phase == moveJournalPhaseDeleting {
+		o.journal.restoreTenants(objectGraph)
+	}
+
+	// Restrict the object graph to the selected Clusters, if any.
+	if isSelected != nil {
+		if err := objectGraph.filterClusters(isSelected); err != nil {
+			return nil, err
+		}
+	}
+
 	// Checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move/toDirectory operation.
 	// This is required because if the infrastructure is provisioned, then we can reasonably assume that the objects we are moving/backing up are
 	// not currently waiting for long-running reconciliation loops, and so we can safely rely on the pause field on the Cluster object
@@ -226,6 +387,12 @@ func (o *objectMover) checkProvisioningCompleted(ctx context.Context, graph *obj
 	if o.dryRun {
 		return nil
 	}
+
+	// When resuming a move which failed while deleting objects, objects are already partially deleted from the source cluster,
+	// and provisioning was already checked when the move started.
+	if o.journal != nil && o.journal.Phase == moveJournalPhaseDeleting {
+		return nil
+	}
 	errList := []error{}
 
 	// Checking all the clusters have infrastructure is ready
@@ -319,39 +486,17 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	log := logf.Log
 
 	clusters := graph.getClusters()
+	// NOTE: ClusterClasses shared with Clusters not being moved are only copied, so they are not paused.
+	clusterClasses := getMovedNodes(graph.getClusterClasses())
+	if o.journal != nil {
+		// When resuming a move, the list of Clusters and ClusterClasses is read from the journal, because
+		// objects could be already deleted from the source cluster.
+		clusters = o.journal.getClusters()
+		clusterClasses = o.journal.getClusterClasses()
+	}
 	log.Info("Moving Cluster API objects", "Clusters", len(clusters))
-
-	clusterClasses := graph.getClusterClasses()
 	log.Info("Moving Cluster API objects", "ClusterClasses", len(clusterClasses))
 
-	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
-	log.V(1).Info("Pausing the source cluster")
-	if err := setClusterPause(ctx, o.fromProxy, clusters, true, o.dryRun); err != nil {
-		return err
-	}
-
-	log.V(1).Info("Pausing the source ClusterClasses")
-	if err := setClusterClassPause(ctx, o.fromProxy, clusterClasses, true, o.dryRun); err != nil {
-		return errors.Wrap(err, "error pausing ClusterClasses")
-	}
-
-	log.Info("Waiting for all resources to be ready to move")
-	// exponential backoff configuration which returns durations for a total time of ~2m.
-	// Example: 0, 5s, 8s, 11s, 17s, 26s, 38s, 57s, 86s, 128s
-	waitForMoveUnblockedBackoff := wait.Backoff{
-		Duration: 5 * time.Second,
-		Factor:   1.5,
-		Steps:    10,
-		Jitter:   0.1,
-	}
-	if err := waitReadyForMove(ctx, o.fromProxy, graph.getMoveNodes(), o.dryRun, waitForMoveUnblockedBackoff); err != nil {
-		return errors.Wrap(err, "error waiting for resources to be ready to move")
-	}
-
-	// Nb. DO NOT call ensureNamespaces at this point because:
-	// - namespace will be ensured to exist before creating the resource.
-	// - If it's done here, we might create a namespace that can end up unused on target cluster (due to mutators).
-
 	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
 	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
 	// - All the Clusters should be moved first (group 1, processed in parallel)
@@ -359,12 +504,72 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	// - then all the MachineSets, then all the Machines, etc.
 	moveSequence := getMoveSequence(graph)
 
-	// Create all objects group by group, ensuring all the ownerReferences are re-created.
-	log.Info("Creating objects in the target cluster")
-	for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
-		if err := o.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+	if o.journal == nil || o.journal.Phase == moveJournalPhaseCreating {
+		// Record the move into the journal before pausing the source cluster, so a move failing from now on
+		// can always be resumed or rolled back, unpausing the source cluster.
+		if err := o.startMoveJournal(ctx, moveSequence); err != nil {
+			return err
+		}
+
+		// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
+		log.V(1).Info("Pausing the source cluster")
+		if err := setClusterPause(ctx, o.fromProxy, clusters, true, o.dryRun); err != nil {
 			return err
 		}
+
+		log.V(1).Info("Pausing the source ClusterClasses")
+		if err := setClusterClassPause(ctx, o.fromProxy, clusterClasses, true, o.dryRun); err != nil {
+			return errors.Wrap(err, "error pausing ClusterClasses")
+		}
+
+		log.Info("Waiting for all resources to be ready to move")
+		// exponential backoff configuration which returns durations for a total time of ~2m.
+		// Example: 0, 5s, 8s, 11s, 17s, 26s, 38s, 57s, 86s, 128s
+		waitForMoveUnblockedBackoff := wait.Backoff{
+			Duration: 5 * time.Second,
+			Factor:   1.5,
+			Steps:    10,
+			Jitter:   0.1,
+		}
+		if err := waitReadyForMove(ctx, o.fromProxy, graph.getMoveNodes(), o.dryRun, waitForMoveUnblockedBackoff); err != nil {
+			return errors.Wrap(err, "error waiting for resources to be ready to move")
+		}
+
+		// Nb. DO NOT call ensureNamespaces at this point because:
+		// - namespace will be ensured to exist before creating the resource.
+		// - If it's done here, we might create a namespace that can end up unused on target cluster (due to mutators).
+
+		// Create all objects group by group, ensuring all the ownerReferences are re-created.
+		log.Info("Creating objects in the target cluster")
+		for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+			// If resuming a move, groups already created are only read from the target cluster,
+			// so the ownerReferences of the next groups can be re-created.
+			if o.journal != nil && groupIndex < o.journal.CreatedGroups {
+				if err := o.readTargetGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := o.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+				return err
+			}
+
+			if o.journal != nil {
+				o.journal.CreatedGroups = groupIndex + 1
+				if err := saveMoveJournal(ctx, o.fromProxy, o.journal); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Record that all the objects are now created in the target cluster; from now on, the move can't be rolled back.
+		if o.journal != nil {
+			o.journal.Phase = moveJournalPhaseDeleting
+			if err := saveMoveJournal(ctx, o.fromProxy, o.journal); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Nb. mutators used after this point (after creating the resources on target clusters) are mainly intended for
@@ -387,7 +592,69 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...)
+	if err := setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...); err != nil {
+		return err
+	}
+
+	// The move is completed, so the journal is not required anymore.
+	if o.journal != nil {
+		if err := deleteMoveJournal(ctx, o.fromProxy, o.journal.Namespace); err != nil {
+			return err
+		}
+		o.journal = nil
+	}
+	return nil
+}
+
+// startMoveJournal records the move sequence into the move journal, or checks the move sequence is consistent with
+// the one recorded when resuming a move.
+func (o *objectMover) startMoveJournal(ctx context.Context, moveSequence *moveSequence) error {
+	if o.journal == nil {
+		return nil
+	}
+
+	if o.journal.Groups == 0 {
+		o.journal.Groups = len(moveSequence.groups)
+		return saveMoveJournal(ctx, o.fromProxy, o.journal)
+	}
+
+	if o.journal.Groups != len(moveSequence.groups) {
+		return errors.Errorf("the objects to be moved changed since the move started (expected %d groups, got %d); use rollback to undo the move", o.journal.Groups, len(moveSequence.groups))
+	}
+	return nil
+}
+
+// rollback undoes a move which failed while creating objects in the target management cluster.
+func (o *objectMover) rollback(ctx context.Context, graph *objectGraph, journal *moveJournal, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+
+	moveSequence := getMoveSequence(graph)
+
+	// Delete all objects possibly created in the target cluster group by group in reverse order;
+	// this includes the group the move was processing when it failed.
+	log.Info("Deleting objects from the target cluster")
+	lastGroup := journal.CreatedGroups
+	if lastGroup >= len(moveSequence.groups) {
+		lastGroup = len(moveSequence.groups) - 1
+	}
+	for groupIndex := lastGroup; groupIndex >= 0; groupIndex-- {
+		if err := o.deleteTargetGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
+			return err
+		}
+	}
+
+	// Resume the ClusterClasses and the Clusters in the source management cluster, so the controllers start reconciling them.
+	log.V(1).Info("Resuming the source ClusterClasses")
+	if err := setClusterClassPause(ctx, o.fromProxy, journal.getClusterClasses(), false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming ClusterClasses")
+	}
+
+	log.V(1).Info("Resuming the source cluster")
+	if err := setClusterPause(ctx, o.fromProxy, journal.getClusters(), false, o.dryRun); err != nil {
+		return err
+	}
+
+	return deleteMoveJournal(ctx, o.fromProxy, journal.Namespace)
 }
 
 func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, directory string) error {
@@ -553,6 +820,17 @@ func getMoveSequence(graph *objectGraph) *moveSequence {
 	return moveSequence
 }
 
+// getMovedNodes returns the nodes that are going to be moved, dropping the ones that are only copied.
+func getMovedNodes(nodes []*node) []*node {
+	moved := []*node{}
+	for _, n := range nodes {
+		if !n.copyOnly {
+			moved = append(moved, n)
+		}
+	}
+	return moved
+}
+
 // setClusterPause sets the paused field on nodes referring to Cluster objects.
 func setClusterPause(ctx context.Context, proxy Proxy, clusters []*node, value bool, dryRun bool, mutators ...ResourceMutatorFunc) error {
 	if dryRun {
@@ -1001,6 +1279,9 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 		// If the object already exists, try to update it if it is node a global object / something belonging to a global object hierarchy (e.g. a secrets owned by a global identity object).
 		if nodeToCreate.isGlobal || nodeToCreate.isGlobalHierarchy {
 			log.V(5).Info("Object already exists, skipping upgrade because it is global/it is owned by a global object", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+		} else if nodeToCreate.copyOnly {
+			// If the object is shared with Clusters not being moved (e.g. a ClusterClass), it might be already in use in the target cluster by Clusters moved before.
+			log.V(5).Info("Object already exists, skipping upgrade because it is shared with other Clusters", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
 		} else {
 			// Nb. This should not happen, but it is supported to make move more resilient to unexpected interrupt/restarts of the move process.
 			log.V(5).Info("Object already exists, updating", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
@@ -1037,27 +1318,8 @@ func (o *objectMover) backupTargetObject(ctx context.Context, nodeToCreate *node
 	log := logf.Log
 	log.V(1).Info("Saving", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
 
-	cFrom, err := o.fromProxy.NewClient()
-	if err != nil {
-		return err
-	}
-
-	// Get the source object
-	obj := &unstructured.Unstructured{}
-	obj.SetAPIVersion(nodeToCreate.identity.APIVersion)
-	obj.SetKind(nodeToCreate.identity.Kind)
-	objKey := client.ObjectKey{
-		Namespace: nodeToCreate.identity.Namespace,
-		Name:      nodeToCreate.identity.Name,
-	}
-
-	if err := cFrom.Get(ctx, objKey, obj); err != nil {
-		return errors.Wrapf(err, "error reading %q %s/%s",
-			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
-	}
-
-	// Get JSON for object and write it into the configured directory
-	byObj, err := obj.MarshalJSON()
+	// Get JSON for the source object and write it into the configured directory
+	byObj, err := o.getSourceObjectJSON(ctx, nodeToCreate)
 	if err != nil {
 		return err
 	}
@@ -1084,6 +1346,29 @@ func (o *objectMover) backupTargetObject(ctx context.Context, nodeToCreate *node
 	return nil
 }
 
+// getSourceObjectJSON reads the Kubernetes object corresponding to the object graph node from the source management cluster, and returns its JSON representation.
+func (o *objectMover) getSourceObjectJSON(ctx context.Context, n *node) ([]byte, error) {
+	cFrom, err := o.fromProxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(n.identity.APIVersion)
+	obj.SetKind(n.identity.Kind)
+	objKey := client.ObjectKey{
+		Namespace: n.identity.Namespace,
+		Name:      n.identity.Name,
+	}
+
+	if err := cFrom.Get(ctx, objKey, obj); err != nil {
+		return nil, errors.Wrapf(err, "error reading %q %s/%s",
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	return obj.MarshalJSON()
+}
+
 func (o *objectMover) restoreTargetObject(ctx context.Context, nodeToCreate *node, toProxy Proxy) error {
 	log := logf.Log
 	log.V(1).Info("Restoring", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
@@ -1199,6 +1484,11 @@ func (o *objectMover) deleteSourceObject(ctx context.Context, nodeToDelete *node
 		return nil
 	}
 
+	// Don't delete nodes shared with Clusters not being moved (e.g. a ClusterClass).
+	if nodeToDelete.copyOnly {
+		return nil
+	}
+
 	log := logf.Log
 	log.V(1).Info("Deleting", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
 
@@ -1250,6 +1540,125 @@ func (o *objectMover) deleteSourceObject(ctx context.Context, nodeToDelete *node
 	return nil
 }
 
+// readTargetGroup reads all the Kubernetes objects already created into the target management cluster corresponding to the
+// object graph nodes in a moveGroup, so the newUID of each node is known.
+func (o *objectMover) readTargetGroup(ctx context.Context, group moveGroup, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	readTargetObjectBackoff := newReadBackoff()
+	errList := []error{}
+
+	for _, nodeToRead := range group {
+		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
+		err := retryWithExponentialBackoff(readTargetObjectBackoff, func() error {
+			obj, err := getTargetObject(ctx, nodeToRead, toProxy, mutators)
+			if err != nil {
+				return err
+			}
+			nodeToRead.newUID = obj.GetUID()
+			return nil
+		})
+		if err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errList)
+}
+
+// deleteTargetGroup deletes all the Kubernetes objects from the target management cluster corresponding to the object graph nodes in a moveGroup.
+func (o *objectMover) deleteTargetGroup(ctx context.Context, group moveGroup, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	deleteTargetObjectBackoff := newWriteBackoff()
+	errList := []error{}
+
+	for i := range group {
+		nodeToDelete := group[i]
+
+		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
+		err := retryWithExponentialBackoff(deleteTargetObjectBackoff, func() error {
+			return o.deleteTargetObject(ctx, nodeToDelete, toProxy, mutators)
+		})
+		if err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errList)
+}
+
+// deleteTargetObject deletes the Kubernetes object corresponding to the node from the target management cluster, taking care of removing all the finalizers so
+// the objects gets immediately deleted (force delete).
+func (o *objectMover) deleteTargetObject(ctx context.Context, nodeToDelete *node, toProxy Proxy, mutators []ResourceMutatorFunc) error {
+	// Don't delete cluster-wide nodes, nodes that are below a hierarchy that starts with a global object, or nodes shared with
+	// Clusters not being moved, because they could have existed in the target cluster before the move.
+	if nodeToDelete.isGlobal || nodeToDelete.isGlobalHierarchy || nodeToDelete.copyOnly {
+		return nil
+	}
+
+	log := logf.Log
+	log.V(1).Info("Deleting from target", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
+
+	if o.dryRun {
+		return nil
+	}
+
+	targetObj, err := getTargetObject(ctx, nodeToDelete, toProxy, mutators)
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			// If the object was never created or it is already deleted, move on.
+			return nil
+		}
+		return err
+	}
+
+	cTo, err := toProxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	if err := cTo.Patch(ctx, targetObj, addDeleteForMoveAnnotationPatch); err != nil {
+		return errors.Wrapf(err, "error adding delete-for-move annotation to %q %s/%s",
+			targetObj.GroupVersionKind(), targetObj.GetNamespace(), targetObj.GetName())
+	}
+
+	if len(targetObj.GetFinalizers()) > 0 {
+		if err := cTo.Patch(ctx, targetObj, removeFinalizersPatch); err != nil {
+			return errors.Wrapf(err, "error removing finalizers from %q %s/%s",
+				targetObj.GroupVersionKind(), targetObj.GetNamespace(), targetObj.GetName())
+		}
+	}
+
+	if err := cTo.Delete(ctx, targetObj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error deleting %q %s/%s",
+			targetObj.GroupVersionKind(), targetObj.GetNamespace(), targetObj.GetName())
+	}
+
+	return nil
+}
+
+// getTargetObject reads the Kubernetes object corresponding to the node from the target management cluster.
+func getTargetObject(ctx context.Context, n *node, toProxy Proxy, mutators []ResourceMutatorFunc) (*unstructured.Unstructured, error) {
+	cTo, err := toProxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Mutators could change the name and namespace of the object in the target cluster.
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(n.identity.APIVersion)
+	obj.SetKind(n.identity.Kind)
+	obj.SetNamespace(n.identity.Namespace)
+	obj.SetName(n.identity.Name)
+	obj, err = applyMutators(obj, mutators...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cTo.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return nil, errors.Wrapf(err, "error reading %q %s/%s from the target cluster",
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+	return obj, nil
+}
+
 // checkTargetProviders checks that all the providers installed in the source cluster exists in the target cluster as well (with a version >= of the current version).
 func (o *objectMover) checkTargetProviders(ctx context.Context, toInventory InventoryClient) error {
 	if o.dryRun {
@@ -0,0 +1,352 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// moveJournalName is the name of the ConfigMap storing the move journal in the source management cluster.
+	moveJournalName = "clusterctl-move-journal"
+
+	// moveJournalKey is the key of the ConfigMap data storing the move journal.
+	moveJournalKey = "journal"
+)
+
+// moveJournalPhase defines the phase of a move operation recorded in the move journal.
+type moveJournalPhase string
+
+const (
+	// moveJournalPhaseCreating is the phase of a move while objects are being created in the target management cluster.
+	moveJournalPhaseCreating = moveJournalPhase("Creating")
+
+	// moveJournalPhaseDeleting is the phase of a move while objects are being deleted from the source management cluster.
+	moveJournalPhaseDeleting = moveJournalPhase("Deleting")
+)
+
+// moveJournalObject is a reference to an object recorded in the move journal.
+type moveJournalObject struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// moveJournal records the progress of a move operation, so an interrupted move can be resumed or rolled back.
+// The journal is stored as a ConfigMap in the source management cluster, in the namespace being moved.
+type moveJournal struct {
+	// Namespace is the namespace being moved.
+	Namespace string `json:"namespace"`
+
+	// TargetHost is the API server of the target management cluster.
+	TargetHost string `json:"targetHost,omitempty"`
+
+	// Phase is the current phase of the move.
+	Phase moveJournalPhase `json:"phase"`
+
+	// Groups is the number of groups in the move sequence.
+	Groups int `json:"groups"`
+
+	// CreatedGroups is the number of groups in the move sequence already created in the target management cluster.
+	CreatedGroups int `json:"createdGroups"`
+
+	// Clusters is the list of Clusters being moved.
+	Clusters []moveJournalObject `json:"clusters,omitempty"`
+
+	// ClusterClasses is the list of ClusterClasses being moved.
+	ClusterClasses []moveJournalObject `json:"clusterClasses,omitempty"`
+
+	// Selective is true if only the Clusters in the journal are being moved, instead of all the Clusters in the namespace.
+	Selective bool `json:"selective,omitempty"`
+}
+
+// newMoveJournal returns a move journal for moving a namespace to a target management cluster.
+func newMoveJournal(namespace string, toProxy Proxy, clusters, clusterClasses []*node) (*moveJournal, error) {
+	journal := &moveJournal{
+		Namespace:      namespace,
+		Phase:          moveJournalPhaseCreating,
+		Clusters:       nodesToJournalObjects(clusters),
+		ClusterClasses: nodesToJournalObjects(clusterClasses),
+	}
+
+	targetHost, err := getProxyHost(toProxy)
+	if err != nil {
+		return nil, err
+	}
+	journal.TargetHost = targetHost
+	return journal, nil
+}
+
+// checkTarget checks the move journal refers to the target management cluster.
+func (j *moveJournal) checkTarget(toProxy Proxy) error {
+	targetHost, err := getProxyHost(toProxy)
+	if err != nil {
+		return err
+	}
+	if j.TargetHost != targetHost {
+		return errors.Errorf("the move journal refers to the target management cluster %q, but the current target is %q", j.TargetHost, targetHost)
+	}
+	return nil
+}
+
+// getClusters returns the nodes for the Clusters recorded in the move journal.
+func (j *moveJournal) getClusters() []*node {
+	return journalObjectsToNodes(j.Clusters, clusterv1.GroupVersion.String(), clusterv1.ClusterKind)
+}
+
+// getClusterClasses returns the nodes for the ClusterClasses recorded in the move journal.
+func (j *moveJournal) getClusterClasses() []*node {
+	return journalObjectsToNodes(j.ClusterClasses, clusterv1.GroupVersion.String(), clusterv1.ClusterClassKind)
+}
+
+// clusterSelector returns a function selecting the Clusters recorded in the move journal, if the journal is for a selective move.
+func (j *moveJournal) clusterSelector() func(cluster *node) bool {
+	if !j.Selective {
+		return nil
+	}
+	return func(cluster *node) bool {
+		for _, c := range j.Clusters {
+			if c.Namespace == cluster.identity.Namespace && c.Name == cluster.identity.Name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// restoreTenants ensures objects belonging to Clusters and ClusterClasses recorded in the move journal are considered
+// for move even if the Clusters and ClusterClasses are already deleted from the source cluster; this is required when
+// resuming a move that failed while deleting objects from the source cluster.
+func (j *moveJournal) restoreTenants(graph *objectGraph) {
+	for _, journalNode := range append(j.getClusters(), j.getClusterClasses()...) {
+		found := false
+		for _, n := range graph.uidToNode {
+			if n.identity.GroupVersionKind().GroupKind() != journalNode.identity.GroupVersionKind().GroupKind() || n.identity.Name != journalNode.identity.Name {
+				continue
+			}
+			if !n.virtual {
+				if n.identity.Namespace == journalNode.identity.Namespace {
+					found = true
+				}
+				continue
+			}
+
+			// Virtual nodes, created from the OwnerReferences of the objects still existing in the source cluster,
+			// do not have a namespace; given that OwnerReferences can only point to objects in the same namespace, the
+			// namespace is derived from the journal.
+			n.identity.Namespace = journalNode.identity.Namespace
+			n.forceMoveHierarchy = true
+			found = true
+		}
+
+		// If there are no nodes for an object recorded in the journal, add a virtual node so objects soft owned by it are moved.
+		if !found {
+			journalNode.identity.UID = types.UID(fmt.Sprintf("%s, %s/%s", journalNode.identity.GroupVersionKind(), journalNode.identity.Namespace, journalNode.identity.Name))
+			journalNode.owners = make(map[*node]ownerReferenceAttributes)
+			journalNode.softOwners = make(map[*node]empty)
+			journalNode.tenant = make(map[*node]empty)
+			journalNode.virtual = true
+			journalNode.forceMoveHierarchy = true
+			graph.uidToNode[journalNode.identity.UID] = journalNode
+		}
+	}
+
+	graph.setSoftOwnership()
+	graph.setTenants()
+}
+
+func getProxyHost(proxy Proxy) (string, error) {
+	config, err := proxy.GetConfig()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the configuration for the target management cluster")
+	}
+	if config == nil {
+		return "", nil
+	}
+	return config.Host, nil
+}
+
+func nodesToJournalObjects(nodes []*node) []moveJournalObject {
+	objects := make([]moveJournalObject, 0, len(nodes))
+	for _, n := range nodes {
+		objects = append(objects, moveJournalObject{Namespace: n.identity.Namespace, Name: n.identity.Name})
+	}
+	return objects
+}
+
+func journalObjectsToNodes(objects []moveJournalObject, apiVersion, kind string) []*node {
+	nodes := make([]*node, 0, len(objects))
+	for _, o := range objects {
+		nodes = append(nodes, &node{
+			identity: corev1.ObjectReference{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  o.Namespace,
+				Name:       o.Name,
+			},
+		})
+	}
+	return nodes
+}
+
+// getMoveJournalKey returns the key of the ConfigMap storing the move journal for a namespace.
+func getMoveJournalKey(namespace string) client.ObjectKey {
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return client.ObjectKey{Namespace: namespace, Name: moveJournalName}
+}
+
+// getMoveJournal reads the move journal for a namespace from the source management cluster; it returns nil if the journal
+// does not exist.
+func getMoveJournal(ctx context.Context, proxy Proxy, namespace string) (*moveJournal, error) {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	key := getMoveJournalKey(namespace)
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error reading move journal ConfigMap %s", key)
+	}
+
+	journal := &moveJournal{}
+	if err := json.Unmarshal([]byte(cm.Data[moveJournalKey]), journal); err != nil {
+		return nil, errors.Wrapf(err, "error decoding move journal ConfigMap %s", key)
+	}
+	return journal, nil
+}
+
+// createMoveJournal creates the move journal in the source management cluster; it fails if there is already a move journal
+// for the namespace, given that only one move per namespace can run at a time.
+func createMoveJournal(ctx context.Context, proxy Proxy, journal *moveJournal) error {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(journal)
+	if err != nil {
+		return errors.Wrap(err, "error encoding move journal")
+	}
+
+	key := getMoveJournalKey(journal.Namespace)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+		},
+		Data: map[string]string{moveJournalKey: string(data)},
+	}
+	if err := c.Create(ctx, cm); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			existing, err := getMoveJournal(ctx, proxy, journal.Namespace)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return errMoveInProgress(journal.Namespace, existing)
+			}
+		}
+		return errors.Wrapf(err, "error creating move journal ConfigMap %s", key)
+	}
+	return nil
+}
+
+// errMoveInProgress returns the error for a move conflicting with the move recorded in the move journal.
+func errMoveInProgress(namespace string, journal *moveJournal) error {
+	clusters := make([]string, 0, len(journal.Clusters))
+	for _, c := range journal.Clusters {
+		clusters = append(clusters, c.Name)
+	}
+	return errors.Errorf("another move for namespace %q, moving Clusters [%s], is in progress or did not complete; only one move per namespace "+
+		"can run at a time, so wait for it to complete, or use resume to complete it or rollback to undo it", namespace, strings.Join(clusters, ", "))
+}
+
+// saveMoveJournal writes the move journal to the source management cluster.
+func saveMoveJournal(ctx context.Context, proxy Proxy, journal *moveJournal) error {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(journal)
+	if err != nil {
+		return errors.Wrap(err, "error encoding move journal")
+	}
+
+	key := getMoveJournalKey(journal.Namespace)
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error reading move journal ConfigMap %s", key)
+		}
+
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+			},
+			Data: map[string]string{moveJournalKey: string(data)},
+		}
+		if err := c.Create(ctx, cm); err != nil {
+			return errors.Wrapf(err, "error creating move journal ConfigMap %s", key)
+		}
+		return nil
+	}
+
+	cm.Data = map[string]string{moveJournalKey: string(data)}
+	if err := c.Update(ctx, cm); err != nil {
+		return errors.Wrapf(err, "error updating move journal ConfigMap %s", key)
+	}
+	return nil
+}
+
+// deleteMoveJournal deletes the move journal from the source management cluster.
+func deleteMoveJournal(ctx context.Context, proxy Proxy, namespace string) error {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	key := getMoveJournalKey(namespace)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: key.Namespace,
+			Name:      key.Name,
+		},
+	}
+	if err := c.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error deleting move journal ConfigMap %s", key)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+// startPartialMove starts a move recording the progress in the move journal, but only creates the first group
+// of objects in the target cluster, thus simulating a move failing midway.
+func startPartialMove(ctx context.Context, g *WithT, objs []client.Object) (*objectGraph, *test.FakeProxy) {
+	graph := getObjectGraphWithObjs(objs)
+	g.Expect(getFakeDiscoveryTypes(ctx, graph)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+
+	toProxy := getFakeProxyWithCRDs()
+
+	journal, err := newMoveJournal("ns1", toProxy, graph.getClusters(), graph.getClusterClasses())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+
+	g.Expect(setClusterPause(ctx, graph.proxy, graph.getClusters(), true, false)).To(Succeed())
+
+	moveSequence := getMoveSequence(graph)
+	g.Expect(len(moveSequence.groups)).To(BeNumerically(">", 1))
+	g.Expect(mover.startMoveJournal(ctx, moveSequence)).To(Succeed())
+	g.Expect(mover.createGroup(ctx, moveSequence.getGroup(0), toProxy)).To(Succeed())
+	journal.CreatedGroups = 1
+	g.Expect(saveMoveJournal(ctx, graph.proxy, journal)).To(Succeed())
+
+	return graph, toProxy
+}
+
+// rediscoverObjectGraph returns a new object graph for the source cluster, like a new invocation of clusterctl would do.
+func rediscoverObjectGraph(ctx context.Context, g *WithT, graph *objectGraph) *objectGraph {
+	newGraph := newObjectGraph(graph.proxy, graph.providerInventory)
+	g.Expect(getFakeDiscoveryTypes(ctx, newGraph)).To(Succeed())
+	g.Expect(newGraph.Discovery(ctx, "ns1")).To(Succeed())
+	return newGraph
+}
+
+func Test_objectMover_resumeWithJournal(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	graph, toProxy := startPartialMove(ctx, g, test.NewFakeCluster("ns1", "foo").Objs())
+
+	journal, err := getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal).ToNot(BeNil())
+	g.Expect(journal.Phase).To(Equal(moveJournalPhaseCreating))
+	g.Expect(journal.CreatedGroups).To(Equal(1))
+	g.Expect(journal.Clusters).To(ConsistOf(moveJournalObject{Namespace: "ns1", Name: "foo"}))
+
+	// Resume the move.
+	newGraph := rediscoverObjectGraph(ctx, g, graph)
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	g.Expect(mover.move(ctx, newGraph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Check all the objects are deleted from the source cluster and created in the target cluster.
+	for _, node := range newGraph.getMoveNodes() {
+		key := client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}
+
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(node.identity.APIVersion)
+		oFrom.SetKind(node.identity.Kind)
+		err := csFrom.Get(ctx, key, oFrom)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s %v not deleted from the source cluster", node.identity.Kind, key)
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed(), "%s %v not created in the target cluster", node.identity.Kind, key)
+
+		// Check the ownerReferences are re-created using the UIDs of the owners in the target cluster, including the
+		// owners created before the move was resumed.
+		for _, ref := range oTo.GetOwnerReferences() {
+			g.Expect(ref.UID).ToNot(BeEmpty())
+		}
+	}
+
+	// Check the cluster is un-paused in the target cluster and the journal is deleted.
+	cluster := &clusterv1.Cluster{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Paused).To(BeFalse())
+
+	journal, err = getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal).To(BeNil())
+}
+
+func Test_objectMover_resumeWithJournalWhileDeleting(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	graph, toProxy := startPartialMove(ctx, g, test.NewFakeCluster("ns1", "foo").Objs())
+
+	// Complete the creation of the objects, then delete the Cluster from the source cluster, thus simulating
+	// a move failing while deleting objects.
+	moveSequence := getMoveSequence(graph)
+	mover := objectMover{fromProxy: graph.proxy}
+	for i := 0; i < len(moveSequence.groups); i++ {
+		g.Expect(mover.createGroup(ctx, moveSequence.getGroup(i), toProxy)).To(Succeed())
+	}
+	g.Expect(mover.deleteGroup(ctx, moveSequence.getGroup(0))).To(Succeed())
+
+	journal, err := getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	journal.CreatedGroups = len(moveSequence.groups)
+	journal.Phase = moveJournalPhaseDeleting
+	g.Expect(saveMoveJournal(ctx, graph.proxy, journal)).To(Succeed())
+
+	// Resume the move; given that the Cluster is already deleted, tenants are restored from the journal.
+	newGraph := rediscoverObjectGraph(ctx, g, graph)
+	journal.restoreTenants(newGraph)
+	mover = objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	g.Expect(mover.move(ctx, newGraph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, node := range graph.getMoveNodes() {
+		if node.isGlobal || node.isGlobalHierarchy {
+			continue
+		}
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(node.identity.APIVersion)
+		oFrom.SetKind(node.identity.Kind)
+		err := csFrom.Get(ctx, client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}, oFrom)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	}
+
+	// Check the cluster read from the journal is un-paused in the target cluster.
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	cluster := &clusterv1.Cluster{}
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Paused).To(BeFalse())
+}
+
+func Test_objectMover_rollbackWithJournal(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	graph, toProxy := startPartialMove(ctx, g, test.NewFakeCluster("ns1", "foo").Objs())
+
+	journal, err := getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Rollback the move.
+	newGraph := rediscoverObjectGraph(ctx, g, graph)
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.rollback(ctx, newGraph, journal, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Check all the objects are kept in the source cluster and deleted from the target cluster.
+	for _, node := range newGraph.getMoveNodes() {
+		key := client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}
+
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(node.identity.APIVersion)
+		oFrom.SetKind(node.identity.Kind)
+		g.Expect(csFrom.Get(ctx, key, oFrom)).To(Succeed(), "%s %v deleted from the source cluster", node.identity.Kind, key)
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(node.identity.APIVersion)
+		oTo.SetKind(node.identity.Kind)
+		err := csTo.Get(ctx, key, oTo)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s %v not deleted from the target cluster", node.identity.Kind, key)
+	}
+
+	// Check the cluster is un-paused in the source cluster and the journal is deleted.
+	cluster := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Paused).To(BeFalse())
+
+	journal, err = getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal).To(BeNil())
+}
+
+func Test_objectMover_startMoveJournalDetectsChanges(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	graph, _ := startPartialMove(ctx, g, test.NewFakeCluster("ns1", "foo").Objs())
+
+	journal, err := getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	journal.Groups++
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	g.Expect(mover.startMoveJournal(ctx, getMoveSequence(graph))).ToNot(Succeed())
+}
+
+func Test_objectMover_rollbackBeforeCreatingObjects(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+	g.Expect(getFakeDiscoveryTypes(ctx, graph)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+
+	toProxy := getFakeProxyWithCRDs()
+
+	journal, err := newMoveJournal("ns1", toProxy, graph.getClusters(), graph.getClusterClasses())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Record the move and pause the source cluster, thus simulating a move failing while waiting for
+	// the objects to be ready to move; the journal is saved before pausing the source cluster.
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	g.Expect(mover.startMoveJournal(ctx, getMoveSequence(graph))).To(Succeed())
+	g.Expect(setClusterPause(ctx, graph.proxy, graph.getClusters(), true, false)).To(Succeed())
+
+	journal, err = getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal).ToNot(BeNil())
+	g.Expect(journal.CreatedGroups).To(Equal(0))
+
+	// Rollback the move.
+	newGraph := rediscoverObjectGraph(ctx, g, graph)
+	mover = objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.rollback(ctx, newGraph, journal, toProxy)).To(Succeed())
+
+	// Check the cluster is un-paused in the source cluster and the journal is deleted.
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	cluster := &clusterv1.Cluster{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo"}, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Paused).To(BeFalse())
+
+	journal, err = getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal).To(BeNil())
+}
+
+func Test_createMoveJournalRejectsConcurrentMoves(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	graph := getObjectGraphWithObjs(append(test.NewFakeCluster("ns1", "foo").Objs(), test.NewFakeCluster("ns1", "bar").Objs()...))
+	g.Expect(getFakeDiscoveryTypes(ctx, graph)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+
+	toProxy := getFakeProxyWithCRDs()
+
+	// Start a selective move for the Cluster foo.
+	var foo, bar []*node
+	for _, c := range graph.getClusters() {
+		if c.identity.Name == "foo" {
+			foo = append(foo, c)
+		} else {
+			bar = append(bar, c)
+		}
+	}
+	fooJournal, err := newMoveJournal("ns1", toProxy, foo, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	fooJournal.Selective = true
+	g.Expect(createMoveJournal(ctx, graph.proxy, fooJournal)).To(Succeed())
+
+	// A selective move for the Cluster bar in the same namespace is rejected, and the journal of the first move is preserved.
+	barJournal, err := newMoveJournal("ns1", toProxy, bar, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	barJournal.Selective = true
+	err = createMoveJournal(ctx, graph.proxy, barJournal)
+	g.Expect(err).To(MatchError(ContainSubstring(`another move for namespace "ns1", moving Clusters [foo], is in progress`)))
+
+	journal, err := getMoveJournal(ctx, graph.proxy, "ns1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(journal.Clusters).To(ConsistOf(moveJournalObject{Namespace: "ns1", Name: "foo"}))
+}
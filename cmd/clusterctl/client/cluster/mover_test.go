@@ -1327,6 +1327,77 @@ func Test_objectMover_move(t *testing.T) {
 	}
 }
 
+func Test_objectMover_move_selective(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+	objs = append(objs, test.NewFakeCluster("ns1", "foo").WithTopologyClass("class1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "bar").WithTopologyClass("class1").Objs()...)
+	objs = deduplicateObjects(objs)
+
+	graph := getObjectGraphWithObjs(objs)
+	g.Expect(getFakeDiscoveryTypes(ctx, graph)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+	g.Expect(graph.filterClusters(ClusterFilter{Names: []string{"foo"}}.matches)).To(Succeed())
+
+	toProxy := getFakeProxyWithCRDs()
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		identity     corev1.ObjectReference
+		wantInSource bool
+		wantInTarget bool
+	}{
+		// The selected Cluster is moved.
+		{identity: corev1.ObjectReference{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Namespace: "ns1", Name: "foo"}, wantInSource: false, wantInTarget: true},
+		{identity: corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: "foo-kubeconfig"}, wantInSource: false, wantInTarget: true},
+		// Other Clusters are not moved.
+		{identity: corev1.ObjectReference{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Namespace: "ns1", Name: "bar"}, wantInSource: true, wantInTarget: false},
+		{identity: corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: "bar-kubeconfig"}, wantInSource: true, wantInTarget: false},
+		// The shared ClusterClass is copied.
+		{identity: corev1.ObjectReference{APIVersion: clusterv1.GroupVersion.String(), Kind: "ClusterClass", Namespace: "ns1", Name: "class1"}, wantInSource: true, wantInTarget: true},
+		{identity: corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "GenericInfrastructureClusterTemplate", Namespace: "ns1", Name: "class1"}, wantInSource: true, wantInTarget: true},
+	}
+	for _, tt := range tests {
+		key := client.ObjectKey{Namespace: tt.identity.Namespace, Name: tt.identity.Name}
+
+		oFrom := &unstructured.Unstructured{}
+		oFrom.SetAPIVersion(tt.identity.APIVersion)
+		oFrom.SetKind(tt.identity.Kind)
+		err := csFrom.Get(ctx, key, oFrom)
+		if tt.wantInSource {
+			g.Expect(err).ToNot(HaveOccurred(), "%s %v should exist in the source cluster", tt.identity.Kind, key)
+		} else {
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s %v should not exist in the source cluster", tt.identity.Kind, key)
+		}
+
+		oTo := &unstructured.Unstructured{}
+		oTo.SetAPIVersion(tt.identity.APIVersion)
+		oTo.SetKind(tt.identity.Kind)
+		err = csTo.Get(ctx, key, oTo)
+		if tt.wantInTarget {
+			g.Expect(err).ToNot(HaveOccurred(), "%s %v should exist in the target cluster", tt.identity.Kind, key)
+		} else {
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%s %v should not exist in the target cluster", tt.identity.Kind, key)
+		}
+	}
+
+	// The shared ClusterClass is not paused in the source cluster.
+	clusterClass := &clusterv1.ClusterClass{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "class1"}, clusterClass)).To(Succeed())
+	g.Expect(clusterClass.Annotations).ToNot(HaveKey(clusterv1.PausedAnnotation))
+}
+
 func Test_objectMover_move_with_Mutator(t *testing.T) {
 	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
 	// we use same mutator function for all tests and validate outcome based on input.
@@ -27,6 +27,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,6 +40,7 @@ import (
 
 const clusterTopologyNameKey = "cluster.spec.topology.class"
 const clusterResourceSetBindingClusterNameKey = "clusterresourcesetbinding.spec.clustername"
+const clusterLabelsKey = "cluster.metadata.labels"
 
 type empty struct{}
 
@@ -74,6 +76,11 @@ type node struct {
 	// When this flag is true the object should not be deleted from the source cluster.
 	isGlobalHierarchy bool
 
+	// copyOnly gets set to true if this object is shared with Clusters not included in a selective move, e.g.
+	// a ClusterClass used by many Clusters.
+	// When this flag is true the object should be copied to the target cluster, but not deleted from the source cluster.
+	copyOnly bool
+
 	// virtual records if this node was discovered indirectly, e.g. by processing an OwnerRef, but not yet observed as a concrete object.
 	virtual bool
 
@@ -144,12 +151,14 @@ func (n *node) captureAdditionalInformation(obj *unstructured.Unstructured) erro
 		if err := localScheme.Convert(obj, cluster, nil); err != nil {
 			return errors.Wrapf(err, "failed to convert object %s to Cluster", n.identityStr())
 		}
+		if n.additionalInfo == nil {
+			n.additionalInfo = map[string]interface{}{}
+		}
 		if cluster.Spec.Topology != nil {
-			if n.additionalInfo == nil {
-				n.additionalInfo = map[string]interface{}{}
-			}
 			n.additionalInfo[clusterTopologyNameKey] = cluster.Spec.Topology.Class
 		}
+		// Capture the labels of the cluster, so they can be used for selecting the clusters to be moved.
+		n.additionalInfo[clusterLabelsKey] = labels.Set(cluster.Labels)
 	}
 
 	// If the node is a ClusterResourceSetBinding capture the name of the cluster it is referencing to.
@@ -665,6 +674,100 @@ func (o *objectGraph) setTenant(node, tenant *node, isGlobalHierarchy bool) {
 	}
 }
 
+// filterClusters restricts the objects to be moved to the hierarchy of the selected Clusters.
+// Objects shared with Clusters not selected and their own hierarchy, like e.g. a ClusterClass and its templates,
+// as well as global objects, are kept in the graph, but they are marked as copy only, so they are created in the target
+// cluster without being deleted from the source cluster. All the other objects are removed from the graph.
+func (o *objectGraph) filterClusters(isSelected func(cluster *node) bool) error {
+	clusterGroupKind := clusterv1.GroupVersion.WithKind("Cluster").GroupKind()
+
+	// moved records the nodes that are going to be moved (true) or copied (false).
+	moved := map[*node]bool{}
+	hasClusterTenant := map[*node]bool{}
+	selected := 0
+	for _, n := range o.getMoveNodes() {
+		hasSelectedCluster, hasOtherCluster := false, false
+		for tenant := range n.tenant {
+			if tenant.identity.GroupVersionKind().GroupKind() != clusterGroupKind {
+				continue
+			}
+			if isSelected(tenant) {
+				hasSelectedCluster = true
+			} else {
+				hasOtherCluster = true
+			}
+		}
+		hasClusterTenant[n] = hasSelectedCluster || hasOtherCluster
+
+		if n.identity.GroupVersionKind().GroupKind() == clusterGroupKind && isSelected(n) {
+			selected++
+		}
+
+		switch {
+		case hasSelectedCluster:
+			// Objects belonging also to Clusters not selected are shared, so they can only be copied.
+			moved[n] = !hasOtherCluster
+		case !hasOtherCluster && (n.isGlobal || n.isGlobalHierarchy):
+			moved[n] = false
+		}
+	}
+
+	if selected == 0 {
+		return errors.New("there are no Clusters matching the selection")
+	}
+
+	// Add to the graph all the owners of the objects to be moved, because they are required for re-creating the
+	// OwnerReference chain in the target cluster, as well as all the objects in their own hierarchy not linked to
+	// any Cluster; e.g. if a Cluster is moved, the ClusterClass it uses and the ClusterClass templates should be copied.
+	for changed := true; changed; {
+		changed = false
+		for _, n := range o.getMoveNodes() {
+			if _, ok := moved[n]; ok {
+				for owner := range n.owners {
+					changed = o.addCopyOnly(moved, owner) || changed
+				}
+				for owner := range n.softOwners {
+					changed = o.addCopyOnly(moved, owner) || changed
+				}
+				continue
+			}
+
+			if hasClusterTenant[n] {
+				continue
+			}
+			for owner := range n.owners {
+				if isMoved, ok := moved[owner]; ok && !isMoved {
+					changed = o.addCopyOnly(moved, n) || changed
+					break
+				}
+			}
+		}
+	}
+
+	for _, n := range o.getMoveNodes() {
+		isMoved, ok := moved[n]
+		if !ok {
+			delete(o.uidToNode, n.identity.UID)
+			continue
+		}
+		n.copyOnly = !isMoved
+	}
+	return nil
+}
+
+// addCopyOnly adds a node to the list of nodes to be copied, if the node should be moved and it is not already
+// in the list; it returns true if the node is added.
+func (o *objectGraph) addCopyOnly(moved map[*node]bool, n *node) bool {
+	if _, ok := moved[n]; ok {
+		return false
+	}
+	if len(n.tenant) == 0 && !n.forceMove {
+		return false
+	}
+	moved[n] = false
+	return true
+}
+
 // checkVirtualNode logs if nodes are still virtual.
 func (o *objectGraph) checkVirtualNode() {
 	log := logf.Log
@@ -24,8 +24,10 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -2539,3 +2541,98 @@ func deduplicateObjects(objs []client.Object) []client.Object {
 	}
 	return res
 }
+
+func Test_objectGraph_filterClusters(t *testing.T) {
+	objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+	objs = append(objs, test.NewFakeCluster("ns1", "foo").WithTopologyClass("class1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "bar").WithTopologyClass("class1").Objs()...)
+	objs = append(objs, test.NewFakeClusterResourceSet("ns1", "crs1").
+		WithSecret("resource-s1").
+		ApplyToCluster(test.SelectClusterObj(objs, "ns1", "foo")).
+		ApplyToCluster(test.SelectClusterObj(objs, "ns1", "bar")).
+		Objs()...)
+	objs = append(objs, test.NewFakeClusterInfrastructureIdentity("infra1-identity").
+		WithSecretIn("infra1-system").
+		Objs()...)
+	objs = deduplicateObjects(objs)
+
+	tests := []struct {
+		name         string
+		isSelected   func(cluster *node) bool
+		wantMoved    []string
+		wantCopyOnly []string
+		wantErr      bool
+	}{
+		{
+			name:       "Select one Cluster",
+			isSelected: ClusterFilter{Names: []string{"foo"}}.matches,
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+				"addons.cluster.x-k8s.io/v1beta1, Kind=ClusterResourceSetBinding, ns1/foo",
+			},
+			wantCopyOnly: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=ClusterClass, ns1/class1",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureClusterTemplate, ns1/class1",
+				"controlplane.cluster.x-k8s.io/v1beta1, Kind=GenericControlPlaneTemplate, ns1/class1",
+				"addons.cluster.x-k8s.io/v1beta1, Kind=ClusterResourceSet, ns1/crs1",
+				"/v1, Kind=Secret, ns1/resource-s1",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericClusterInfrastructureIdentity, infra1-identity",
+				"/v1, Kind=Secret, infra1-system/infra1-identity-credentials",
+			},
+		},
+		{
+			name:       "No Clusters selected",
+			isSelected: ClusterFilter{Names: []string{"baz"}}.matches,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			graph := getObjectGraphWithObjs(objs)
+			g.Expect(getFakeDiscoveryTypes(context.Background(), graph)).To(Succeed())
+			g.Expect(graph.Discovery(context.Background(), "")).To(Succeed())
+
+			err := graph.filterClusters(tt.isSelected)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotMoved := []string{}
+			gotCopyOnly := []string{}
+			for _, n := range graph.getMoveNodes() {
+				if n.copyOnly {
+					gotCopyOnly = append(gotCopyOnly, string(n.identity.UID))
+					continue
+				}
+				gotMoved = append(gotMoved, string(n.identity.UID))
+			}
+			g.Expect(gotMoved).To(ConsistOf(tt.wantMoved))
+			g.Expect(gotCopyOnly).To(ConsistOf(tt.wantCopyOnly))
+		})
+	}
+}
+
+func Test_ClusterFilter_matches(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &node{
+		identity: corev1.ObjectReference{Namespace: "ns1", Name: "foo"},
+		additionalInfo: map[string]interface{}{
+			clusterLabelsKey: labels.Set{"env": "prod"},
+		},
+	}
+
+	g.Expect(ClusterFilter{}.IsEmpty()).To(BeTrue())
+	g.Expect(ClusterFilter{Names: []string{"foo"}}.matches(cluster)).To(BeTrue())
+	g.Expect(ClusterFilter{Names: []string{"bar"}}.matches(cluster)).To(BeFalse())
+	g.Expect(ClusterFilter{Selector: labels.SelectorFromSet(labels.Set{"env": "prod"})}.matches(cluster)).To(BeTrue())
+	g.Expect(ClusterFilter{Selector: labels.SelectorFromSet(labels.Set{"env": "dev"})}.matches(cluster)).To(BeFalse())
+	g.Expect(ClusterFilter{Names: []string{"bar"}, Selector: labels.SelectorFromSet(labels.Set{"env": "prod"})}.matches(cluster)).To(BeTrue())
+}
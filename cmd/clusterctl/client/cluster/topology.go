@@ -44,6 +44,8 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	clusterclasscontroller "sigs.k8s.io/cluster-api/internal/controllers/clusterclass"
 	clustertopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 	"sigs.k8s.io/cluster-api/util/contract"
 )
@@ -56,6 +58,7 @@ const (
 // TopologyClient has methods to work with ClusterClass and ManagedTopologies.
 type TopologyClient interface {
 	Plan(ctx context.Context, in *TopologyPlanInput) (*TopologyPlanOutput, error)
+	RenderPatches(ctx context.Context, in *TopologyPlanInput) (*TopologyRenderPatchesOutput, error)
 }
 
 // topologyClient implements TopologyClient.
@@ -104,9 +107,44 @@ type TopologyPlanOutput struct {
 	*ChangeSummary
 }
 
+// GeneratedPatch is a patch generated by a ClusterClass patch for a template.
+type GeneratedPatch = patches.GeneratedPatch
+
+// TopologyRenderPatchesOutput defines the output of the topology render patches operation.
+type TopologyRenderPatchesOutput struct {
+	// ReconciledCluster is the cluster for which the patches have been generated.
+	ReconciledCluster *client.ObjectKey
+	// GeneratedPatches is the list of patches generated for the templates of the ReconciledCluster,
+	// in the order in which they are applied.
+	GeneratedPatches []GeneratedPatch
+}
+
 // Plan performs a dry run execution of the topology reconciler using the given inputs.
 // It returns a summary of the changes observed during the execution.
 func (t *topologyClient) Plan(ctx context.Context, in *TopologyPlanInput) (*TopologyPlanOutput, error) {
+	return t.dryRun(ctx, in)
+}
+
+// RenderPatches performs a dry run execution of the topology reconciler using the given inputs.
+// It returns the patches generated by the ClusterClass patches for the templates of the target cluster.
+func (t *topologyClient) RenderPatches(ctx context.Context, in *TopologyPlanInput) (*TopologyRenderPatchesOutput, error) {
+	res := &TopologyRenderPatchesOutput{}
+	out, err := t.dryRun(ctx, in, patches.WithGeneratedPatchRecorder(func(patch patches.GeneratedPatch) {
+		res.GeneratedPatches = append(res.GeneratedPatches, patch)
+	}))
+	if err != nil {
+		return nil, err
+	}
+	if out.ReconciledCluster == nil {
+		return nil, errors.New("failed to identify the target cluster: the input should contain exactly one affected cluster or the target cluster name should be set")
+	}
+	res.ReconciledCluster = out.ReconciledCluster
+	return res, nil
+}
+
+// dryRun performs a dry run execution of the topology reconciler using the given inputs.
+// The given patchEngineOptions are used to configure the patch engine of the topology reconciler.
+func (t *topologyClient) dryRun(ctx context.Context, in *TopologyPlanInput, patchEngineOptions ...patches.EngineOption) (*TopologyPlanOutput, error) {
 	log := logf.Log
 
 	// Make sure the inputs are valid.
@@ -204,7 +242,7 @@ func (t *topologyClient) Plan(ctx context.Context, in *TopologyPlanInput) (*Topo
 		APIReader:                 dryRunClient,
 		UnstructuredCachingClient: dryRunClient,
 	}
-	reconciler.SetupForDryRun(&noOpRecorder{})
+	reconciler.SetupForDryRun(&noOpRecorder{}, patchEngineOptions...)
 	request := reconcile.Request{NamespacedName: *targetCluster}
 	// Run the topology reconciler.
 	if _, err := reconciler.Reconcile(ctx, request); err != nil {
@@ -696,11 +734,23 @@ func (t *topologyClient) affectedClusters(ctx context.Context, in *TopologyPlanI
 	}
 
 	// Each of the Cluster that uses the ClusterClass in the input is an affected cluster.
+	// NOTE: Clusters pinned to a revision of the ClusterClass are affected only by changes to the templates used by the revision.
 	for _, cc := range affectedClusterClasses {
 		for i := range clusterList.Items {
-			if clusterList.Items[i].Spec.Topology != nil && clusterList.Items[i].Spec.Topology.Class == cc.Name {
-				affectedClusters[client.ObjectKeyFromObject(&clusterList.Items[i])] = true
+			cluster := &clusterList.Items[i]
+			if cluster.Spec.Topology == nil || cluster.Spec.Topology.Class != cc.Name {
+				continue
 			}
+			if cluster.Spec.Topology.ClassRevision != "" {
+				affected, err := pinnedClusterIsAffected(ctx, in, c, cluster)
+				if err != nil {
+					return nil, err
+				}
+				if !affected {
+					continue
+				}
+			}
+			affectedClusters[client.ObjectKeyFromObject(cluster)] = true
 		}
 	}
 
@@ -716,6 +766,26 @@ func (t *topologyClient) affectedClusters(ctx context.Context, in *TopologyPlanI
 	return affectedClustersList, nil
 }
 
+// pinnedClusterIsAffected returns true if any of the Templates in the input is used by the revision of the ClusterClass
+// the Cluster is pinned to.
+func pinnedClusterIsAffected(ctx context.Context, in *TopologyPlanInput, c client.Reader, cluster *clusterv1.Cluster) (bool, error) {
+	clusterClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.Topology.Class}, clusterClass); err != nil {
+		return false, errors.Wrapf(err, "failed to get ClusterClass %s", cluster.Spec.Topology.Class)
+	}
+	clusterClass, err := revisions.ClusterClass(ctx, c, clusterClass, cluster.Spec.Topology.ClassRevision)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get the ClusterClass for Cluster %s", cluster.Name)
+	}
+
+	for _, template := range getTemplates(in.Objs) {
+		if clusterClassUsesTemplate(clusterClass, objToRef(template)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func inList(list []client.ObjectKey, target client.ObjectKey) bool {
 	for _, i := range list {
 		if i == target {
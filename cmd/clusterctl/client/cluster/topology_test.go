@@ -36,6 +36,9 @@ var (
 	//go:embed assets/topology-test/new-clusterclass-and-cluster.yaml
 	newClusterClassAndClusterYAML []byte
 
+	//go:embed assets/topology-test/new-clusterclass-with-patches-and-cluster.yaml
+	newClusterClassWithPatchesAndClusterYAML []byte
+
 	//go:embed assets/topology-test/mock-CRDs.yaml
 	mockCRDsYAML []byte
 
@@ -56,6 +59,15 @@ var (
 	//go:embed assets/topology-test/modified-CP-dockermachinetemplate.yaml
 	modifiedDockerMachineTemplateYAML []byte
 
+	// existingMyClusterClassRevisionsYAML has two revisions of my-cluster-class; the first revision uses another
+	// DockerMachineTemplate for the control plane, the second revision adds nodeDeletionTimeout to the control plane.
+	//go:embed assets/topology-test/my-cluster-class-revisions.yaml
+	existingMyClusterClassRevisionsYAML []byte
+
+	// rebasedMyClusterYAML pins my-cluster to the second revision of my-cluster-class.
+	//go:embed assets/topology-test/rebased-my-cluster.yaml
+	rebasedMyClusterYAML []byte
+
 	//go:embed assets/topology-test/objects-in-different-namespaces.yaml
 	objsInDifferentNamespacesYAML []byte
 )
@@ -241,6 +253,64 @@ func Test_topologyClient_Plan(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Rebasing an existing Cluster to a revision of the ClusterClass",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+				existingMyClusterClassRevisionsYAML,
+				existingMyClusterYAML,
+			),
+			args: args{
+				in: &TopologyPlanInput{
+					Objs: mustToUnstructured(rebasedMyClusterYAML),
+				},
+			},
+			want: out{
+				affectedClusters: func() []client.ObjectKey {
+					cluster := client.ObjectKey{Namespace: "default", Name: "my-cluster"}
+					return []client.ObjectKey{cluster}
+				}(),
+				affectedClusterClasses: []client.ObjectKey{},
+				modified: []item{
+					{kind: "KubeadmControlPlane", namespace: "default", namePrefix: "my-cluster-"},
+				},
+				reconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Modifying an existing DockerMachineTemplate. Clusters pinned to a revision not using the template are not affected.",
+			existingObjects: append(
+				mustToUnstructured(
+					mockCRDsYAML,
+					existingMyClusterClassYAML,
+					existingMyClusterClassRevisionsYAML,
+					existingMyClusterYAML,
+				),
+				pinnedToRevision(mustToUnstructured(existingMySecondClusterYAML), "my-cluster-class-1")...,
+			),
+			args: args{
+				in: &TopologyPlanInput{
+					Objs: mustToUnstructured(modifiedDockerMachineTemplateYAML),
+				},
+			},
+			want: out{
+				affectedClusters: func() []client.ObjectKey {
+					cluster := client.ObjectKey{Namespace: "default", Name: "my-cluster"}
+					return []client.ObjectKey{cluster}
+				}(),
+				affectedClusterClasses: func() []client.ObjectKey {
+					cc := client.ObjectKey{Namespace: "default", Name: "my-cluster-class"}
+					return []client.ObjectKey{cc}
+				}(),
+				modified: []item{
+					{kind: "KubeadmControlPlane", namespace: "default", namePrefix: "my-cluster-"},
+				},
+				reconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Input with objects in different namespaces should return error",
 			args: args{
@@ -328,6 +398,104 @@ func Test_topologyClient_Plan(t *testing.T) {
 	}
 }
 
+// pinnedToRevision pins the Clusters in objs to the given revision of their ClusterClass.
+func pinnedToRevision(objs []*unstructured.Unstructured, classRevision string) []*unstructured.Unstructured {
+	for _, obj := range objs {
+		if obj.GetKind() == "Cluster" {
+			if err := unstructured.SetNestedField(obj.Object, classRevision, "spec", "topology", "classRevision"); err != nil {
+				panic(err)
+			}
+		}
+	}
+	return objs
+}
+
+func Test_topologyClient_RenderPatches(t *testing.T) {
+	type generatedPatch struct {
+		patchName    string
+		templateKind string
+		holderKind   string
+		holderName   string
+		patch        string
+	}
+	tests := []struct {
+		name    string
+		in      *TopologyPlanInput
+		want    []generatedPatch
+		wantErr bool
+	}{
+		{
+			name: "Input with new ClusterClass with patches and new Cluster",
+			in: &TopologyPlanInput{
+				Objs: mustToUnstructured(newClusterClassWithPatchesAndClusterYAML),
+			},
+			want: []generatedPatch{
+				{
+					patchName:    "imageRepository",
+					templateKind: "KubeadmControlPlaneTemplate",
+					holderKind:   "Cluster",
+					holderName:   "my-cluster",
+					patch:        `[{"op":"add","path":"/spec/template/spec/kubeadmConfigSpec/clusterConfiguration/imageRepository","value":"registry.k8s.io"}]`,
+				},
+				{
+					patchName:    "clusterName",
+					templateKind: "KubeadmConfigTemplate",
+					holderKind:   "MachineDeployment",
+					holderName:   "my-cluster-md-0-",
+					patch:        `{"spec":{"template":{"spec":{"preKubeadmCommands":["echo \"my-cluster\""]}}}}`,
+				},
+				{
+					patchName:    "clusterName",
+					templateKind: "KubeadmConfigTemplate",
+					holderKind:   "MachineDeployment",
+					holderName:   "my-cluster-md-1-",
+					patch:        `{"spec":{"template":{"spec":{"preKubeadmCommands":["echo \"my-cluster\""]}}}}`,
+				},
+			},
+		},
+		{
+			name: "Input without Cluster",
+			in: &TopologyPlanInput{
+				Objs: mustToUnstructured(existingMyClusterClassYAML),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			proxy := test.NewFakeProxy().WithClusterAvailable(false)
+			inventoryClient := newInventoryClient(proxy, nil)
+			tc := newTopologyClient(
+				proxy,
+				inventoryClient,
+			)
+
+			res, err := tc.RenderPatches(ctx, tt.in)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(res.ReconciledCluster).ToNot(BeNil())
+			g.Expect(res.GeneratedPatches).To(HaveLen(len(tt.want)))
+			for _, want := range tt.want {
+				g.Expect(res.GeneratedPatches).To(ContainElement(SatisfyAll(
+					HaveField("PatchName", want.patchName),
+					HaveField("TemplateRef.Kind", want.templateKind),
+					HaveField("HolderReference.Kind", want.holderKind),
+					HaveField("HolderReference.Name", HavePrefix(want.holderName)),
+					HaveField("Patch", WithTransform(func(b []byte) string { return string(b) }, Equal(want.patch))),
+				)))
+			}
+		})
+	}
+}
+
 func MatchTopologyPlanOutputItem(kind, namespace, namePrefix string) types.GomegaMatcher {
 	return &topologyPlanOutputItemMatcher{kind, namespace, namePrefix}
 }
@@ -48,16 +48,72 @@ type Provider interface {
 	Less(other Provider) bool
 }
 
+// VerifiableProvider is an optional interface implemented by providers defining settings for verifying
+// the integrity of the provider components.
+type VerifiableProvider interface {
+	// Verification returns the settings for verifying the integrity of the provider components.
+	Verification() ProviderVerification
+}
+
+// GetProviderVerification returns the settings for verifying the integrity of the provider components;
+// verification is disabled for providers not implementing VerifiableProvider.
+func GetProviderVerification(p Provider) ProviderVerification {
+	if v, ok := p.(VerifiableProvider); ok {
+		return v.Verification()
+	}
+	return ProviderVerification{}
+}
+
+// VerificationPolicy defines how clusterctl handles the verification of the provider components.
+type VerificationPolicy string
+
+const (
+	// VerificationPolicyRequired blocks the installation of provider components failing verification.
+	VerificationPolicyRequired VerificationPolicy = "required"
+
+	// VerificationPolicyWarn logs a warning for provider components failing verification, but allows installation.
+	VerificationPolicyWarn VerificationPolicy = "warn"
+
+	// VerificationPolicyOff disables verification of the provider components; this is the default.
+	VerificationPolicyOff VerificationPolicy = "off"
+)
+
+// ProviderVerification defines the settings for verifying the integrity of the provider components
+// before installing them.
+type ProviderVerification struct {
+	// Policy defines how to handle verification failures; if empty, verification is disabled.
+	Policy VerificationPolicy `json:"policy,omitempty"`
+
+	// ChecksumsFile is the name of a file, published in the provider repository next to the components YAML,
+	// containing the sha256 checksums of the release assets in the format used by sha256sum.
+	// If empty and no PublicKeys are defined, the checksums.txt file is used.
+	ChecksumsFile string `json:"checksumsFile,omitempty"`
+
+	// PublicKeys is a list of PEM encoded public keys trusted for verifying the detached signature
+	// of the components YAML, which is expected to be published in the provider repository
+	// next to the components YAML with the .sig suffix.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+}
+
+// IsEnabled returns true if verification of the provider components is enabled.
+func (v ProviderVerification) IsEnabled() bool {
+	return v.Policy != "" && v.Policy != VerificationPolicyOff
+}
+
 // provider implements Provider.
 type provider struct {
 	name         string
 	url          string
 	providerType clusterctlv1.ProviderType
+	verification ProviderVerification
 }
 
 // ensure provider implements provider.
 var _ Provider = &provider{}
 
+// ensure provider implements VerifiableProvider.
+var _ VerifiableProvider = &provider{}
+
 func (p *provider) Name() string {
 	return p.name
 }
@@ -83,6 +139,10 @@ func (p *provider) Less(other Provider) bool {
 		(p.providerType.Order() == other.Type().Order() && p.name < other.Name())
 }
 
+func (p *provider) Verification() ProviderVerification {
+	return p.verification
+}
+
 // NewProvider creates a new Provider with the given input.
 func NewProvider(name string, url string, ttype clusterctlv1.ProviderType) Provider {
 	return &provider{
@@ -92,6 +152,17 @@ func NewProvider(name string, url string, ttype clusterctlv1.ProviderType) Provi
 	}
 }
 
+// NewProviderWithVerification creates a new Provider with the given input, including the
+// settings for verifying the integrity of the provider components.
+func NewProviderWithVerification(name string, url string, ttype clusterctlv1.ProviderType, verification ProviderVerification) Provider {
+	return &provider{
+		name:         name,
+		url:          url,
+		providerType: ttype,
+		verification: verification,
+	}
+}
+
 func (p provider) MarshalJSON() ([]byte, error) {
 	dir, file := filepath.Split(p.url)
 	j, err := json.Marshal(struct {
@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"encoding/pem"
 	"net/url"
 	"os"
 	"sort"
@@ -346,9 +347,10 @@ func (p *providersClient) defaults() []Provider {
 
 // configProvider mirrors config.Provider interface and allows serialization of the corresponding info.
 type configProvider struct {
-	Name string                    `json:"name,omitempty"`
-	URL  string                    `json:"url,omitempty"`
-	Type clusterctlv1.ProviderType `json:"type,omitempty"`
+	Name         string                    `json:"name,omitempty"`
+	URL          string                    `json:"url,omitempty"`
+	Type         clusterctlv1.ProviderType `json:"type,omitempty"`
+	Verification ProviderVerification      `json:"verification,omitempty"`
 }
 
 func (p *providersClient) List() ([]Provider, error) {
@@ -370,7 +372,7 @@ func (p *providersClient) List() ([]Provider, error) {
 			return nil, errors.Wrapf(err, "unable to evaluate url: %q", u.URL)
 		}
 
-		provider := NewProvider(u.Name, u.URL, u.Type)
+		provider := NewProviderWithVerification(u.Name, u.URL, u.Type, u.Verification)
 		if err := validateProvider(provider); err != nil {
 			return nil, errors.Wrapf(err, "error validating configuration for the %s with name %s. Please fix the providers value in clusterctl configuration file", provider.Type(), provider.Name())
 		}
@@ -451,5 +453,28 @@ func validateProvider(r Provider) error {
 			clusterctlv1.RuntimeExtensionProviderType,
 			clusterctlv1.AddonProviderType)
 	}
+
+	if err := validateProviderVerification(GetProviderVerification(r)); err != nil {
+		return errors.Wrap(err, "invalid verification settings")
+	}
+	return nil
+}
+
+func validateProviderVerification(v ProviderVerification) error {
+	switch v.Policy {
+	case "", VerificationPolicyOff, VerificationPolicyWarn, VerificationPolicyRequired:
+		break
+	default:
+		return errors.Errorf("invalid policy %q. Allowed values are [%s, %s, %s]", v.Policy,
+			VerificationPolicyRequired,
+			VerificationPolicyWarn,
+			VerificationPolicyOff)
+	}
+
+	for i, k := range v.PublicKeys {
+		if block, _ := pem.Decode([]byte(k)); block == nil {
+			return errors.Errorf("public key at index %d is not PEM encoded", i)
+		}
+	}
 	return nil
 }
@@ -47,6 +47,15 @@ func Test_providers_List(t *testing.T) {
 		return defaultsAndZZZ[i].Less(defaultsAndZZZ[j])
 	})
 
+	defaultsAndZZZWithVerification := append([]Provider{}, defaults...)
+	defaultsAndZZZWithVerification = append(defaultsAndZZZWithVerification, NewProviderWithVerification("zzz", "https://zzz/infrastructure-components.yaml", "InfrastructureProvider", ProviderVerification{
+		Policy:        VerificationPolicyWarn,
+		ChecksumsFile: "SHA256SUMS",
+	}))
+	sort.Slice(defaultsAndZZZWithVerification, func(i, j int) bool {
+		return defaultsAndZZZWithVerification[i].Less(defaultsAndZZZWithVerification[j])
+	})
+
 	defaultsWithOverride := append([]Provider{}, defaults...)
 	defaultsWithOverride[0] = NewProvider(defaults[0].Name(), "https://zzz/infrastructure-components.yaml", defaults[0].Type())
 
@@ -113,6 +122,23 @@ func Test_providers_List(t *testing.T) {
 			want:    defaultsWithOverride,
 			wantErr: false,
 		},
+		{
+			name: "Returns user defined provider configurations with verification settings",
+			fields: fields{
+				configGetter: test.NewFakeReader().
+					WithVar(
+						ProvidersConfigKey,
+						"- name: \"zzz\"\n"+
+							"  url: \"https://zzz/infrastructure-components.yaml\"\n"+
+							"  type: \"InfrastructureProvider\"\n"+
+							"  verification:\n"+
+							"    policy: \"warn\"\n"+
+							"    checksumsFile: \"SHA256SUMS\"\n",
+					),
+			},
+			want:    defaultsAndZZZWithVerification,
+			wantErr: false,
+		},
 		{
 			name: "Fails for invalid user defined provider configurations",
 			fields: fields{
@@ -249,6 +275,35 @@ func Test_validateProvider(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Pass with verification settings",
+			args: args{
+				r: NewProviderWithVerification("foo", "https://something.com", clusterctlv1.InfrastructureProviderType, ProviderVerification{
+					Policy:     VerificationPolicyRequired,
+					PublicKeys: []string{"-----BEGIN PUBLIC KEY-----\nMCowBQYDK2VwAyEAGb9ECWmEzf6FQbrBZ9w7lshQhqowtrbLDFw4rXAxZuE=\n-----END PUBLIC KEY-----\n"},
+				}),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Fails if verification policy is not valid",
+			args: args{
+				r: NewProviderWithVerification("foo", "https://something.com", clusterctlv1.InfrastructureProviderType, ProviderVerification{
+					Policy: "bar",
+				}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fails if verification public key is not PEM encoded",
+			args: args{
+				r: NewProviderWithVerification("foo", "https://something.com", clusterctlv1.InfrastructureProviderType, ProviderVerification{
+					Policy:     VerificationPolicyRequired,
+					PublicKeys: []string{"not-a-key"},
+				}),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -98,3 +98,51 @@ func (c *clusterctlClient) DescribeCluster(ctx context.Context, options Describe
 		Grouping:                options.Grouping,
 	})
 }
+
+// DescribeClustersOptions carries the options supported by DescribeClusters.
+type DescribeClustersOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the workload clusters are located. If unspecified, the current namespace will be used.
+	Namespace string
+
+	// AllNamespaces instructs DescribeClusters to summarize the workload clusters in all the namespaces.
+	AllNamespaces bool
+}
+
+// DescribeClusters returns a summary of the status of all the Cluster API clusters in a management cluster.
+func (c *clusterctlClient) DescribeClusters(ctx context.Context, options DescribeClustersOptions) ([]tree.ClusterSummary, error) {
+	// gets access to the management cluster
+	cluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := cluster.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+		return nil, err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it, unless all the namespaces should be considered.
+	namespace := options.Namespace
+	if options.AllNamespaces {
+		namespace = ""
+	} else if namespace == "" {
+		currentNamespace, err := cluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return nil, err
+		}
+		namespace = currentNamespace
+	}
+
+	// Fetch the Cluster client.
+	client, err := cluster.Proxy().NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Gets the summary of the status of the Cluster API clusters.
+	return tree.SummarizeClusters(ctx, client, namespace)
+}
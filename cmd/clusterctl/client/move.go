@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 )
@@ -39,6 +40,14 @@ type MoveOptions struct {
 	// namespace will be used.
 	Namespace string
 
+	// Clusters defines the names of the Clusters to be moved. If Clusters and ClusterSelector are unspecified,
+	// all the Clusters in the namespace will be moved.
+	Clusters []string
+
+	// ClusterSelector defines a label selector for the Clusters to be moved. If Clusters and ClusterSelector are unspecified,
+	// all the Clusters in the namespace will be moved.
+	ClusterSelector string
+
 	// ExperimentalResourceMutatorFn accepts any number of resource mutator functions that are applied on all resources being moved.
 	// This is an experimental feature and is exposed only from the library and not (yet) through the CLI.
 	ExperimentalResourceMutators []cluster.ResourceMutatorFunc
@@ -51,6 +60,14 @@ type MoveOptions struct {
 
 	// DryRun means the move action is a dry run, no real action will be performed.
 	DryRun bool
+
+	// Resume means the move action resumes a previous move that did not complete, using the move journal
+	// stored in the source management cluster.
+	Resume bool
+
+	// Rollback means the move action rolls back a previous move that did not complete, using the move journal
+	// stored in the source management cluster.
+	Rollback bool
 }
 
 func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error {
@@ -66,6 +83,21 @@ func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error
 		return errors.Errorf("at least one of FromDirectory, ToDirectory and ToKubeconfig must be set")
 	}
 
+	if options.Resume || options.Rollback {
+		if options.Resume && options.Rollback {
+			return errors.Errorf("can't set both Resume and Rollback")
+		}
+		if options.DryRun || options.FromDirectory != "" || options.ToDirectory != "" {
+			return errors.Errorf("Resume and Rollback can't be used with DryRun, FromDirectory or ToDirectory")
+		}
+	}
+
+	if len(options.Clusters) > 0 || options.ClusterSelector != "" {
+		if options.FromDirectory != "" || options.ToDirectory != "" || options.Resume || options.Rollback {
+			return errors.Errorf("Clusters and ClusterSelector can't be used with FromDirectory, ToDirectory, Resume or Rollback")
+		}
+	}
+
 	if options.ToDirectory != "" {
 		return c.toDirectory(ctx, options)
 	} else if options.FromDirectory != "" {
@@ -99,6 +131,21 @@ func (c *clusterctlClient) move(ctx context.Context, options MoveOptions) error
 		}
 	}
 
+	if options.Resume {
+		return fromCluster.ObjectMover().Resume(ctx, options.Namespace, toCluster, options.ExperimentalResourceMutators...)
+	}
+	if options.Rollback {
+		return fromCluster.ObjectMover().Rollback(ctx, options.Namespace, toCluster, options.ExperimentalResourceMutators...)
+	}
+	if len(options.Clusters) > 0 || options.ClusterSelector != "" {
+		filter := cluster.ClusterFilter{Names: options.Clusters}
+		if options.ClusterSelector != "" {
+			if filter.Selector, err = labels.Parse(options.ClusterSelector); err != nil {
+				return errors.Wrapf(err, "failed to parse cluster selector %q", options.ClusterSelector)
+			}
+		}
+		return fromCluster.ObjectMover().MoveClusters(ctx, options.Namespace, filter, toCluster, options.DryRun, options.ExperimentalResourceMutators...)
+	}
 	return fromCluster.ObjectMover().Move(ctx, options.Namespace, toCluster, options.DryRun, options.ExperimentalResourceMutators...)
 }
 
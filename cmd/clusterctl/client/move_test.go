@@ -124,6 +124,106 @@ func Test_clusterctlClient_Move(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "does not return an error when resuming a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Resume:         true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "does not return an error when rolling back a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Rollback:       true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "does not return an error when moving selected clusters",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Clusters:        []string{"foo"},
+					ClusterSelector: "env=prod",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if the cluster selector is not valid",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					ClusterSelector: "env in (prod",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if selected clusters are moved to a directory",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToDirectory:    "/var/cache/toDirectory",
+					Clusters:       []string{"foo"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if both Resume and Rollback are set",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Resume:         true,
+					Rollback:       true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if Resume is used with DryRun",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					Resume:         true,
+					DryRun:         true,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -303,14 +403,30 @@ func fakeClientForMove() *fakeClient {
 
 type fakeObjectMover struct {
 	moveErr          error
+	resumeErr        error
+	rollbackErr      error
 	toDirectoryErr   error
 	fromDirectoryErr error
+	toArchiveErr     error
+	fromArchiveErr   error
 }
 
 func (f *fakeObjectMover) Move(_ context.Context, _ string, _ cluster.Client, _ bool, _ ...cluster.ResourceMutatorFunc) error {
 	return f.moveErr
 }
 
+func (f *fakeObjectMover) MoveClusters(_ context.Context, _ string, _ cluster.ClusterFilter, _ cluster.Client, _ bool, _ ...cluster.ResourceMutatorFunc) error {
+	return f.moveErr
+}
+
+func (f *fakeObjectMover) Resume(_ context.Context, _ string, _ cluster.Client, _ ...cluster.ResourceMutatorFunc) error {
+	return f.resumeErr
+}
+
+func (f *fakeObjectMover) Rollback(_ context.Context, _ string, _ cluster.Client, _ ...cluster.ResourceMutatorFunc) error {
+	return f.rollbackErr
+}
+
 func (f *fakeObjectMover) ToDirectory(_ context.Context, _ string, _ string) error {
 	return f.toDirectoryErr
 }
@@ -326,3 +442,11 @@ func (f *fakeObjectMover) FromDirectory(_ context.Context, _ cluster.Client, _ s
 func (f *fakeObjectMover) Restore(_ context.Context, _ cluster.Client, _ string) error {
 	return f.fromDirectoryErr
 }
+
+func (f *fakeObjectMover) ToArchive(_ context.Context, _ string) error {
+	return f.toArchiveErr
+}
+
+func (f *fakeObjectMover) FromArchive(_ context.Context, _ cluster.Client, _ string) error {
+	return f.fromArchiveErr
+}
@@ -79,6 +79,13 @@ func Test_newRepositoryClient_LocalFileSystemRepository(t *testing.T) {
 			},
 			expected: &gitLabRepository{},
 		},
+		{
+			name: "successfully creates repository client with OCI backend",
+			fields: fields{
+				provider: config.NewProvider("bar", "oci://registry.example.org/capi/bootstrap-bar/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			},
+			expected: &ociRepository{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -96,8 +96,37 @@ func (f *componentsClient) getRawBytes(ctx context.Context, options *ComponentsO
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to read %q from provider's repository %q", path, f.provider.ManifestLabel())
 		}
+
+		if err := f.verify(ctx, options.Version, path, file); err != nil {
+			return nil, err
+		}
 	} else {
 		log.Info("Using", "Override", path, "Provider", f.provider.ManifestLabel(), "Version", options.Version)
 	}
 	return file, nil
 }
+
+// verify checks the integrity of the components YAML according to the verification settings of the provider.
+func (f *componentsClient) verify(ctx context.Context, version, path string, file []byte) error {
+	log := logf.Log
+
+	verification := config.GetProviderVerification(f.provider)
+	if !verification.IsEnabled() {
+		return nil
+	}
+
+	verifier := &componentsVerifier{
+		repository:   f.repository,
+		verification: verification,
+	}
+	if err := verifier.Verify(ctx, version, path, file); err != nil {
+		if verification.Policy == config.VerificationPolicyWarn {
+			log.Info("Warning: verification of the provider components failed", "File", path, "Provider", f.provider.ManifestLabel(), "Version", version, "Error", err.Error())
+			return nil
+		}
+		return errors.Wrapf(err, "failed to verify %q from provider's repository %q; installation is blocked by the %q verification policy", path, f.provider.ManifestLabel(), verification.Policy)
+	}
+
+	log.V(5).Info("Verified", "File", path, "Provider", f.provider.ManifestLabel(), "Version", version)
+	return nil
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	defaultChecksumsFile = "checksums.txt"
+	signatureFileSuffix  = ".sig"
+)
+
+// componentsVerifier verifies the integrity of a file fetched from a provider repository using
+// a checksums file and/or a detached signature published next to the file itself.
+type componentsVerifier struct {
+	repository   Repository
+	verification config.ProviderVerification
+}
+
+// Verify verifies the content of a file with a given version and path.
+func (v *componentsVerifier) Verify(ctx context.Context, version, filePath string, content []byte) error {
+	// If trusted public keys are defined, signature verification is performed; the checksum verification is
+	// performed only if explicitly configured or if signature verification is not possible.
+	if len(v.verification.PublicKeys) > 0 {
+		if err := v.verifySignature(ctx, version, filePath, content); err != nil {
+			return err
+		}
+	}
+	if v.verification.ChecksumsFile != "" || len(v.verification.PublicKeys) == 0 {
+		if err := v.verifyChecksum(ctx, version, filePath, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyChecksum checks the sha256 checksum of a file against the value in the checksums file.
+func (v *componentsVerifier) verifyChecksum(ctx context.Context, version, filePath string, content []byte) error {
+	checksumsFile := v.verification.ChecksumsFile
+	if checksumsFile == "" {
+		checksumsFile = defaultChecksumsFile
+	}
+
+	checksums, err := v.repository.GetFile(ctx, version, checksumsFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read checksums file %q", checksumsFile)
+	}
+
+	expected, err := findChecksum(checksums, filePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read checksums file %q", checksumsFile)
+	}
+
+	actual := sha256.Sum256(content)
+	if !strings.EqualFold(expected, hex.EncodeToString(actual[:])) {
+		return errors.Errorf("checksum mismatch for %q: expected sha256 %s, got %s", filePath, expected, hex.EncodeToString(actual[:]))
+	}
+	return nil
+}
+
+// findChecksum returns the checksum for a file from a checksums file in the format used by sha256sum,
+// e.g. "{checksum}  {file name}".
+// File names must match the path of the file in the repository; matching only the base name is allowed
+// when all the entries of the checksums file are bare file names, like e.g. the ones generated for release assets.
+func findChecksum(checksums []byte, filePath string) (string, error) {
+	filePath = path.Clean(filePath)
+
+	baseNameChecksum := ""
+	bareFileNames := true
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// NOTE: sha256sum prefixes the file name with * when using binary mode.
+		fileName := path.Clean(strings.TrimPrefix(fields[1], "*"))
+		if fileName == filePath {
+			return fields[0], nil
+		}
+		if strings.Contains(fileName, "/") {
+			bareFileNames = false
+			continue
+		}
+		if baseNameChecksum == "" && fileName == path.Base(filePath) {
+			baseNameChecksum = fields[0]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	if bareFileNames && baseNameChecksum != "" {
+		return baseNameChecksum, nil
+	}
+	return "", errors.Errorf("checksum for %q not found", filePath)
+}
+
+// verifySignature checks the detached signature of a file using the trusted public keys; verification succeeds
+// if the signature can be verified with at least one of the keys.
+// Signatures are expected to be base64 encoded, like e.g. the ones generated by `cosign sign-blob`, or raw bytes.
+func (v *componentsVerifier) verifySignature(ctx context.Context, version, filePath string, content []byte) error {
+	signatureFile := filePath + signatureFileSuffix
+	rawSignature, err := v.repository.GetFile(ctx, version, signatureFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read signature file %q", signatureFile)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(rawSignature)))
+	if err != nil {
+		signature = rawSignature
+	}
+
+	digest := sha256.Sum256(content)
+	for i, k := range v.verification.PublicKeys {
+		publicKey, err := parsePublicKey(k)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse public key at index %d", i)
+		}
+
+		switch key := publicKey.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, digest[:], signature) {
+				return nil
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil {
+				return nil
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(key, content, signature) {
+				return nil
+			}
+		default:
+			return errors.Errorf("unsupported type %T for public key at index %d", publicKey, i)
+		}
+	}
+	return errors.Errorf("signature %q for %q can't be verified with any of the trusted public keys", signatureFile, filePath)
+}
+
+// parsePublicKey parses a PEM encoded PKIX public key.
+func parsePublicKey(key string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
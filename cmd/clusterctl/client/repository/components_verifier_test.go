@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func encodePublicKey(t *testing.T, key crypto.PublicKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func Test_componentsClient_Verify(t *testing.T) {
+	components := []byte("components")
+	digest := sha256.Sum256(components)
+	checksums := []byte(fmt.Sprintf("%s  components.yaml\n%s  metadata.yaml\n", hex.EncodeToString(digest[:]), hex.EncodeToString(make([]byte, 32))))
+	wrongChecksums := []byte(fmt.Sprintf("%s  components.yaml\n", hex.EncodeToString(make([]byte, 32))))
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaSignature, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ed25519PublicKey, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Signature := ed25519.Sign(ed25519Key, components)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configClient, err := config.New(context.Background(), "", config.InjectReader(test.NewFakeReader()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		verification config.ProviderVerification
+		files        map[string][]byte
+		wantErr      bool
+	}{
+		{
+			name:         "verification disabled",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyOff},
+			files:        map[string][]byte{},
+			wantErr:      false,
+		},
+		{
+			name:         "checksum verification passes",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired},
+			files:        map[string][]byte{"checksums.txt": checksums},
+			wantErr:      false,
+		},
+		{
+			name:         "checksum verification passes with a custom checksums file",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired, ChecksumsFile: "SHA256SUMS"},
+			files:        map[string][]byte{"SHA256SUMS": checksums},
+			wantErr:      false,
+		},
+		{
+			name:         "checksum verification fails for wrong checksum",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired},
+			files:        map[string][]byte{"checksums.txt": wrongChecksums},
+			wantErr:      true,
+		},
+		{
+			name:         "checksum verification fails for missing checksums file",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired},
+			files:        map[string][]byte{},
+			wantErr:      true,
+		},
+		{
+			name:         "checksum verification failure is ignored with warn policy",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyWarn},
+			files:        map[string][]byte{"checksums.txt": wrongChecksums},
+			wantErr:      false,
+		},
+		{
+			name:         "ecdsa signature verification passes",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired, PublicKeys: []string{encodePublicKey(t, &otherKey.PublicKey), encodePublicKey(t, &ecdsaKey.PublicKey)}},
+			files:        map[string][]byte{"components.yaml.sig": []byte(base64.StdEncoding.EncodeToString(ecdsaSignature))},
+			wantErr:      false,
+		},
+		{
+			name:         "ed25519 signature verification passes",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired, PublicKeys: []string{encodePublicKey(t, ed25519PublicKey)}},
+			files:        map[string][]byte{"components.yaml.sig": ed25519Signature},
+			wantErr:      false,
+		},
+		{
+			name:         "signature verification fails for untrusted keys",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired, PublicKeys: []string{encodePublicKey(t, &otherKey.PublicKey)}},
+			files:        map[string][]byte{"components.yaml.sig": []byte(base64.StdEncoding.EncodeToString(ecdsaSignature))},
+			wantErr:      true,
+		},
+		{
+			name:         "signature verification fails for missing signature",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired, PublicKeys: []string{encodePublicKey(t, &ecdsaKey.PublicKey)}},
+			files:        map[string][]byte{},
+			wantErr:      true,
+		},
+		{
+			name:         "signature and checksum verification fails if checksum is wrong",
+			verification: config.ProviderVerification{Policy: config.VerificationPolicyRequired, ChecksumsFile: "checksums.txt", PublicKeys: []string{encodePublicKey(t, &ecdsaKey.PublicKey)}},
+			files:        map[string][]byte{"components.yaml.sig": []byte(base64.StdEncoding.EncodeToString(ecdsaSignature)), "checksums.txt": wrongChecksums},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			repository := NewMemoryRepository().
+				WithPaths("root", "components.yaml").
+				WithDefaultVersion("v1.0.0").
+				WithFile("v1.0.0", "components.yaml", components)
+			for name, content := range tt.files {
+				repository.WithFile("v1.0.0", name, content)
+			}
+
+			provider := config.NewProviderWithVerification("p1", "", clusterctlv1.BootstrapProviderType, tt.verification)
+			f := newComponentsClient(provider, repository, configClient)
+
+			got, err := f.Raw(context.Background(), ComponentsOptions{Version: "v1.0.0"})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(components))
+		})
+	}
+}
+
+func Test_findChecksum(t *testing.T) {
+	tests := []struct {
+		name      string
+		checksums string
+		filePath  string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "matches the file path",
+			checksums: "aaa  v1.0.0/components.yaml\nbbb  components.yaml\n",
+			filePath:  "v1.0.0/components.yaml",
+			want:      "aaa",
+		},
+		{
+			name:      "matches the file path in binary mode",
+			checksums: "aaa *./components.yaml\n",
+			filePath:  "components.yaml",
+			want:      "aaa",
+		},
+		{
+			name:      "matches the base name if the checksums file lists bare file names",
+			checksums: "aaa  metadata.yaml\nbbb  components.yaml\n",
+			filePath:  "v1.0.0/components.yaml",
+			want:      "bbb",
+		},
+		{
+			name:      "does not match the base name if the checksums file lists paths",
+			checksums: "aaa  other/components.yaml\nbbb  components.yaml\n",
+			filePath:  "v1.0.0/components.yaml",
+			wantErr:   true,
+		},
+		{
+			name:      "does not match a different path with the same base name",
+			checksums: "aaa  other/components.yaml\n",
+			filePath:  "components.yaml",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := findChecksum([]byte(tt.checksums), tt.filePath)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,460 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	ociScheme = "oci"
+
+	ociManifestMediaType      = "application/vnd.oci.image.manifest.v1+json"
+	ociImageTitleAnnotation   = "org.opencontainers.image.title"
+	ociRegistryRequestTimeout = 30 * time.Second
+)
+
+// ociManifest is the subset of an OCI image manifest used by the ociRepository.
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// ociDescriptor is the subset of an OCI content descriptor used by the ociRepository.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociTagList is the response of the OCI distribution tags/list endpoint.
+type ociTagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// ociRepository provides support for providers hosted on an OCI registry.
+//
+// We support OCI artifacts where each provider version is published as a tag, and the provider files
+// (components YAML, metadata YAML and eventually the workload cluster templates) are stored as layers
+// of the artifact, identified by the org.opencontainers.image.title annotation; this is the
+// layout produced by e.g. `oras push`.
+type ociRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	host                  string
+	repository            string
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+	username              string
+	password              string
+	token                 string
+}
+
+var _ Repository = &ociRepository{}
+
+type ociRepositoryOption func(*ociRepository)
+
+func injectOCIHTTPClient(c *http.Client) ociRepositoryOption {
+	return func(o *ociRepository) {
+		o.httpClient = c
+	}
+}
+
+// NewOCIRepository returns an ociRepository implementation.
+func NewOCIRepository(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...ociRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	// Check if the url is an OCI repository and if the path is in the expected format.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if rURL.Scheme != ociScheme || rURL.Host == "" || len(urlSplit) < 3 {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{host}/{repository}/{latest|version-tag}/{componentsClient.yaml}")
+	}
+
+	// Extract all the info from url split.
+	repository := strings.Join(urlSplit[:len(urlSplit)-2], "/")
+	defaultVersion := urlSplit[len(urlSplit)-2]
+	componentsPath := urlSplit[len(urlSplit)-1]
+
+	repo := &ociRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		host:                  rURL.Host,
+		repository:            repository,
+		defaultVersion:        defaultVersion,
+		rootPath:              ".",
+		componentsPath:        componentsPath,
+	}
+
+	// Process ociRepositoryOptions.
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if username, err := configVariablesClient.Get(config.OCIUsernameVariable); err == nil {
+		repo.username = username
+	}
+	if password, err := configVariablesClient.Get(config.OCIPasswordVariable); err == nil {
+		repo.password = password
+	}
+
+	if defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(ctx, repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest release")
+		}
+	}
+
+	return repo, nil
+}
+
+// Host returns host field of ociRepository struct.
+func (o *ociRepository) Host() string {
+	return o.host
+}
+
+// Repository returns repository field of ociRepository struct.
+func (o *ociRepository) Repository() string {
+	return o.repository
+}
+
+// DefaultVersion returns defaultVersion field of ociRepository struct.
+func (o *ociRepository) DefaultVersion() string {
+	return o.defaultVersion
+}
+
+// RootPath returns rootPath field of ociRepository struct.
+func (o *ociRepository) RootPath() string {
+	return o.rootPath
+}
+
+// ComponentsPath returns componentsPath field of ociRepository struct.
+func (o *ociRepository) ComponentsPath() string {
+	return o.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository, that is
+// the list of tags of the OCI repository.
+func (o *ociRepository) GetVersions(ctx context.Context) ([]string, error) {
+	cacheID := fmt.Sprintf("oci://%s/%s", o.host, o.repository)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	versions := []string{}
+	next := fmt.Sprintf("/v2/%s/tags/list", o.repository)
+	for next != "" {
+		response, err := o.get(ctx, next, "application/json")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the list of tags for %q", o.repository)
+		}
+
+		tagList := &ociTagList{}
+		err = json.NewDecoder(response.Body).Decode(tagList)
+		response.Body.Close()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode the list of tags for %q", o.repository)
+		}
+		versions = append(versions, tagList.Tags...)
+
+		// Registries paginate the list of tags using a Link header, e.g. </v2/{repository}/tags/list?n=100&last=v1.0.0>; rel="next".
+		next = ociNextLink(response.Header.Get("Link"))
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (o *ociRepository) GetFile(ctx context.Context, version, fileName string) ([]byte, error) {
+	if version == "" {
+		version = o.defaultVersion
+	}
+
+	cacheID := fmt.Sprintf("oci://%s/%s:%s:%s", o.host, o.repository, version, fileName)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	manifest, err := o.getManifest(ctx, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", fileName, version)
+	}
+
+	// Search for the layer containing the file; layers are identified by their title annotation,
+	// which contains the file name the artifact has been pushed with.
+	fileName = filepath.ToSlash(filepath.Clean(fileName))
+	layer, err := findOCILayer(manifest, fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q from the OCI artifact %s/%s:%s", fileName, version, o.host, o.repository, version)
+	}
+
+	response, err := o.get(ctx, fmt.Sprintf("/v2/%s/blobs/%s", o.repository, layer.Digest), "*/*")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", fileName, version)
+	}
+	defer response.Body.Close()
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", fileName, version)
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// findOCILayer returns the layer of the manifest with a title matching the file name; if no layer matches
+// the file name, the layer with the same base name is returned, but only if there is exactly one.
+func findOCILayer(manifest *ociManifest, fileName string) (*ociDescriptor, error) {
+	var baseNameMatches []*ociDescriptor
+	for i := range manifest.Layers {
+		title, ok := manifest.Layers[i].Annotations[ociImageTitleAnnotation]
+		if !ok {
+			continue
+		}
+		title = path.Clean(title)
+		if title == fileName {
+			return &manifest.Layers[i], nil
+		}
+		if path.Base(title) == path.Base(fileName) {
+			baseNameMatches = append(baseNameMatches, &manifest.Layers[i])
+		}
+	}
+
+	switch len(baseNameMatches) {
+	case 0:
+		return nil, errors.New("the file is not included in the OCI artifact")
+	case 1:
+		return baseNameMatches[0], nil
+	default:
+		return nil, errors.Errorf("%d files with the same name are included in the OCI artifact", len(baseNameMatches))
+	}
+}
+
+// getManifest returns the manifest of the OCI artifact for a given version.
+func (o *ociRepository) getManifest(ctx context.Context, version string) (*ociManifest, error) {
+	response, err := o.get(ctx, fmt.Sprintf("/v2/%s/manifests/%s", o.repository, version), ociManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	manifest := &ociManifest{}
+	if err := json.NewDecoder(response.Body).Decode(manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the manifest for %s/%s:%s", o.host, o.repository, version)
+	}
+	return manifest, nil
+}
+
+// get executes a GET request against the OCI registry; in case the registry requires
+// token authentication or the current token expired, a new token is requested and the request is retried once.
+// The caller is responsible for closing the response body.
+func (o *ociRepository) get(ctx context.Context, urlPath, accept string) (*http.Response, error) {
+	response, err := o.do(ctx, urlPath, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		response.Body.Close()
+
+		o.token = ""
+		if err := o.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if response, err = o.do(ctx, urlPath, accept); err != nil {
+			return nil, err
+		}
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, errors.Wrapf(errNotFound, "failed to get %q from %q", urlPath, o.host)
+	default:
+		response.Body.Close()
+		return nil, errors.Errorf("failed to get %q from %q, got %d", urlPath, o.host, response.StatusCode)
+	}
+}
+
+func (o *ociRepository) do(ctx context.Context, urlPath, accept string) (*http.Response, error) {
+	timeoutctx, cancel := context.WithTimeout(ctx, ociRegistryRequestTimeout)
+	// NOTE: the context is canceled when the response body is closed.
+	request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, fmt.Sprintf("https://%s%s", o.host, urlPath), http.NoBody)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to create request for %q", urlPath)
+	}
+	request.Header.Set("Accept", accept)
+
+	switch {
+	case o.token != "":
+		request.Header.Set("Authorization", "Bearer "+o.token)
+	case o.username != "":
+		request.SetBasicAuth(o.username, o.password)
+	}
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrapf(err, "failed to get %q from %q", urlPath, o.host)
+	}
+	response.Body = &cancelOnCloseReader{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// authenticate requests a bearer token according to the challenge returned by the registry.
+// See https://distribution.github.io/distribution/spec/auth/token/ for more details.
+func (o *ociRepository) authenticate(ctx context.Context, challenge string) error {
+	params := parseOCIChallenge(challenge)
+	realm, ok := params["realm"]
+	if !ok {
+		return errors.Errorf("failed to authenticate to %q: the registry did not return a bearer token challenge", o.host)
+	}
+
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return errors.Wrapf(err, "failed to authenticate to %q: invalid realm %q", o.host, realm)
+	}
+	query := tokenURL.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v, ok := params[k]; ok {
+			query.Set(k, v)
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	timeoutctx, cancel := context.WithTimeout(ctx, ociRegistryRequestTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, tokenURL.String(), http.NoBody)
+	if err != nil {
+		return errors.Wrapf(err, "failed to authenticate to %q: failed to create request", o.host)
+	}
+	if o.username != "" {
+		request.SetBasicAuth(o.username, o.password)
+	}
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to authenticate to %q", o.host)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to authenticate to %q, got %d", o.host, response.StatusCode)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return errors.Wrapf(err, "failed to authenticate to %q: failed to decode token", o.host)
+	}
+
+	o.token = token.Token
+	if o.token == "" {
+		o.token = token.AccessToken
+	}
+	if o.token == "" {
+		return errors.Errorf("failed to authenticate to %q: the registry returned an empty token", o.host)
+	}
+	return nil
+}
+
+// parseOCIChallenge parses a WWW-Authenticate header in the form
+// Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo:pull".
+func parseOCIChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+
+	scheme, rest, ok := strings.Cut(challenge, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return params
+	}
+
+	for rest != "" {
+		var key, value string
+		key, rest, ok = strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if !ok {
+			break
+		}
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	return params
+}
+
+// ociNextLink returns the path of the next page from a Link header, if any.
+func ociNextLink(link string) string {
+	if !strings.Contains(link, `rel="next"`) {
+		return ""
+	}
+	start := strings.Index(link, "<")
+	end := strings.Index(link, ">")
+	if start == -1 || end < start {
+		return ""
+	}
+	// The link could be either a path or an absolute URL.
+	next, err := url.Parse(link[start+1 : end])
+	if err != nil {
+		return ""
+	}
+	return next.RequestURI()
+}
+
+// cancelOnCloseReader cancels a context when the underlying ReadCloser is closed.
+type cancelOnCloseReader struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelOnCloseReader) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
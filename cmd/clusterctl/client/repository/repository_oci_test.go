@@ -0,0 +1,366 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+// newFakeOCIRegistry returns a fake OCI registry hosting the capi/core repository with the
+// v1.0.0 and v1.1.0 tags, each one containing a components file and a metadata file.
+func newFakeOCIRegistry(t *testing.T) (*httptest.Server, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+
+	mux.HandleFunc("/v2/capi/core/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		// Serve the tags over two pages to test pagination.
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/capi/core/tags/list?n=2&last=v1.0.0>; rel="next"`)
+			fmt.Fprint(w, `{"name":"capi/core","tags":["not-a-semver","v1.0.0"]}`)
+			return
+		}
+		fmt.Fprint(w, `{"name":"capi/core","tags":["v1.1.0"]}`)
+	})
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		version := version
+		mux.HandleFunc("/v2/capi/core/manifests/"+version, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			g := NewWithT(t)
+			g.Expect(r.Header.Get("Accept")).To(Equal(ociManifestMediaType))
+			w.Header().Set("Content-Type", ociManifestMediaType)
+			fmt.Fprintf(w, `{"schemaVersion":2,"layers":[`+
+				`{"mediaType":"application/yaml","digest":"sha256:components-%[1]s","size":7,"annotations":{"org.opencontainers.image.title":"components.yaml"}},`+
+				`{"mediaType":"application/yaml","digest":"sha256:metadata-%[1]s","size":7,"annotations":{"org.opencontainers.image.title":"metadata.yaml"}}`+
+				`]}`, version)
+		})
+		mux.HandleFunc("/v2/capi/core/blobs/sha256:components-"+version, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprintf(w, "components-%s", version)
+		})
+		mux.HandleFunc("/v2/capi/core/blobs/sha256:metadata-"+version, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, "apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3\nreleaseSeries:\n  - major: 1\n    minor: 1\n    contract: v1beta1\n  - major: 1\n    minor: 0\n    contract: v1beta1\n")
+		})
+	}
+
+	return server, mux
+}
+
+func Test_ociRepository_newOCIRepository(t *testing.T) {
+	server, _ := newFakeOCIRegistry(t)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name           string
+		url            string
+		variableClient config.VariablesClient
+		wantRepository string
+		wantVersion    string
+		wantComponents string
+		wantErr        string
+	}{
+		{
+			name:           "can create a new OCI repo with a version",
+			url:            fmt.Sprintf("oci://%s/capi/core/v1.0.0/components.yaml", host),
+			variableClient: test.NewFakeVariableClient(),
+			wantRepository: "capi/core",
+			wantVersion:    "v1.0.0",
+			wantComponents: "components.yaml",
+		},
+		{
+			name:           "can create a new OCI repo resolving latest",
+			url:            fmt.Sprintf("oci://%s/capi/core/latest/components.yaml", host),
+			variableClient: test.NewFakeVariableClient(),
+			wantRepository: "capi/core",
+			wantVersion:    "v1.1.0",
+			wantComponents: "components.yaml",
+		},
+		{
+			name:           "missing variableClient",
+			url:            fmt.Sprintf("oci://%s/capi/core/v1.0.0/components.yaml", host),
+			variableClient: nil,
+			wantErr:        "invalid arguments: configVariablesClient can't be nil",
+		},
+		{
+			name:           "provider url should use the oci scheme",
+			url:            fmt.Sprintf("https://%s/capi/core/v1.0.0/components.yaml", host),
+			variableClient: test.NewFakeVariableClient(),
+			wantErr:        "invalid url: an OCI repository url should be in the form oci://{host}/{repository}/{latest|version-tag}/{componentsClient.yaml}",
+		},
+		{
+			name:           "provider url should have the correct number of parts",
+			url:            fmt.Sprintf("oci://%s/v1.0.0/components.yaml", host),
+			variableClient: test.NewFakeVariableClient(),
+			wantErr:        "invalid url: an OCI repository url should be in the form oci://{host}/{repository}/{latest|version-tag}/{componentsClient.yaml}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			providerConfig := config.NewProvider("test", tt.url, clusterctlv1.CoreProviderType)
+			repo, err := NewOCIRepository(context.Background(), providerConfig, tt.variableClient, injectOCIHTTPClient(server.Client()))
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(repo.(*ociRepository).Host()).To(Equal(host))
+			g.Expect(repo.(*ociRepository).Repository()).To(Equal(tt.wantRepository))
+			g.Expect(repo.DefaultVersion()).To(Equal(tt.wantVersion))
+			g.Expect(repo.ComponentsPath()).To(Equal(tt.wantComponents))
+			g.Expect(repo.RootPath()).To(Equal("."))
+		})
+	}
+}
+
+func Test_ociRepository_GetVersions(t *testing.T) {
+	g := NewWithT(t)
+	resetCaches()
+
+	server, _ := newFakeOCIRegistry(t)
+	defer server.Close()
+
+	providerConfig := config.NewProvider("test", fmt.Sprintf("oci://%s/capi/core/v1.0.0/components.yaml", strings.TrimPrefix(server.URL, "https://")), clusterctlv1.CoreProviderType)
+	repo, err := NewOCIRepository(context.Background(), providerConfig, test.NewFakeVariableClient(), injectOCIHTTPClient(server.Client()))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := repo.GetVersions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal([]string{"not-a-semver", "v1.0.0", "v1.1.0"}))
+}
+
+func Test_ociRepository_GetFile(t *testing.T) {
+	server, mux := newFakeOCIRegistry(t)
+	defer server.Close()
+
+	// Add a version with files with the same name in different directories.
+	mux.HandleFunc("/v2/capi/core/manifests/v1.2.0", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", ociManifestMediaType)
+		fmt.Fprint(w, `{"schemaVersion":2,"layers":[`+
+			`{"mediaType":"application/yaml","digest":"sha256:a-metadata","size":10,"annotations":{"org.opencontainers.image.title":"a/metadata.yaml"}},`+
+			`{"mediaType":"application/yaml","digest":"sha256:b-metadata","size":10,"annotations":{"org.opencontainers.image.title":"b/metadata.yaml"}},`+
+			`{"mediaType":"application/yaml","digest":"sha256:cluster-template","size":16,"annotations":{"org.opencontainers.image.title":"templates/cluster-template.yaml"}}`+
+			`]}`)
+	})
+	for _, blob := range []string{"a-metadata", "b-metadata", "cluster-template"} {
+		blob := blob
+		mux.HandleFunc("/v2/capi/core/blobs/sha256:"+blob, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, blob)
+		})
+	}
+
+	providerConfig := config.NewProvider("test", fmt.Sprintf("oci://%s/capi/core/v1.0.0/components.yaml", strings.TrimPrefix(server.URL, "https://")), clusterctlv1.CoreProviderType)
+
+	tests := []struct {
+		name     string
+		version  string
+		fileName string
+		want     []byte
+		wantErr  bool
+	}{
+		{
+			name:     "Version and file exist",
+			version:  "v1.1.0",
+			fileName: "components.yaml",
+			want:     []byte("components-v1.1.0"),
+			wantErr:  false,
+		},
+		{
+			name:     "Empty version defaults to the repository default version",
+			version:  "",
+			fileName: "components.yaml",
+			want:     []byte("components-v1.0.0"),
+			wantErr:  false,
+		},
+		{
+			name:     "File does not exist",
+			version:  "v1.0.0",
+			fileName: "cluster-template.yaml",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "File matches the title of a layer",
+			version:  "v1.2.0",
+			fileName: "b/metadata.yaml",
+			want:     []byte("b-metadata"),
+			wantErr:  false,
+		},
+		{
+			name:     "File matches the base name of exactly one layer",
+			version:  "v1.2.0",
+			fileName: "cluster-template.yaml",
+			want:     []byte("cluster-template"),
+			wantErr:  false,
+		},
+		{
+			name:     "File matches the base name of more than one layer",
+			version:  "v1.2.0",
+			fileName: "metadata.yaml",
+			want:     nil,
+			wantErr:  true,
+		},
+		{
+			name:     "Version does not exist",
+			version:  "v2.0.0",
+			fileName: "components.yaml",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewOCIRepository(context.Background(), providerConfig, test.NewFakeVariableClient(), injectOCIHTTPClient(server.Client()))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := repo.GetFile(context.Background(), tt.version, tt.fileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_ociRepository_tokenAuthentication(t *testing.T) {
+	g := NewWithT(t)
+	resetCaches()
+
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		g.Expect(r.URL.Query().Get("service")).To(Equal("registry"))
+		g.Expect(r.URL.Query().Get("scope")).To(Equal("repository:capi/core:pull"))
+		fmt.Fprint(w, `{"token":"secret-token"}`)
+	})
+	mux.HandleFunc("/v2/capi/core/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:capi/core:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"name":"capi/core","tags":["v1.0.0"]}`)
+	})
+
+	configVariablesClient := test.NewFakeVariableClient().
+		WithVar(config.OCIUsernameVariable, "user").
+		WithVar(config.OCIPasswordVariable, "pass")
+
+	providerConfig := config.NewProvider("test", fmt.Sprintf("oci://%s/capi/core/v1.0.0/components.yaml", host), clusterctlv1.CoreProviderType)
+	repo, err := NewOCIRepository(context.Background(), providerConfig, configVariablesClient, injectOCIHTTPClient(server.Client()))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := repo.GetVersions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal([]string{"v1.0.0"}))
+}
+
+func Test_parseOCIChallenge(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(parseOCIChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo/bar:pull"`)).To(Equal(map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:foo/bar:pull",
+	}))
+	g.Expect(parseOCIChallenge(`Basic realm="registry"`)).To(BeEmpty())
+}
+
+func Test_ociRepository_tokenRefresh(t *testing.T) {
+	g := NewWithT(t)
+	resetCaches()
+
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	// The registry accepts only the last token issued.
+	tokens := 0
+	validToken := ""
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		tokens++
+		validToken = fmt.Sprintf("token-%d", tokens)
+		fmt.Fprintf(w, `{"token":%q}`, validToken)
+	})
+	mux.HandleFunc("/v2/capi/core/tags/list", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if validToken == "" || r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:capi/core:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"name":"capi/core","tags":["v1.0.0"]}`)
+	})
+
+	providerConfig := config.NewProvider("test", fmt.Sprintf("oci://%s/capi/core/v1.0.0/components.yaml", host), clusterctlv1.CoreProviderType)
+	repo, err := NewOCIRepository(context.Background(), providerConfig, test.NewFakeVariableClient(), injectOCIHTTPClient(server.Client()))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := repo.GetVersions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal([]string{"v1.0.0"}))
+	g.Expect(tokens).To(Equal(1))
+
+	// Expire the token; a new token is requested.
+	validToken = ""
+	resetCaches()
+
+	got, err = repo.GetVersions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal([]string{"v1.0.0"}))
+	g.Expect(tokens).To(Equal(2))
+}
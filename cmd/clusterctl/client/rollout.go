@@ -20,13 +20,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 )
 
+// rolloutStatusInterval is the interval at which the status of a rollout is checked while waiting for it to finish.
+var rolloutStatusInterval = 5 * time.Second
+
 // RolloutRestartOptions carries the options supported by RolloutRestart.
 type RolloutRestartOptions struct {
 	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
@@ -86,6 +94,51 @@ type RolloutUndoOptions struct {
 	ToRevision int64
 }
 
+// RolloutHistoryOptions carries the options supported by RolloutHistory.
+type RolloutHistoryOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resources for the rollout command
+	Resources []string
+
+	// Namespace where the resource(s) live. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+}
+
+// RolloutStatusOptions carries the options supported by RolloutStatus.
+type RolloutStatusOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resources for the rollout command
+	Resources []string
+
+	// Namespace where the resource(s) live. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+
+	// Wait for the rollout of the resource(s) to finish; if false, the current status is reported
+	// without waiting.
+	Wait bool
+
+	// Timeout is the maximum time to wait for the rollout of the resource(s) to finish.
+	// If zero, there is no timeout.
+	Timeout time.Duration
+}
+
+// RolloutHistory is the rollout history of a cluster-api resource.
+type RolloutHistory struct {
+	// Object is the reference to the cluster-api resource.
+	Object corev1.ObjectReference
+
+	// Revisions are the revisions in the rollout history of the resource, sorted by revision.
+	Revisions []alpha.RolloutRevision
+}
+
 func (c *clusterctlClient) RolloutRestart(ctx context.Context, options RolloutRestartOptions) error {
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -154,6 +207,68 @@ func (c *clusterctlClient) RolloutUndo(ctx context.Context, options RolloutUndoO
 	return nil
 }
 
+func (c *clusterctlClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutHistory, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, options.Resources)
+	if err != nil {
+		return nil, err
+	}
+	histories := make([]RolloutHistory, 0, len(objRefs))
+	for _, ref := range objRefs {
+		revisions, err := c.alphaClient.Rollout().ObjectHistoryViewer(ctx, clusterClient.Proxy(), ref)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, RolloutHistory{Object: ref, Revisions: revisions})
+	}
+	return histories, nil
+}
+
+func (c *clusterctlClient) RolloutStatus(ctx context.Context, options RolloutStatusOptions) error {
+	log := logf.Log
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return err
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, options.Resources)
+	if err != nil {
+		return err
+	}
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+	for _, ref := range objRefs {
+		lastMessage := ""
+		var statusErr error
+		err := wait.PollUntilContextCancel(ctx, rolloutStatusInterval, true, func(ctx context.Context) (bool, error) {
+			status, err := c.alphaClient.Rollout().ObjectStatusViewer(ctx, clusterClient.Proxy(), ref)
+			if err != nil {
+				statusErr = err
+				return false, err
+			}
+			// Report progress only when it changes, to avoid flooding the output while waiting.
+			if status.Message != lastMessage {
+				log.Info(status.Message)
+				lastMessage = status.Message
+			}
+			return status.Done || !options.Wait, nil
+		})
+		if statusErr != nil {
+			return statusErr
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed waiting for the rollout of %s/%s to finish", ref.Kind, ref.Name)
+		}
+	}
+	return nil
+}
+
 func getObjectRefs(clusterClient cluster.Client, namespace string, resources []string) ([]corev1.ObjectReference, error) {
 	// If the option specifying the Namespace is empty, try to detect it.
 	if namespace == "" {
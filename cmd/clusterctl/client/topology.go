@@ -62,3 +62,41 @@ func (c *clusterctlClient) TopologyPlan(ctx context.Context, options TopologyPla
 
 	return out, err
 }
+
+// TopologyRenderPatchesOptions define options for TopologyRenderPatches.
+type TopologyRenderPatchesOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Objs is the list of objects that are input to the topology render patches (dry run) operation.
+	// The objects should include the Cluster, the ClusterClass and the templates referenced by the ClusterClass.
+	// Objects which are not part of the input are read from the management cluster, if available.
+	Objs []*unstructured.Unstructured
+
+	// Cluster is the name of the cluster to render patches for if multiple clusters are affected by the input.
+	Cluster string
+
+	// Namespace is the target namespace for the operation.
+	// This namespace is used as default for objects with missing namespaces.
+	// If the namespace of any of the input objects conflicts with Namespace an error is returned.
+	Namespace string
+}
+
+// TopologyRenderPatchesOutput defines the output of the topology render patches operation.
+type TopologyRenderPatchesOutput = cluster.TopologyRenderPatchesOutput
+
+// TopologyRenderPatches performs a dry run execution of the topology reconciler using the given inputs.
+// It returns the patches generated by the ClusterClass patches for the templates of the target cluster.
+func (c *clusterctlClient) TopologyRenderPatches(ctx context.Context, options TopologyRenderPatchesOptions) (*TopologyRenderPatchesOutput, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterClient.Topology().RenderPatches(ctx, &cluster.TopologyPlanInput{
+		Objs:              options.Objs,
+		TargetClusterName: options.Cluster,
+		TargetNamespace:   options.Namespace,
+	})
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// treeAnnotationPrefix is the prefix of the annotations used for signaling the presentation layer.
+const treeAnnotationPrefix = "tree.cluster.x-k8s.io.io/"
+
+// ObjectNode is a structured representation of an object in an ObjectTree and of its children,
+// e.g. for serializing an ObjectTree to JSON or YAML.
+type ObjectNode struct {
+	// APIVersion of the object; empty for virtual objects.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// MetaName is the name that should be used for the object in the presentation layer, if defined, e.g. ControlPlane.
+	MetaName string `json:"metaName,omitempty"`
+
+	// Virtual is true if the object does not correspond to any real object, e.g. Workers.
+	Virtual bool `json:"virtual,omitempty"`
+
+	// Group is true if the object represents a group of sibling objects with the same ready condition, e.g. a group of Machines.
+	Group bool `json:"group,omitempty"`
+
+	// GroupItems is the list of the names of the objects included in a group.
+	GroupItems []string `json:"groupItems,omitempty"`
+
+	// DeletionTimestamp of the object, if it is being deleted.
+	DeletionTimestamp *metav1.Time `json:"deletionTimestamp,omitempty"`
+
+	// Annotations used for signaling the presentation layer how to render the object.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Ready is the ready condition of the object, if defined.
+	Ready *clusterv1.Condition `json:"ready,omitempty"`
+
+	// Conditions is the list of all the other conditions of the object.
+	Conditions []clusterv1.Condition `json:"conditions,omitempty"`
+
+	// Children of the object in the tree.
+	Children []*ObjectNode `json:"children,omitempty"`
+}
+
+// Structured returns a structured representation of the ObjectTree, starting from the root object.
+func (od ObjectTree) Structured() *ObjectNode {
+	return od.structuredNode(od.root)
+}
+
+func (od ObjectTree) structuredNode(obj client.Object) *ObjectNode {
+	n := &ObjectNode{
+		Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		MetaName:  GetMetaName(obj),
+		Virtual:   IsVirtualObject(obj),
+		Group:     IsGroupObject(obj),
+		Ready:     GetReadyCondition(obj),
+	}
+	if !n.Virtual {
+		n.APIVersion = obj.GetObjectKind().GroupVersionKind().GroupVersion().String()
+	}
+	if n.Group {
+		n.GroupItems = strings.Split(GetGroupItems(obj), GroupItemsSeparator)
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		n.DeletionTimestamp = obj.GetDeletionTimestamp()
+	}
+	for k, v := range obj.GetAnnotations() {
+		if strings.HasPrefix(k, treeAnnotationPrefix) {
+			if n.Annotations == nil {
+				n.Annotations = map[string]string{}
+			}
+			n.Annotations[k] = v
+		}
+	}
+	for _, c := range GetOtherConditions(obj) {
+		n.Conditions = append(n.Conditions, *c)
+	}
+
+	// Children are sorted by z-order and name, consistently with the order used when printing the tree.
+	children := od.GetObjectsByParent(obj.GetUID())
+	sort.Slice(children, func(i, j int) bool {
+		if GetZOrder(children[i]) == GetZOrder(children[j]) {
+			return children[i].GetName() < children[j].GetName()
+		}
+		return GetZOrder(children[i]) > GetZOrder(children[j])
+	})
+	for _, child := range children {
+		n.Children = append(n.Children, od.structuredNode(child))
+	}
+	return n
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func Test_ObjectTree_Structured(t *testing.T) {
+	g := NewWithT(t)
+
+	root := fakeCluster("my-cluster",
+		withClusterCondition(conditions.TrueCondition(clusterv1.ReadyCondition)),
+		withClusterCondition(conditions.TrueCondition(clusterv1.InfrastructureReadyCondition)),
+	)
+	tree := NewObjectTree(root, ObjectTreeOptions{Grouping: true})
+
+	controlPlane := fakeMachine("control-plane-machine", withMachineCondition(conditions.FalseCondition(clusterv1.ReadyCondition, "Reason", clusterv1.ConditionSeverityWarning, "message")))
+	tree.Add(root, controlPlane, ObjectMetaName("ControlPlane"), ZOrder(1))
+
+	workers := VirtualObject("ns", "WorkerGroup", "Workers")
+	tree.Add(root, workers, GroupingObject(true))
+	for _, m := range []client.Object{
+		fakeMachine("worker-machine-1", withMachineCondition(conditions.TrueCondition(clusterv1.ReadyCondition))),
+		fakeMachine("worker-machine-2", withMachineCondition(conditions.TrueCondition(clusterv1.ReadyCondition))),
+	} {
+		tree.Add(workers, m)
+	}
+
+	got := tree.Structured()
+
+	g.Expect(got.Kind).To(Equal("Cluster"))
+	g.Expect(got.Name).To(Equal("my-cluster"))
+	g.Expect(got.Ready).ToNot(BeNil())
+	g.Expect(got.Ready.Status).To(BeEquivalentTo("True"))
+	g.Expect(got.Conditions).To(HaveLen(1))
+	g.Expect(got.Conditions[0].Type).To(Equal(clusterv1.InfrastructureReadyCondition))
+	g.Expect(got.Children).To(HaveLen(2))
+
+	// Children are sorted by z-order.
+	cp := got.Children[0]
+	g.Expect(cp.Name).To(Equal("control-plane-machine"))
+	g.Expect(cp.MetaName).To(Equal("ControlPlane"))
+	g.Expect(cp.Ready.Reason).To(Equal("Reason"))
+	g.Expect(cp.Annotations).To(HaveKeyWithValue(ObjectMetaNameAnnotation, "ControlPlane"))
+
+	w := got.Children[1]
+	g.Expect(w.Name).To(Equal("Workers"))
+	g.Expect(w.Virtual).To(BeTrue())
+	g.Expect(w.APIVersion).To(BeEmpty())
+	g.Expect(w.Children).To(HaveLen(1))
+	g.Expect(w.Children[0].Group).To(BeTrue())
+	g.Expect(w.Children[0].GroupItems).To(ConsistOf("worker-machine-1", "worker-machine-2"))
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ClusterSummary is a summary of the status of a Cluster API cluster.
+type ClusterSummary struct {
+	// Namespace of the Cluster.
+	Namespace string `json:"namespace"`
+
+	// Name of the Cluster.
+	Name string `json:"name"`
+
+	// TopologyClass is the name of the ClusterClass used by the Cluster, if it uses a managed topology.
+	TopologyClass string `json:"topologyClass,omitempty"`
+
+	// Version is the Kubernetes version of the Cluster, read from the managed topology or from the control plane.
+	Version string `json:"version,omitempty"`
+
+	// Paused is true if the reconciliation of the Cluster is paused.
+	Paused bool `json:"paused"`
+
+	// Ready is the ready condition of the Cluster, if defined.
+	Ready *clusterv1.Condition `json:"ready,omitempty"`
+
+	// ControlPlaneReady is true if the control plane of the Cluster is ready.
+	ControlPlaneReady bool `json:"controlPlaneReady"`
+
+	// ControlPlane summarizes the control plane machines of the Cluster.
+	ControlPlane ReplicasSummary `json:"controlPlane"`
+
+	// Workers summarizes the worker machines of the Cluster.
+	Workers ReplicasSummary `json:"workers"`
+
+	// WorstCondition is the failing condition with the highest severity among all the objects of the Cluster, if any.
+	WorstCondition *ObjectCondition `json:"worstCondition,omitempty"`
+
+	// Error is the error that occurred while summarizing the Cluster, if any; in this case, only the fields
+	// read from the Cluster object are set.
+	Error string `json:"error,omitempty"`
+}
+
+// ReplicasSummary summarizes the replicas of a group of machines.
+type ReplicasSummary struct {
+	// Desired is the number of desired replicas.
+	Desired int32 `json:"desired"`
+
+	// Ready is the number of ready replicas.
+	Ready int32 `json:"ready"`
+}
+
+// ObjectCondition is a condition of an object in the ObjectTree.
+type ObjectCondition struct {
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Condition of the object.
+	Condition clusterv1.Condition `json:"condition"`
+}
+
+// SummarizeClusters returns a summary of the status of all the Cluster API clusters in a namespace, or in all the
+// namespaces if empty; summaries are sorted by namespace and name.
+// A Cluster that cannot be summarized does not prevent summarizing the others; instead, the error is reported
+// in the Error field of its summary.
+func SummarizeClusters(ctx context.Context, c client.Client, namespace string) ([]ClusterSummary, error) {
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	summaries := make([]ClusterSummary, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		summary, err := summarizeCluster(ctx, c, cluster)
+		if err != nil {
+			summary = newClusterSummary(cluster)
+			summary.Error = err.Error()
+		}
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace == summaries[j].Namespace {
+			return summaries[i].Name < summaries[j].Name
+		}
+		return summaries[i].Namespace < summaries[j].Namespace
+	})
+	return summaries, nil
+}
+
+// newClusterSummary returns a summary with the fields read from the Cluster object.
+func newClusterSummary(cluster *clusterv1.Cluster) *ClusterSummary {
+	summary := &ClusterSummary{
+		Namespace:         cluster.Namespace,
+		Name:              cluster.Name,
+		Paused:            annotations.IsPaused(cluster, cluster),
+		Ready:             conditions.Get(cluster, clusterv1.ReadyCondition),
+		ControlPlaneReady: cluster.Status.ControlPlaneReady,
+	}
+	if cluster.Spec.Topology != nil {
+		summary.TopologyClass = cluster.Spec.Topology.Class
+		summary.Version = cluster.Spec.Topology.Version
+	}
+	return summary
+}
+
+func summarizeCluster(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) (*ClusterSummary, error) {
+	summary := newClusterSummary(cluster)
+
+	// Gets the object tree for the Cluster, without grouping, so all the objects with failing conditions are visible.
+	tree, err := Discovery(ctx, c, cluster.Namespace, cluster.Name, DiscoverOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Gets the version and the desired replicas from the control plane, if any.
+	var controlPlane *unstructured.Unstructured
+	for _, child := range tree.GetObjectsByParent(tree.GetRoot().GetUID()) {
+		if u, ok := child.(*unstructured.Unstructured); ok && GetMetaName(u) == "ControlPlane" {
+			controlPlane = u
+		}
+	}
+	if controlPlane != nil {
+		if summary.Version == "" {
+			if version, err := contract.ControlPlane().Version().Get(controlPlane); err == nil {
+				summary.Version = *version
+			}
+		}
+		if replicas, err := contract.ControlPlane().Replicas().Get(controlPlane); err == nil {
+			summary.ControlPlane.Desired = int32(*replicas)
+		}
+	}
+
+	machines, err := getMachinesInCluster(ctx, c, cluster.Namespace, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		ready := conditions.IsTrue(m, clusterv1.ReadyCondition)
+		switch {
+		case util.IsControlPlaneMachine(m):
+			if controlPlane == nil || summary.ControlPlane.Desired == 0 {
+				// If the control plane does not define replicas, all the existing control plane machines are considered desired.
+				summary.ControlPlane.Desired++
+			}
+			if ready {
+				summary.ControlPlane.Ready++
+			}
+		case m.Labels[clusterv1.MachinePoolNameLabel] != "":
+			// Machines belonging to MachinePools are counted using the MachinePool status.
+		case ready:
+			summary.Workers.Ready++
+		}
+	}
+
+	machineDeployments, err := getMachineDeploymentsInCluster(ctx, c, cluster.Namespace, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range machineDeployments.Items {
+		if replicas := machineDeployments.Items[i].Spec.Replicas; replicas != nil {
+			summary.Workers.Desired += *replicas
+		}
+	}
+
+	machinePools, err := getMachinePoolsInCluster(ctx, c, cluster.Namespace, cluster.Name)
+	if err != nil {
+		return nil, err
+	}
+	for i := range machinePools.Items {
+		if replicas := machinePools.Items[i].Spec.Replicas; replicas != nil {
+			summary.Workers.Desired += *replicas
+		}
+		summary.Workers.Ready += machinePools.Items[i].Status.ReadyReplicas
+	}
+
+	if worst := getWorstCondition(tree, tree.GetRoot(), 0, nil); worst != nil {
+		summary.WorstCondition = worst.condition
+	}
+	return summary, nil
+}
+
+// worstConditionCandidate is a failing condition found while walking the ObjectTree, with the depth of the object in the tree.
+type worstConditionCandidate struct {
+	condition *ObjectCondition
+	depth     int
+}
+
+// getWorstCondition returns the failing condition with the highest severity among an object and its descendants.
+// Given that conditions of the parent objects usually summarize the conditions of their children, in case of conditions
+// with the same severity the one of the object deepest in the tree is preferred, because it is closer to the root cause.
+func getWorstCondition(tree *ObjectTree, obj client.Object, depth int, worst *worstConditionCandidate) *worstConditionCandidate {
+	if !IsVirtualObject(obj) {
+		allConditions := GetOtherConditions(obj)
+		if ready := GetReadyCondition(obj); ready != nil {
+			allConditions = append([]*clusterv1.Condition{ready}, allConditions...)
+		}
+		for _, c := range allConditions {
+			if c.Status == corev1.ConditionTrue {
+				continue
+			}
+			if worst == nil ||
+				conditionSeverityRank(c) > conditionSeverityRank(&worst.condition.Condition) ||
+				(conditionSeverityRank(c) == conditionSeverityRank(&worst.condition.Condition) && depth > worst.depth) {
+				worst = &worstConditionCandidate{
+					condition: &ObjectCondition{
+						Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+						Name:      obj.GetName(),
+						Condition: *c,
+					},
+					depth: depth,
+				}
+			}
+		}
+	}
+
+	// Children are sorted by name, so the result is deterministic.
+	children := tree.GetObjectsByParent(obj.GetUID())
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].GetName() < children[j].GetName()
+	})
+	for _, child := range children {
+		worst = getWorstCondition(tree, child, depth+1, worst)
+	}
+	return worst
+}
+
+// conditionSeverityRank returns a rank for a failing condition, higher for more severe conditions.
+func conditionSeverityRank(c *clusterv1.Condition) int {
+	switch c.Severity {
+	case clusterv1.ConditionSeverityError:
+		return 3
+	case clusterv1.ConditionSeverityWarning:
+		return 2
+	case clusterv1.ConditionSeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func Test_SummarizeClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster1 := test.NewFakeCluster("ns1", "cluster1").
+		WithTopologyClass("class1").
+		WithControlPlane(
+			test.NewFakeControlPlane("cp").
+				WithMachines(
+					test.NewFakeMachine("cp1"),
+				),
+		).
+		WithMachineDeployments(
+			test.NewFakeMachineDeployment("md1").
+				WithMachineSets(
+					test.NewFakeMachineSet("ms1").
+						WithMachines(
+							test.NewFakeMachine("m1"),
+							test.NewFakeMachine("m2"),
+						),
+				),
+		).
+		Objs()
+	cluster2 := test.NewFakeCluster("ns2", "cluster2").Objs()
+
+	objs := []client.Object{}
+	for _, obj := range append(cluster1, cluster2...) {
+		switch o := obj.(type) {
+		case *clusterv1.Cluster:
+			if o.Name == "cluster1" {
+				o.Spec.Topology.Version = "v1.27.3"
+				o.Status.ControlPlaneReady = true
+				conditions.MarkTrue(o, clusterv1.ReadyCondition)
+			} else {
+				o.Spec.Paused = true
+			}
+		case *clusterv1.MachineDeployment:
+			o.Spec.Replicas = pointer.Int32(3)
+		case *clusterv1.Machine:
+			switch o.Name {
+			case "cp1", "m1":
+				conditions.MarkTrue(o, clusterv1.ReadyCondition)
+			case "m2":
+				conditions.MarkFalse(o, clusterv1.ReadyCondition, "Provisioning", clusterv1.ConditionSeverityInfo, "")
+				conditions.MarkFalse(o, clusterv1.BootstrapReadyCondition, "BootstrapFailed", clusterv1.ConditionSeverityError, "")
+			}
+		}
+		objs = append(objs, obj)
+	}
+
+	c, err := test.NewFakeProxy().WithObjs(objs...).NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := SummarizeClusters(context.Background(), c, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(HaveLen(2))
+
+	g.Expect(got[0].Namespace).To(Equal("ns1"))
+	g.Expect(got[0].Name).To(Equal("cluster1"))
+	g.Expect(got[0].TopologyClass).To(Equal("class1"))
+	g.Expect(got[0].Version).To(Equal("v1.27.3"))
+	g.Expect(got[0].Paused).To(BeFalse())
+	g.Expect(got[0].Ready).ToNot(BeNil())
+	g.Expect(got[0].Ready.Status).To(Equal(corev1.ConditionTrue))
+	g.Expect(got[0].ControlPlaneReady).To(BeTrue())
+	g.Expect(got[0].ControlPlane).To(Equal(ReplicasSummary{Desired: 1, Ready: 1}))
+	g.Expect(got[0].Workers).To(Equal(ReplicasSummary{Desired: 3, Ready: 1}))
+	g.Expect(got[0].WorstCondition).ToNot(BeNil())
+	g.Expect(got[0].WorstCondition.Kind).To(Equal("Machine"))
+	g.Expect(got[0].WorstCondition.Name).To(Equal("m2"))
+	g.Expect(got[0].WorstCondition.Condition.Type).To(Equal(clusterv1.BootstrapReadyCondition))
+
+	g.Expect(got[1].Namespace).To(Equal("ns2"))
+	g.Expect(got[1].Name).To(Equal("cluster2"))
+	g.Expect(got[1].Paused).To(BeTrue())
+	g.Expect(got[1].ControlPlane).To(Equal(ReplicasSummary{}))
+	g.Expect(got[1].Workers).To(Equal(ReplicasSummary{}))
+	g.Expect(got[1].WorstCondition).To(BeNil())
+
+	// Summarizes only the clusters in a namespace.
+	got, err = SummarizeClusters(context.Background(), c, "ns2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(HaveLen(1))
+	g.Expect(got[0].Name).To(Equal("cluster2"))
+}
+
+func Test_SummarizeClustersWithErrors(t *testing.T) {
+	g := NewWithT(t)
+
+	healthy1 := test.NewFakeCluster("ns1", "cluster1").WithTopologyClass("class1").Objs()
+	healthy2 := test.NewFakeCluster("ns1", "cluster3").Objs()
+	broken := test.NewFakeCluster("ns1", "cluster2").WithTopologyClass("class1").Objs()
+
+	objs := append(healthy1, healthy2...)
+	for _, obj := range broken {
+		// Drop the InfrastructureCluster, so the Cluster cannot be summarized.
+		if obj.GetObjectKind().GroupVersionKind().Kind == "GenericInfrastructureCluster" {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+
+	c, err := test.NewFakeProxy().WithObjs(objs...).NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := SummarizeClusters(context.Background(), c, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(HaveLen(3))
+
+	g.Expect(got[0].Name).To(Equal("cluster1"))
+	g.Expect(got[0].Error).To(BeEmpty())
+
+	g.Expect(got[1].Name).To(Equal("cluster2"))
+	g.Expect(got[1].TopologyClass).To(Equal("class1"))
+	g.Expect(got[1].Error).To(ContainSubstring("get InfraCluster reference from Cluster"))
+
+	g.Expect(got[2].Name).To(Equal("cluster3"))
+	g.Expect(got[2].Error).To(BeEmpty())
+}
+
+func Test_getWorstCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions map[string][]clusterv1.Condition
+		wantObject string
+		wantType   clusterv1.ConditionType
+	}{
+		{
+			name: "no failing conditions",
+			conditions: map[string][]clusterv1.Condition{
+				"cluster1": {*conditions.TrueCondition(clusterv1.ReadyCondition)},
+			},
+			wantObject: "",
+		},
+		{
+			name: "higher severity wins",
+			conditions: map[string][]clusterv1.Condition{
+				"cluster1": {*conditions.FalseCondition(clusterv1.ReadyCondition, "", clusterv1.ConditionSeverityError, "")},
+				"m1":       {*conditions.FalseCondition(clusterv1.ReadyCondition, "", clusterv1.ConditionSeverityWarning, "")},
+			},
+			wantObject: "cluster1",
+			wantType:   clusterv1.ReadyCondition,
+		},
+		{
+			name: "same severity, deeper object wins",
+			conditions: map[string][]clusterv1.Condition{
+				"cluster1": {*conditions.FalseCondition(clusterv1.ReadyCondition, "", clusterv1.ConditionSeverityWarning, "")},
+				"m1":       {*conditions.FalseCondition(clusterv1.InfrastructureReadyCondition, "", clusterv1.ConditionSeverityWarning, "")},
+			},
+			wantObject: "m1",
+			wantType:   clusterv1.InfrastructureReadyCondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &clusterv1.Cluster{}
+			cluster.SetName("cluster1")
+			cluster.SetUID("cluster1")
+			cluster.Status.Conditions = tt.conditions["cluster1"]
+			machine := &clusterv1.Machine{}
+			machine.SetName("m1")
+			machine.SetUID("m1")
+			machine.Status.Conditions = tt.conditions["m1"]
+
+			tree := NewObjectTree(cluster, ObjectTreeOptions{})
+			tree.Add(cluster, machine)
+
+			got := getWorstCondition(tree, cluster, 0, nil)
+			if tt.wantObject == "" {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).ToNot(BeNil())
+			g.Expect(got.condition.Name).To(Equal(tt.wantObject))
+			g.Expect(got.condition.Condition.Type).To(Equal(tt.wantType))
+		})
+	}
+}
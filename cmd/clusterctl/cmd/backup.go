@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+type backupOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	file              string
+}
+
+var bo = &backupOptions{}
+
+var backupCmd = &cobra.Command{
+	Use:     "backup",
+	GroupID: groupManagement,
+	Short:   "Backup the Cluster API objects and the provider inventory of a management cluster",
+	Long: LongDesc(`
+		Backup the Cluster API objects in all the namespaces of a management cluster, including ClusterClasses
+		and the Secrets they depend on, and the provider inventory, to a versioned archive.
+
+		The archive can be restored into a new management cluster using clusterctl restore.`),
+
+	Example: Examples(`
+		Backup the management cluster to an archive.
+		clusterctl backup --file backup.tar.gz`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBackup()
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&bo.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file for the management cluster. If unspecified, default discovery rules apply.")
+	backupCmd.Flags().StringVar(&bo.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the management cluster. If empty, current context will be used.")
+	backupCmd.Flags().StringVarP(&bo.file, "file", "f", "",
+		"Path of the backup archive to be created.")
+
+	_ = backupCmd.MarkFlagRequired("file")
+
+	RootCmd.AddCommand(backupCmd)
+}
+
+func runBackup() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	return c.Backup(ctx, client.BackupOptions{
+		Kubeconfig: client.Kubeconfig{Path: bo.kubeconfig, Context: bo.kubeconfigContext},
+		Archive:    bo.file,
+	})
+}
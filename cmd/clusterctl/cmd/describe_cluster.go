@@ -17,11 +17,16 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/fatih/color"
@@ -32,6 +37,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
@@ -43,6 +49,9 @@ const (
 	lastElemPrefix  = `└─`
 	indent          = "  "
 	pipe            = `│ `
+
+	// clearScreen is the escape sequence for clearing the terminal screen before re-rendering the tree in watch mode.
+	clearScreen = "\033[H\033[2J"
 )
 
 var (
@@ -66,6 +75,9 @@ type describeClusterOptions struct {
 	grouping                bool
 	disableGrouping         bool
 	color                   bool
+	output                  string
+	watch                   bool
+	watchInterval           time.Duration
 }
 
 var dc = &describeClusterOptions{}
@@ -94,7 +106,13 @@ var describeClusterClusterCmd = &cobra.Command{
 
 		# Describe the cluster named test-1 showing the MachineInfrastructure and BootstrapConfig objects
 		# also when their status is the same as the status of the corresponding machine object.
-		clusterctl describe cluster test-1 --echo`),
+		clusterctl describe cluster test-1 --echo
+
+		# Describe the cluster named test-1 in JSON format.
+		clusterctl describe cluster test-1 -o json
+
+		# Describe the cluster named test-1, rendering the description again every time it changes.
+		clusterctl describe cluster test-1 --watch`),
 
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -133,6 +151,12 @@ func init() {
 	_ = describeClusterClusterCmd.Flags().MarkDeprecated("disable-grouping",
 		"use --grouping instead.")
 	describeClusterClusterCmd.Flags().BoolVarP(&dc.color, "color", "c", false, "Enable or disable color output; if not set color is enabled by default only if using tty. The flag is overridden by the NO_COLOR env variable if set.")
+	describeClusterClusterCmd.Flags().StringVarP(&dc.output, "output", "o", "",
+		"Output format; available options are 'yaml' and 'json'. If unspecified, the cluster is described using a tree view.")
+	describeClusterClusterCmd.Flags().BoolVarP(&dc.watch, "watch", "w", false,
+		"Watch the cluster, rendering the description again every time it changes.")
+	describeClusterClusterCmd.Flags().DurationVar(&dc.watchInterval, "watch-interval", 5*time.Second,
+		"The interval between checks for changes to the cluster when using --watch.")
 
 	// completions
 	describeClusterClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -149,12 +173,22 @@ func init() {
 func runDescribeCluster(cmd *cobra.Command, name string) error {
 	ctx := context.Background()
 
+	switch dc.output {
+	case "", "yaml", "json":
+	default:
+		return errors.Errorf("invalid output format: %s", dc.output)
+	}
+
+	if dc.watch && dc.watchInterval <= 0 {
+		return errors.New("--watch-interval must be greater than zero")
+	}
+
 	c, err := client.New(ctx, cfgFile)
 	if err != nil {
 		return err
 	}
 
-	tree, err := c.DescribeCluster(ctx, client.DescribeClusterOptions{
+	options := client.DescribeClusterOptions{
 		Kubeconfig:              client.Kubeconfig{Path: dc.kubeconfig, Context: dc.kubeconfigContext},
 		Namespace:               dc.namespace,
 		ClusterName:             name,
@@ -165,23 +199,99 @@ func runDescribeCluster(cmd *cobra.Command, name string) error {
 		AddTemplateVirtualNode:  true,
 		Echo:                    dc.echo,
 		Grouping:                dc.grouping && !dc.disableGrouping,
-	})
-	if err != nil {
-		return err
 	}
 
 	if cmd.Flags().Changed("color") {
 		color.NoColor = !dc.color
 	}
 
-	printObjectTree(tree)
+	if dc.watch {
+		return watchDescribeCluster(ctx, c, options)
+	}
+
+	tree, err := c.DescribeCluster(ctx, options)
+	if err != nil {
+		return err
+	}
+	return printDescribeCluster(os.Stdout, tree, dc.output)
+}
+
+// watchDescribeCluster describes the cluster every watch interval, printing the description again if the cluster has
+// changed since the last time it was printed, until the command gets interrupted.
+func watchDescribeCluster(ctx context.Context, c client.Client, options client.DescribeClusterOptions) error {
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	ticker := time.NewTicker(dc.watchInterval)
+	defer ticker.Stop()
+
+	var last []byte
+	for {
+		tree, err := c.DescribeCluster(ctx, options)
+		if err != nil {
+			// Errors are reported without stopping the watch, because they could be caused by temporary problems,
+			// e.g. while the management cluster is being upgraded.
+			fmt.Fprintf(os.Stderr, "Error describing the cluster: %v\n", err)
+		} else {
+			// NOTE: changes are detected using the structured representation of the tree, because the tree view
+			// includes the time since the last transition of each condition, which changes at every interval.
+			current, err := json.Marshal(tree.Structured())
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(current, last) {
+				last = current
+				if err := printWatchedDescribeCluster(os.Stdout, tree, dc.output); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// printWatchedDescribeCluster prints a cluster description in watch mode; the tree view replaces the previous one on the screen,
+// while each YAML or JSON description is printed as a new document.
+func printWatchedDescribeCluster(w io.Writer, tree *tree.ObjectTree, output string) error {
+	switch output {
+	case "":
+		fmt.Fprint(w, clearScreen)
+	case "yaml":
+		fmt.Fprintln(w, "---")
+	}
+	return printDescribeCluster(w, tree, output)
+}
+
+// printDescribeCluster prints a cluster description using the given output format.
+func printDescribeCluster(w io.Writer, tree *tree.ObjectTree, output string) error {
+	switch output {
+	case "yaml":
+		y, err := yaml.Marshal(tree.Structured())
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(y))
+	case "json":
+		j, err := json.MarshalIndent(tree.Structured(), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(j))
+	default:
+		printObjectTree(w, tree)
+	}
 	return nil
 }
 
-// printObjectTree prints the cluster status to stdout.
-func printObjectTree(tree *tree.ObjectTree) {
+// printObjectTree prints the cluster status to the given writer.
+func printObjectTree(w io.Writer, tree *tree.ObjectTree) {
 	// Creates the output table
-	tbl := tablewriter.NewWriter(os.Stdout)
+	tbl := tablewriter.NewWriter(w)
 	tbl.SetHeader([]string{"NAME", "READY", "SEVERITY", "REASON", "SINCE", "MESSAGE"})
 
 	formatTableTree(tbl)
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -29,6 +30,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
@@ -294,6 +296,50 @@ func Test_TreePrefix(t *testing.T) {
 	}
 }
 
+func Test_printDescribeCluster(t *testing.T) {
+	newObjectTree := func() *tree.ObjectTree {
+		root := fakeObject("root", withCondition(conditions.TrueCondition(clusterv1.ReadyCondition)))
+		objectTree := tree.NewObjectTree(root, tree.ObjectTreeOptions{})
+		objectTree.Add(root, fakeObject("child1", withCondition(conditions.FalseCondition(clusterv1.ReadyCondition, "Reason", clusterv1.ConditionSeverityWarning, ""))))
+		return objectTree
+	}
+
+	tests := []struct {
+		name      string
+		output    string
+		unmarshal func([]byte, interface{}) error
+	}{
+		{
+			name:      "Print the cluster description as JSON",
+			output:    "json",
+			unmarshal: json.Unmarshal,
+		},
+		{
+			name:   "Print the cluster description as YAML",
+			output: "yaml",
+			unmarshal: func(b []byte, i interface{}) error {
+				return yaml.Unmarshal(b, i)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var output bytes.Buffer
+
+			g.Expect(printDescribeCluster(&output, newObjectTree(), tt.output)).To(Succeed())
+
+			got := &tree.ObjectNode{}
+			g.Expect(tt.unmarshal(output.Bytes(), got)).To(Succeed())
+			g.Expect(got.Name).To(Equal("root"))
+			g.Expect(got.Ready.Status).To(BeEquivalentTo("True"))
+			g.Expect(got.Children).To(HaveLen(1))
+			g.Expect(got.Children[0].Name).To(Equal("child1"))
+			g.Expect(got.Children[0].Ready.Reason).To(Equal("Reason"))
+		})
+	}
+}
+
 type objectOption func(object ctrlclient.Object)
 
 func fakeObject(name string, options ...objectOption) ctrlclient.Object {
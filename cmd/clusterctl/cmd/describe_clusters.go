@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+)
+
+type describeClustersOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+	allNamespaces     bool
+	color             bool
+	output            string
+}
+
+var dcs = &describeClustersOptions{}
+
+var describeClustersCmd = &cobra.Command{
+	Use:   "clusters",
+	Short: "Describe all the workload clusters in a management cluster",
+	Long: LongDesc(`
+		Provide an "at glance" view of all the Cluster API clusters in a management cluster, with one row
+		for each cluster summarizing the status of its control plane and workers, and the most severe
+		failing condition among all the objects of the cluster.`),
+
+	Example: Examples(`
+		# Describe all the clusters in the current namespace.
+		clusterctl describe clusters
+
+		# Describe all the clusters in all the namespaces.
+		clusterctl describe clusters --all-namespaces
+
+		# Describe all the clusters in all the namespaces in JSON format.
+		clusterctl describe clusters -A -o json`),
+
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDescribeClusters(cmd)
+	},
+}
+
+func init() {
+	describeClustersCmd.Flags().StringVar(&dcs.kubeconfig, "kubeconfig", "",
+		"Path to a kubeconfig file to use for the management cluster. If empty, default discovery rules apply.")
+	describeClustersCmd.Flags().StringVar(&dcs.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	describeClustersCmd.Flags().StringVarP(&dcs.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are located. If unspecified, the current namespace will be used.")
+	describeClustersCmd.Flags().BoolVarP(&dcs.allNamespaces, "all-namespaces", "A", false,
+		"Describe the workload clusters in all the namespaces.")
+
+	describeClustersCmd.Flags().BoolVarP(&dcs.color, "color", "c", false, "Enable or disable color output; if not set color is enabled by default only if using tty. The flag is overridden by the NO_COLOR env variable if set.")
+	describeClustersCmd.Flags().StringVarP(&dcs.output, "output", "o", "",
+		"Output format; available options are 'yaml' and 'json'. If unspecified, the clusters are described using a table view.")
+
+	describeClustersCmd.MarkFlagsMutuallyExclusive("namespace", "all-namespaces")
+
+	describeCmd.AddCommand(describeClustersCmd)
+}
+
+func runDescribeClusters(cmd *cobra.Command) error {
+	ctx := context.Background()
+
+	switch dcs.output {
+	case "", "yaml", "json":
+	default:
+		return errors.Errorf("invalid output format: %s", dcs.output)
+	}
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	summaries, err := c.DescribeClusters(ctx, client.DescribeClustersOptions{
+		Kubeconfig:    client.Kubeconfig{Path: dcs.kubeconfig, Context: dcs.kubeconfigContext},
+		Namespace:     dcs.namespace,
+		AllNamespaces: dcs.allNamespaces,
+	})
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("color") {
+		color.NoColor = !dcs.color
+	}
+
+	return printDescribeClusters(os.Stdout, summaries, dcs.output)
+}
+
+// printDescribeClusters prints the summary of the clusters using the given output format.
+func printDescribeClusters(w io.Writer, summaries []tree.ClusterSummary, output string) error {
+	switch output {
+	case "yaml":
+		y, err := yaml.Marshal(summaries)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(y))
+	case "json":
+		j, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(j))
+	default:
+		printClusterSummaries(w, summaries)
+	}
+	return nil
+}
+
+// printClusterSummaries prints a table with a row for each cluster summary to the given writer.
+func printClusterSummaries(w io.Writer, summaries []tree.ClusterSummary) {
+	if len(summaries) == 0 {
+		fmt.Fprintln(w, "No clusters found")
+		return
+	}
+
+	// Creates the output table
+	tbl := tablewriter.NewWriter(w)
+	tbl.SetHeader([]string{"NAMESPACE", "NAME", "CLASS", "VERSION", "PAUSED", "CONTROL PLANE", "WORKERS", "READY", "WORST CONDITION"})
+	formatTableTree(tbl)
+
+	for _, s := range summaries {
+		readyDescriptor := conditionDescriptor{readyColor: gray}
+		if s.Ready != nil {
+			readyDescriptor = newConditionDescriptor(s.Ready)
+		}
+
+		controlPlaneColor := yellow
+		if s.ControlPlaneReady {
+			controlPlaneColor = green
+		}
+
+		paused := ""
+		if s.Paused {
+			paused = yellow.Sprint("true")
+		}
+
+		worstCondition := ""
+		switch {
+		case s.Error != "":
+			worstCondition = red.Sprintf("failed to summarize: %s", s.Error)
+		case s.WorstCondition != nil:
+			d := newConditionDescriptor(&s.WorstCondition.Condition)
+			worstCondition = d.readyColor.Sprintf("%s/%s %s", s.WorstCondition.Kind, s.WorstCondition.Name, s.WorstCondition.Condition.Type)
+			if d.reason != "" {
+				worstCondition = fmt.Sprintf("%s %s", worstCondition, gray.Sprintf("(%s)", d.reason))
+			}
+		}
+
+		tbl.Append([]string{
+			s.Namespace,
+			color.New(color.Bold).Sprint(s.Name),
+			s.TopologyClass,
+			s.Version,
+			paused,
+			controlPlaneColor.Sprintf("%d/%d", s.ControlPlane.Ready, s.ControlPlane.Desired),
+			replicasColor(s.Workers).Sprintf("%d/%d", s.Workers.Ready, s.Workers.Desired),
+			readyDescriptor.readyColor.Sprint(readyDescriptor.status),
+			worstCondition,
+		})
+	}
+
+	// Prints the output table
+	tbl.Render()
+}
+
+// replicasColor returns the color to be used for representing a replicas summary.
+func replicasColor(r tree.ReplicasSummary) *color.Color {
+	if r.Ready < r.Desired {
+		return yellow
+	}
+	return green
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/fatih/color"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func Test_printDescribeClusters(t *testing.T) {
+	summaries := []tree.ClusterSummary{
+		{
+			Namespace:         "ns1",
+			Name:              "cluster1",
+			TopologyClass:     "class1",
+			Version:           "v1.27.3",
+			Ready:             conditions.TrueCondition(clusterv1.ReadyCondition),
+			ControlPlaneReady: true,
+			ControlPlane:      tree.ReplicasSummary{Desired: 3, Ready: 3},
+			Workers:           tree.ReplicasSummary{Desired: 2, Ready: 1},
+			WorstCondition: &tree.ObjectCondition{
+				Kind:      "Machine",
+				Name:      "m1",
+				Condition: *conditions.FalseCondition(clusterv1.BootstrapReadyCondition, "Reason", clusterv1.ConditionSeverityWarning, ""),
+			},
+		},
+		{
+			Namespace: "ns2",
+			Name:      "cluster2",
+			Paused:    true,
+		},
+		{
+			Namespace: "ns2",
+			Name:      "cluster3",
+			Error:     "get InfraCluster reference from Cluster: not found",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		output    string
+		unmarshal func([]byte, interface{}) error
+	}{
+		{
+			name:      "Print the clusters summary as JSON",
+			output:    "json",
+			unmarshal: json.Unmarshal,
+		},
+		{
+			name:   "Print the clusters summary as YAML",
+			output: "yaml",
+			unmarshal: func(b []byte, i interface{}) error {
+				return yaml.Unmarshal(b, i)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var output bytes.Buffer
+
+			g.Expect(printDescribeClusters(&output, summaries, tt.output)).To(Succeed())
+
+			got := []tree.ClusterSummary{}
+			g.Expect(tt.unmarshal(output.Bytes(), &got)).To(Succeed())
+			g.Expect(got).To(HaveLen(3))
+			g.Expect(got[0].Name).To(Equal("cluster1"))
+			g.Expect(got[0].Workers).To(Equal(tree.ReplicasSummary{Desired: 2, Ready: 1}))
+			g.Expect(got[0].WorstCondition.Condition.Reason).To(Equal("Reason"))
+			g.Expect(got[1].Name).To(Equal("cluster2"))
+			g.Expect(got[1].Paused).To(BeTrue())
+			g.Expect(got[2].Name).To(Equal("cluster3"))
+			g.Expect(got[2].Error).To(Equal("get InfraCluster reference from Cluster: not found"))
+		})
+	}
+
+	t.Run("Print the clusters summary as a table", func(t *testing.T) {
+		g := NewWithT(t)
+		color.NoColor = true
+		var output bytes.Buffer
+
+		g.Expect(printDescribeClusters(&output, summaries, "")).To(Succeed())
+
+		g.Expect(output.String()).To(ContainSubstring("NAMESPACE"))
+		g.Expect(output.String()).To(MatchRegexp(`ns1\s+cluster1\s+class1\s+v1.27.3\s+3/3\s+1/2\s+True\s+Machine/m1 BootstrapReady \(Reason\)`))
+		g.Expect(output.String()).To(MatchRegexp(`ns2\s+cluster2\s+true\s+0/0\s+0/0`))
+		g.Expect(output.String()).To(MatchRegexp(`ns2\s+cluster3\s+0/0\s+0/0\s+failed to summarize: get InfraCluster reference from Cluster: not found`))
+	})
+}
@@ -31,9 +31,13 @@ type moveOptions struct {
 	toKubeconfig          string
 	toKubeconfigContext   string
 	namespace             string
+	clusters              []string
+	clusterSelector       string
 	fromDirectory         string
 	toDirectory           string
 	dryRun                bool
+	resume                bool
+	rollback              bool
 }
 
 var mo = &moveOptions{}
@@ -56,6 +60,18 @@ var moveCmd = &cobra.Command{
 
 		Read Cluster API objects and all dependencies from a directory into a management cluster.
 		clusterctl move --from-directory /tmp/backup-directory
+
+		Move only the Cluster named my-cluster and all its dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster my-cluster
+
+		Move only the Clusters with the env=test label and all their dependencies between management clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --selector env=test
+
+		Resume a move that did not complete, e.g. because of a network failure.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --resume
+
+		Rollback a move that did not complete, restoring the Cluster API objects in the source management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --rollback
 	`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -74,16 +90,27 @@ func init() {
 		"Context to be used within the kubeconfig file for the destination management cluster. If empty, current context will be used.")
 	moveCmd.Flags().StringVarP(&mo.namespace, "namespace", "n", "",
 		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used.")
+	moveCmd.Flags().StringSliceVar(&mo.clusters, "cluster", nil,
+		"The name of a Cluster to be moved together with all its dependencies. Can be repeated. If unspecified, all the Clusters in the namespace are moved.")
+	moveCmd.Flags().StringVarP(&mo.clusterSelector, "selector", "l", "",
+		"Label selector for the Clusters to be moved together with all their dependencies. If unspecified, all the Clusters in the namespace are moved.")
 	moveCmd.Flags().BoolVar(&mo.dryRun, "dry-run", false,
 		"Enable dry run, don't really perform the move actions")
 	moveCmd.Flags().StringVar(&mo.toDirectory, "to-directory", "",
 		"Write Cluster API objects and all dependencies from a management cluster to directory.")
 	moveCmd.Flags().StringVar(&mo.fromDirectory, "from-directory", "",
 		"Read Cluster API objects and all dependencies from a directory into a management cluster.")
+	moveCmd.Flags().BoolVar(&mo.resume, "resume", false,
+		"Resume a previous move that did not complete, using the move journal stored in the source management cluster.")
+	moveCmd.Flags().BoolVar(&mo.rollback, "rollback", false,
+		"Rollback a previous move that did not complete, deleting the objects already created in the destination management cluster and unpausing the source management cluster.")
 
 	moveCmd.MarkFlagsMutuallyExclusive("to-directory", "to-kubeconfig")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "to-directory")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("resume", "rollback", "dry-run", "to-directory", "from-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("cluster", "to-directory", "from-directory", "resume", "rollback")
+	moveCmd.MarkFlagsMutuallyExclusive("selector", "to-directory", "from-directory", "resume", "rollback")
 
 	RootCmd.AddCommand(moveCmd)
 }
@@ -91,6 +118,10 @@ func init() {
 func runMove() error {
 	ctx := context.Background()
 
+	if (mo.resume || mo.rollback) && mo.toKubeconfig == "" {
+		return errors.New("please specify a target cluster using the --to-kubeconfig flag when using --resume or --rollback")
+	}
+
 	if mo.toDirectory == "" &&
 		mo.fromDirectory == "" &&
 		mo.toKubeconfig == "" &&
@@ -104,11 +135,15 @@ func runMove() error {
 	}
 
 	return c.Move(ctx, client.MoveOptions{
-		FromKubeconfig: client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
-		ToKubeconfig:   client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
-		FromDirectory:  mo.fromDirectory,
-		ToDirectory:    mo.toDirectory,
-		Namespace:      mo.namespace,
-		DryRun:         mo.dryRun,
+		FromKubeconfig:  client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
+		ToKubeconfig:    client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
+		FromDirectory:   mo.fromDirectory,
+		ToDirectory:     mo.toDirectory,
+		Namespace:       mo.namespace,
+		Clusters:        mo.clusters,
+		ClusterSelector: mo.clusterSelector,
+		DryRun:          mo.dryRun,
+		Resume:          mo.resume,
+		Rollback:        mo.rollback,
 	})
 }
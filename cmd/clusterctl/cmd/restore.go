@@ -0,0 +1,78 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+type restoreOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	file              string
+}
+
+var ro = &restoreOptions{}
+
+var restoreCmd = &cobra.Command{
+	Use:     "restore",
+	GroupID: groupManagement,
+	Short:   "Restore the Cluster API objects from a backup archive into a management cluster",
+	Long: LongDesc(`
+		Restore the Cluster API objects from an archive created by clusterctl backup into a management cluster.
+
+		Note: The management cluster MUST have all the providers in the backup installed, e.g. using clusterctl init.`),
+
+	Example: Examples(`
+		Restore a backup archive into a management cluster.
+		clusterctl restore --file backup.tar.gz`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRestore()
+	},
+}
+
+func init() {
+	restoreCmd.Flags().StringVar(&ro.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file for the management cluster. If unspecified, default discovery rules apply.")
+	restoreCmd.Flags().StringVar(&ro.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the management cluster. If empty, current context will be used.")
+	restoreCmd.Flags().StringVarP(&ro.file, "file", "f", "",
+		"Path of the backup archive to be restored.")
+
+	_ = restoreCmd.MarkFlagRequired("file")
+
+	RootCmd.AddCommand(restoreCmd)
+}
+
+func runRestore() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	return c.Restore(ctx, client.RestoreOptions{
+		Kubeconfig: client.Kubeconfig{Path: ro.kubeconfig, Context: ro.kubeconfigContext},
+		Archive:    ro.file,
+	})
+}
@@ -44,8 +44,17 @@ var (
 		clusterctl alpha rollout resume machinedeployment/my-md-0
 		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp
 
-		# Rollback a machinedeployment
-		clusterctl alpha rollout undo machinedeployment/my-md-0 --to-revision=3`)
+		# Watch the rollout status of a machinedeployment or kubeadmcontrolplane until it's done
+		clusterctl alpha rollout status machinedeployment/my-md-0
+		clusterctl alpha rollout status kubeadmcontrolplane/my-kcp
+
+		# View the rollout history of a machinedeployment or kubeadmcontrolplane
+		clusterctl alpha rollout history machinedeployment/my-md-0
+		clusterctl alpha rollout history kubeadmcontrolplane/my-kcp
+
+		# Rollback a machinedeployment or kubeadmcontrolplane
+		clusterctl alpha rollout undo machinedeployment/my-md-0 --to-revision=3
+		clusterctl alpha rollout undo kubeadmcontrolplane/my-kcp --to-revision=3`)
 
 	rolloutCmd = &cobra.Command{
 		Use:     "rollout SUBCOMMAND",
@@ -61,4 +70,6 @@ func init() {
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutPause(cfgFile))
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutResume(cfgFile))
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutUndo(cfgFile))
+	rolloutCmd.AddCommand(rollout.NewCmdRolloutHistory(cfgFile))
+	rolloutCmd.AddCommand(rollout.NewCmdRolloutStatus(cfgFile))
 }
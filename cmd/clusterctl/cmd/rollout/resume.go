@@ -39,12 +39,17 @@ var (
 	resumeLong = templates.LongDesc(`
 		Resume a paused cluster-api resource
 
-	        Paused resources will not be reconciled by a controller. By resuming a resource, we allow it to be reconciled again. Currently only MachineDeployments and KubeadmControlPlanes support being resumed.`)
+	        Paused resources will not be reconciled by a controller. By resuming a resource, we allow it to be reconciled again. Currently only MachineDeployments and KubeadmControlPlanes support being resumed.
+
+	        Resuming a MachineDeployment whose rollout is paused at a pause step, or has been aborted, moves the rollout to the next step, or restarts it.`)
 
 	resumeExample = templates.Examples(`
 		# Resume an already paused machinedeployment
 		clusterctl alpha rollout resume machinedeployment/my-md-0
 
+		# Resume the rollout of a machinedeployment paused at a pause step
+		clusterctl alpha rollout resume machinedeployment/my-md-0
+
 		# Resume a kubeadmcontrolplane
 		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp`)
 )
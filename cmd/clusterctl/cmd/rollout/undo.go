@@ -38,14 +38,19 @@ var undoOpt = &undoOptions{}
 
 var (
 	undoLong = templates.LongDesc(`
-		Rollback to a previous rollout.`)
+		Rollback to a previous rollout.
+
+		Use 'clusterctl alpha rollout history' to list the revisions available for a rollback.`)
 
 	undoExample = templates.Examples(`
 		# Rollback to the previous deployment
 		clusterctl alpha rollout undo machinedeployment/my-md-0
 
 		# Rollback to previous machinedeployment --to-revision=3
-		clusterctl alpha rollout undo machinedeployment/my-md-0 --to-revision=3`)
+		clusterctl alpha rollout undo machinedeployment/my-md-0 --to-revision=3
+
+		# Rollback to the previous revision of a kubeadmcontrolplane
+		clusterctl alpha rollout undo kubeadmcontrolplane/my-kcp`)
 )
 
 // NewCmdRolloutUndo returns a Command instance for 'rollout undo' sub command.
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+type topologyRenderPatchOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	files             []string
+	cluster           string
+	namespace         string
+}
+
+var trp = &topologyRenderPatchOptions{}
+
+var topologyRenderPatchCmd = &cobra.Command{
+	Use:   "render-patch",
+	Short: "Render the patches of a ClusterClass for a Cluster that uses a managed topology",
+	Long: LongDesc(`
+		Evaluate the patches of a ClusterClass against a Cluster and print the patches generated for each template.
+		The input should contain the Cluster, the ClusterClass and the templates referenced by the ClusterClass.
+
+		If the management cluster is reachable and has Cluster API installed, the objects missing from the input are read
+		from the management cluster, so the rendered patches may depend on its current state.
+
+		This command can also be run without a real cluster. In such cases, the input should contain all the objects needed.
+
+		Note: Patches are rendered in the order in which they are applied; each patch is generated against the templates
+		as modified by the previous patches.`),
+	Example: Examples(`
+		# Render the patches of a ClusterClass for a sample Cluster.
+		clusterctl alpha topology render-patch -f cluster-class.yaml -f sample-cluster.yaml
+
+		# Render the patches for "cluster1" when the input affects more than one Cluster.
+		clusterctl alpha topology render-patch -f modified-cluster-class.yaml --cluster "cluster1"`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTopologyRenderPatch()
+	},
+}
+
+func init() {
+	topologyRenderPatchCmd.Flags().StringVar(&trp.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig for the management cluster. If unspecified, default discovery rules apply.")
+	topologyRenderPatchCmd.Flags().StringVar(&trp.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+
+	topologyRenderPatchCmd.Flags().StringArrayVarP(&trp.files, "file", "f", nil, "path to the file with the resources to be used; the files should not contain more than one Cluster or more than one ClusterClass")
+	topologyRenderPatchCmd.Flags().StringVarP(&trp.cluster, "cluster", "c", "", "name of the target cluster; this parameter is required when more than one cluster is affected")
+	topologyRenderPatchCmd.Flags().StringVarP(&trp.namespace, "namespace", "n", "", "target namespace for the operation. If specified, it is used as default namespace for objects with missing namespace")
+
+	if err := topologyRenderPatchCmd.MarkFlagRequired("file"); err != nil {
+		panic(err)
+	}
+
+	topologyCmd.AddCommand(topologyRenderPatchCmd)
+}
+
+func runTopologyRenderPatch() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	objs := []unstructured.Unstructured{}
+	for _, f := range trp.files {
+		raw, err := os.ReadFile(f) //nolint:gosec
+		if err != nil {
+			return errors.Wrapf(err, "failed to read input file %q", f)
+		}
+		objects, err := utilyaml.ToUnstructured(raw)
+		if err != nil {
+			return errors.Wrapf(err, "failed to convert file %q to list of objects", f)
+		}
+		objs = append(objs, objects...)
+	}
+
+	out, err := c.TopologyRenderPatches(ctx, client.TopologyRenderPatchesOptions{
+		Kubeconfig: client.Kubeconfig{Path: trp.kubeconfig, Context: trp.kubeconfigContext},
+		Objs:       convertToPtrSlice(objs),
+		Cluster:    trp.cluster,
+		Namespace:  trp.namespace,
+	})
+	if err != nil {
+		return err
+	}
+	return printTopologyRenderPatchOutput(os.Stdout, out)
+}
+
+func printTopologyRenderPatchOutput(w io.Writer, out *cluster.TopologyRenderPatchesOutput) error {
+	clusterName := fmt.Sprintf("%s/%s", out.ReconciledCluster.Namespace, out.ReconciledCluster.Name)
+	if len(out.GeneratedPatches) == 0 {
+		fmt.Fprintf(w, "No patches generated for Cluster %q.\n", clusterName)
+		return nil
+	}
+
+	fmt.Fprintf(w, "Patches generated for Cluster %q:\n", clusterName)
+	for _, p := range out.GeneratedPatches {
+		patchType := "JSON patch"
+		if p.PatchType == runtimehooksv1.JSONMergePatchType {
+			patchType = "JSON merge patch"
+		}
+
+		fmt.Fprintf(w, "\n＊ Patch %q, %s for %s %q (%s %q, %s):\n", p.PatchName, patchType,
+			p.TemplateRef.Kind, p.TemplateRef.Name,
+			p.HolderReference.Kind, p.HolderReference.Name, p.HolderReference.FieldPath)
+
+		var patch bytes.Buffer
+		if err := json.Indent(&patch, p.Patch, "", "  "); err != nil {
+			return errors.Wrapf(err, "failed to format patch %q", p.PatchName)
+		}
+		fmt.Fprintf(w, "%s\n", patch.String())
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+)
+
+func Test_printTopologyRenderPatchOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		out  *cluster.TopologyRenderPatchesOutput
+		want string
+	}{
+		{
+			name: "No patches generated",
+			out: &cluster.TopologyRenderPatchesOutput{
+				ReconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+			},
+			want: "No patches generated for Cluster \"default/my-cluster\".\n",
+		},
+		{
+			name: "Patches generated",
+			out: &cluster.TopologyRenderPatchesOutput{
+				ReconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+				GeneratedPatches: []cluster.GeneratedPatch{
+					{
+						PatchName:       "imageRepository",
+						HolderReference: runtimehooksv1.HolderReference{Kind: "Cluster", Name: "my-cluster", FieldPath: "spec.controlPlaneRef"},
+						TemplateRef:     corev1.ObjectReference{Kind: "KubeadmControlPlaneTemplate", Name: "control-plane"},
+						PatchType:       runtimehooksv1.JSONPatchType,
+						Patch:           []byte(`[{"op":"add","path":"/spec/template/spec/imageRepository","value":"registry.k8s.io"}]`),
+					},
+					{
+						PatchName:       "files",
+						HolderReference: runtimehooksv1.HolderReference{Kind: "MachineDeployment", Name: "my-cluster-md-0", FieldPath: "spec.template.spec.bootstrap.configRef"},
+						TemplateRef:     corev1.ObjectReference{Kind: "KubeadmConfigTemplate", Name: "worker"},
+						PatchType:       runtimehooksv1.JSONMergePatchType,
+						Patch:           []byte(`{"spec":{"template":{"spec":{"files":[]}}}}`),
+					},
+				},
+			},
+			want: `Patches generated for Cluster "default/my-cluster":
+
+＊ Patch "imageRepository", JSON patch for KubeadmControlPlaneTemplate "control-plane" (Cluster "my-cluster", spec.controlPlaneRef):
+[
+  {
+    "op": "add",
+    "path": "/spec/template/spec/imageRepository",
+    "value": "registry.k8s.io"
+  }
+]
+
+＊ Patch "files", JSON merge patch for KubeadmConfigTemplate "worker" (MachineDeployment "my-cluster-md-0", spec.template.spec.bootstrap.configRef):
+{
+  "spec": {
+    "template": {
+      "spec": {
+        "files": []
+      }
+    }
+  }
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var output bytes.Buffer
+
+			g.Expect(printTopologyRenderPatchOutput(&output, tt.out)).To(Succeed())
+			g.Expect(output.String()).To(Equal(tt.want))
+		})
+	}
+}
@@ -67,6 +67,7 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.MachineTemplate.NodeDeletionTimeout
+	dst.Spec.MachineTemplate.NodeDrainPolicy = restored.Spec.MachineTemplate.NodeDrainPolicy
 	dst.Spec.RolloutBefore = restored.Spec.RolloutBefore
 	dst.Spec.MachineTemplate.NodeVolumeDetachTimeout = restored.Spec.MachineTemplate.NodeVolumeDetachTimeout
 
@@ -90,6 +91,15 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Status.LastRemediation != nil {
 		dst.Status.LastRemediation = restored.Status.LastRemediation
 	}
+	dst.Spec.EtcdBackup = restored.Spec.EtcdBackup
+	dst.Spec.EtcdDefragmentation = restored.Spec.EtcdDefragmentation
+	dst.Status.EtcdBackup = restored.Status.EtcdBackup
+	dst.Status.RemediationHistory = restored.Status.RemediationHistory
+
+	if restored.Spec.RolloutStrategy != nil && restored.Spec.RolloutStrategy.RollingUpdate != nil &&
+		dst.Spec.RolloutStrategy != nil && dst.Spec.RolloutStrategy.RollingUpdate != nil {
+		dst.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = restored.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable
+	}
 
 	return nil
 }
@@ -166,6 +176,7 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.MachineTemplate = restored.Spec.Template.Spec.MachineTemplate
 	} else if restored.Spec.Template.Spec.MachineTemplate != nil {
 		dst.Spec.Template.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.Template.Spec.MachineTemplate.NodeDeletionTimeout
+		dst.Spec.Template.Spec.MachineTemplate.NodeDrainPolicy = restored.Spec.Template.Spec.MachineTemplate.NodeDrainPolicy
 		dst.Spec.Template.Spec.MachineTemplate.NodeVolumeDetachTimeout = restored.Spec.Template.Spec.MachineTemplate.NodeVolumeDetachTimeout
 	}
 
@@ -188,6 +199,13 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Template.Spec.RemediationStrategy != nil {
 		dst.Spec.Template.Spec.RemediationStrategy = restored.Spec.Template.Spec.RemediationStrategy
 	}
+	dst.Spec.Template.Spec.EtcdBackup = restored.Spec.Template.Spec.EtcdBackup
+	dst.Spec.Template.Spec.EtcdDefragmentation = restored.Spec.Template.Spec.EtcdDefragmentation
+
+	if restored.Spec.Template.Spec.RolloutStrategy != nil && restored.Spec.Template.Spec.RolloutStrategy.RollingUpdate != nil &&
+		dst.Spec.Template.Spec.RolloutStrategy != nil && dst.Spec.Template.Spec.RolloutStrategy.RollingUpdate != nil {
+		dst.Spec.Template.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = restored.Spec.Template.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable
+	}
 
 	return nil
 }
@@ -291,3 +309,8 @@ func Convert_v1beta1_KubeadmControlPlaneTemplateResource_To_v1alpha4_KubeadmCont
 	// .metadata and .spec.machineTemplate.metadata was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneTemplateResource_To_v1alpha4_KubeadmControlPlaneTemplateResource(in, out, scope)
 }
+
+func Convert_v1beta1_RollingUpdate_To_v1alpha4_RollingUpdate(in *controlplanev1.RollingUpdate, out *RollingUpdate, scope apiconversion.Scope) error {
+	// .MaxUnavailable was added in v1beta1.
+	return autoConvert_v1beta1_RollingUpdate_To_v1alpha4_RollingUpdate(in, out, scope)
+}
@@ -149,3 +149,33 @@ const (
 	// generate a machine object.
 	MachineGenerationFailedReason = "MachineGenerationFailed"
 )
+
+const (
+	// EtcdBackupSucceededCondition documents that the last attempt to take a snapshot of the etcd cluster
+	// and to store it in the sink succeeded.
+	// NOTE: This conditions exists only if etcd backups are configured.
+	EtcdBackupSucceededCondition clusterv1.ConditionType = "EtcdBackupSucceeded"
+
+	// EtcdBackupFailedReason (Severity=Warning) documents a KubeadmControlPlane failing to take a snapshot of
+	// the etcd cluster or to store it in the sink.
+	EtcdBackupFailedReason = "EtcdBackupFailed"
+)
+
+const (
+	// EtcdDefragmentedCondition documents that there are no etcd members left to be defragmented and no NOSPACE
+	// alarms raised in the etcd cluster.
+	// NOTE: This conditions exists only if etcd defragmentation is configured.
+	EtcdDefragmentedCondition clusterv1.ConditionType = "EtcdDefragmented"
+
+	// EtcdDefragmentationInProgressReason (Severity=Info) documents a KubeadmControlPlane defragmenting the
+	// etcd members one at a time.
+	EtcdDefragmentationInProgressReason = "EtcdDefragmentationInProgress"
+
+	// EtcdDefragmentationFailedReason (Severity=Warning) documents a KubeadmControlPlane failing to defragment
+	// an etcd member or to disarm NOSPACE alarms.
+	EtcdDefragmentationFailedReason = "EtcdDefragmentationFailed"
+
+	// EtcdNoSpaceAlarmReason (Severity=Error) documents a NOSPACE alarm raised in the etcd cluster which cannot be
+	// remediated by defragmenting the etcd members, because there is not enough space to be reclaimed.
+	EtcdNoSpaceAlarmReason = "EtcdNoSpaceAlarm"
+)
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -65,6 +66,13 @@ const (
 	// failures in updating remediation retry (the counter restarts from zero).
 	RemediationForAnnotation = "controlplane.cluster.x-k8s.io/remediation-for"
 
+	// EtcdSnapshotLabel is set on the objects storing an etcd snapshot taken by the KubeadmControlPlane,
+	// and its value is the name of the snapshot.
+	EtcdSnapshotLabel = "controlplane.cluster.x-k8s.io/etcd-snapshot"
+
+	// DefaultEtcdBackupMaxSnapshots defines the default number of etcd snapshots to retain.
+	DefaultEtcdBackupMaxSnapshots = 5
+
 	// DefaultMinHealthyPeriod defines the default minimum period before we consider a remediation on a
 	// machine unrelated from the previous remediation.
 	DefaultMinHealthyPeriod = 1 * time.Hour
@@ -117,6 +125,17 @@ type KubeadmControlPlaneSpec struct {
 	// The RemediationStrategy that controls how control plane machine remediation happens.
 	// +optional
 	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
+
+	// EtcdBackup configures periodic snapshots of the etcd cluster managed by the KubeadmControlPlane.
+	// NOTE: This field can be set only when using a stacked etcd cluster.
+	// +optional
+	EtcdBackup *EtcdBackup `json:"etcdBackup,omitempty"`
+
+	// EtcdDefragmentation configures automatic defragmentation of the members of the etcd cluster
+	// managed by the KubeadmControlPlane.
+	// NOTE: This field can be set only when using a stacked etcd cluster.
+	// +optional
+	EtcdDefragmentation *EtcdDefragmentation `json:"etcdDefragmentation,omitempty"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -147,6 +166,11 @@ type KubeadmControlPlaneMachineTemplate struct {
 	// If no value is provided, the default value for this property of the Machine resource will be used.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// NodeDrainPolicy defines how the controller drains the node before a controlplane Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// +optional
+	NodeDrainPolicy *clusterv1.NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
 }
 
 // RolloutBefore describes when a rollout should be performed on the KCP machines.
@@ -182,6 +206,17 @@ type RollingUpdate struct {
 	// up immediately when the rolling update starts.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// The maximum number of control planes that can be unavailable during the rolling
+	// update, i.e. that can be deleted before their replacement is created.
+	// Value can be an absolute number 1 or 0.
+	// Defaults to 1 if MaxSurge is 0, otherwise to 0.
+	// Example: when this is set to 1 and MaxSurge is set to 0, an outdated control plane
+	// is deleted before creating its replacement, thus scaling in place without requiring
+	// capacity for an additional control plane machine. In this case etcd must retain
+	// quorum after removing the outdated member, and the replica count needs to be at least 3.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // RemediationStrategy allows to define how control plane machine remediation happens.
@@ -228,6 +263,50 @@ type RemediationStrategy struct {
 	MinHealthyPeriod *metav1.Duration `json:"minHealthyPeriod,omitempty"`
 }
 
+// EtcdBackup defines how snapshots of the etcd cluster are taken and stored.
+type EtcdBackup struct {
+	// Interval is the minimum duration between two consecutive snapshots.
+	Interval metav1.Duration `json:"interval"`
+
+	// MaxSnapshots is the number of snapshots to retain; after a new snapshot is stored,
+	// the oldest snapshots exceeding this number are deleted.
+	// If not set, this value is defaulted to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSnapshots *int32 `json:"maxSnapshots,omitempty"`
+
+	// Sink defines where snapshots are stored.
+	Sink EtcdBackupSink `json:"sink"`
+}
+
+// EtcdBackupSink defines where snapshots are stored; exactly one sink must be set.
+type EtcdBackupSink struct {
+	// Secret stores snapshots in Secrets in the namespace of the KubeadmControlPlane.
+	// +optional
+	Secret *EtcdBackupSecretSink `json:"secret,omitempty"`
+}
+
+// EtcdBackupSecretSink stores snapshots in Secrets in the namespace of the KubeadmControlPlane.
+// Given the size limit of Secrets, each snapshot is split into chunks stored in separate Secrets,
+// labeled with the snapshot name.
+type EtcdBackupSecretSink struct {
+	// NamePrefix is the prefix used for the names of the Secrets storing snapshots.
+	// If not set, this value is defaulted to the name of the KubeadmControlPlane followed by "-etcd-snapshot".
+	// +optional
+	NamePrefix string `json:"namePrefix,omitempty"`
+}
+
+// EtcdDefragmentation defines when the members of the etcd cluster are defragmented.
+// Members are defragmented one at a time, with the etcd leader last; once there are no members left
+// to defragment, NOSPACE alarms raised in the etcd cluster are disarmed if the backend database of all
+// the members leaves at least 10% of the etcd backend quota free.
+type EtcdDefragmentation struct {
+	// DBSizeThreshold is the size of the backend database of an etcd member above which the member is defragmented.
+	// If not set, members are defragmented only when a NOSPACE alarm is raised in the etcd cluster.
+	// +optional
+	DBSizeThreshold *resource.Quantity `json:"dbSizeThreshold,omitempty"`
+}
+
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 type KubeadmControlPlaneStatus struct {
 	// Selector is the label selector in string format to avoid introspection
@@ -297,6 +376,15 @@ type KubeadmControlPlaneStatus struct {
 	// LastRemediation stores info about last remediation performed.
 	// +optional
 	LastRemediation *LastRemediationStatus `json:"lastRemediation,omitempty"`
+
+	// RemediationHistory is the history of the most recent remediations of unhealthy Machines
+	// performed by the KubeadmControlPlane, from the oldest to the most recent.
+	// +optional
+	RemediationHistory []clusterv1.RemediationHistoryEntry `json:"remediationHistory,omitempty"`
+
+	// EtcdBackup reports the status of the etcd snapshots taken by the KubeadmControlPlane.
+	// +optional
+	EtcdBackup *EtcdBackupStatus `json:"etcdBackup,omitempty"`
 }
 
 // LastRemediationStatus  stores info about last remediation performed.
@@ -314,6 +402,31 @@ type LastRemediationStatus struct {
 	RetryCount int32 `json:"retryCount"`
 }
 
+// EtcdBackupStatus reports the status of the etcd snapshots taken by the KubeadmControlPlane.
+type EtcdBackupStatus struct {
+	// LastAttempt is when the last snapshot was attempted, either successfully or not. It is represented in RFC3339 form and is in UTC.
+	// The next snapshot is attempted only after the interval since the last attempt elapses, so failed snapshots
+	// are not retried until then.
+	// +optional
+	LastAttempt *metav1.Time `json:"lastAttempt,omitempty"`
+
+	// LastSuccessfulSnapshot is the last snapshot taken and stored successfully.
+	// +optional
+	LastSuccessfulSnapshot *EtcdSnapshot `json:"lastSuccessfulSnapshot,omitempty"`
+}
+
+// EtcdSnapshot stores info about a snapshot of the etcd cluster.
+type EtcdSnapshot struct {
+	// Name is the name identifying the snapshot in the sink.
+	Name string `json:"name"`
+
+	// Timestamp is when the snapshot was taken. It is represented in RFC3339 form and is in UTC.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// Size is the size of the snapshot in bytes.
+	Size int64 `json:"size"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=kubeadmcontrolplanes,shortName=kcp,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
@@ -101,6 +101,17 @@ type KubeadmControlPlaneTemplateResourceSpec struct {
 	// The RemediationStrategy that controls how control plane machine remediation happens.
 	// +optional
 	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
+
+	// EtcdBackup configures periodic snapshots of the etcd cluster managed by the KubeadmControlPlane.
+	// NOTE: This field can be set only when using a stacked etcd cluster.
+	// +optional
+	EtcdBackup *EtcdBackup `json:"etcdBackup,omitempty"`
+
+	// EtcdDefragmentation configures automatic defragmentation of the members of the etcd cluster
+	// managed by the KubeadmControlPlane.
+	// NOTE: This field can be set only when using a stacked etcd cluster.
+	// +optional
+	EtcdDefragmentation *EtcdDefragmentation `json:"etcdDefragmentation,omitempty"`
 }
 
 // KubeadmControlPlaneTemplateMachineTemplate defines the template for Machines
@@ -131,4 +142,9 @@ type KubeadmControlPlaneTemplateMachineTemplate struct {
 	// If no value is provided, the default value for this property of the Machine resource will be used.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// NodeDrainPolicy defines how the controller drains the node before a controlplane Machine is deleted.
+	// If not set, all the pods are evicted at once, respecting PodDisruptionBudgets.
+	// +optional
+	NodeDrainPolicy *clusterv1.NodeDrainPolicy `json:"nodeDrainPolicy,omitempty"`
 }
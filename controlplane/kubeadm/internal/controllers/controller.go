@@ -63,11 +63,12 @@ const (
 )
 
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
-// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;patch;delete
 
 // KubeadmControlPlaneReconciler reconciles a KubeadmControlPlane object.
 type KubeadmControlPlaneReconciler struct {
@@ -329,6 +330,8 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.MachinesReadyCondition,
 			controlplanev1.AvailableCondition,
 			controlplanev1.CertificatesAvailableCondition,
+			controlplanev1.EtcdBackupSucceededCondition,
+			controlplanev1.EtcdDefragmentedCondition,
 		}},
 		patch.WithStatusObservedGeneration{},
 	)
@@ -373,6 +376,11 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return ctrl.Result{}, errors.Wrap(err, "failed to sync Machines")
 	}
 
+	// Record the current spec in the revision history, so it is possible to roll back to it.
+	if err := r.reconcileRevisionHistory(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile revision history")
+	}
+
 	// Aggregate the operational state of all the machines; while aggregating we are adding the
 	// source ref (reason@machine/name) so the problem can be easily tracked down to its source machine.
 	conditions.SetAggregate(controlPlane.KCP, controlplanev1.MachinesReadyCondition, controlPlane.Machines.ConditionGetters(), conditions.AddSourceRef(), conditions.WithStepCounterIf(false))
@@ -475,7 +483,20 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	if err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+
+	// Defragment etcd members and take etcd snapshots if configured.
+	// Note: Similarly to certificate expiries, this is done at the end of the reconcile to ensure it doesn't block anything else.
+	defragmentationResult, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	backupResult, err := r.reconcileEtcdBackup(ctx, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return util.LowestNonZeroResult(defragmentationResult, backupResult), nil
 }
 
 // reconcileClusterCertificates ensures that all the cluster certificates exists and
@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcdbackup"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// etcdSnapshotNameFormat is the layout used to generate the name of etcd snapshots from the time they are taken.
+const etcdSnapshotNameFormat = "20060102150405"
+
+// etcdSnapshotTimeout is the maximum duration for streaming an etcd snapshot; given that snapshots are taken
+// synchronously while reconciling the KubeadmControlPlane, this prevents a slow or large etcd cluster from blocking
+// the reconcile for an unbounded amount of time.
+var etcdSnapshotTimeout = 5 * time.Minute
+
+// reconcileEtcdBackup takes a snapshot of the etcd cluster if etcd backups are configured and the interval since
+// the last attempt is elapsed; after a new snapshot is stored, the oldest snapshots exceeding the retention policy
+// are deleted.
+// NOTE: Failures are reported in the EtcdBackupSucceeded condition and the snapshot is not retried until the next
+// interval, so a failing backup doesn't block the KubeadmControlPlane with retries.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdBackup(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	if kcp.Spec.EtcdBackup == nil || !controlPlane.IsEtcdManaged() {
+		conditions.Delete(kcp, controlplanev1.EtcdBackupSucceededCondition)
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for the interval since the last attempt to elapse.
+	now := time.Now().UTC()
+	interval := kcp.Spec.EtcdBackup.Interval.Duration
+	if lastAttempt := lastEtcdSnapshotAttempt(kcp); lastAttempt != nil {
+		next := lastAttempt.Add(interval)
+		if now.Before(next) {
+			return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	store, err := etcdbackup.NewStore(r.Client, kcp.Spec.EtcdBackup.Sink)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if kcp.Status.EtcdBackup == nil {
+		kcp.Status.EtcdBackup = &controlplanev1.EtcdBackupStatus{}
+	}
+	kcp.Status.EtcdBackup.LastAttempt = &metav1.Time{Time: now}
+
+	snapshot := &etcdbackup.Snapshot{
+		Name:      now.Format(etcdSnapshotNameFormat),
+		Timestamp: now,
+	}
+	if err := r.takeEtcdSnapshot(ctx, controlPlane, store, snapshot); err != nil {
+		log.Error(err, "Failed to take etcd snapshot, retrying after the backup interval", "snapshot", snapshot.Name)
+		conditions.MarkFalse(kcp, controlplanev1.EtcdBackupSucceededCondition, controlplanev1.EtcdBackupFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdBackup", "Failed to take etcd snapshot %s: %v", snapshot.Name, err)
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	log.Info("Took etcd snapshot", "snapshot", snapshot.Name, "size", snapshot.Size)
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "SuccessfulEtcdBackup", "Took etcd snapshot %s", snapshot.Name)
+	conditions.MarkTrue(kcp, controlplanev1.EtcdBackupSucceededCondition)
+	kcp.Status.EtcdBackup.LastSuccessfulSnapshot = &controlplanev1.EtcdSnapshot{
+		Name:      snapshot.Name,
+		Timestamp: metav1.NewTime(snapshot.Timestamp),
+		Size:      snapshot.Size,
+	}
+
+	// NOTE: Expired snapshots which can't be deleted now are deleted after the next snapshot.
+	if err := r.deleteExpiredEtcdSnapshots(ctx, kcp, store); err != nil {
+		log.Error(err, "Failed to delete expired etcd snapshots")
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdBackupRetention", "Failed to delete expired etcd snapshots: %v", err)
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// lastEtcdSnapshotAttempt returns when the last etcd snapshot was attempted; if the attempt was not recorded,
+// the time of the last successful snapshot is used.
+func lastEtcdSnapshotAttempt(kcp *controlplanev1.KubeadmControlPlane) *time.Time {
+	if kcp.Status.EtcdBackup == nil {
+		return nil
+	}
+	if kcp.Status.EtcdBackup.LastAttempt != nil {
+		return &kcp.Status.EtcdBackup.LastAttempt.Time
+	}
+	if kcp.Status.EtcdBackup.LastSuccessfulSnapshot != nil {
+		return &kcp.Status.EtcdBackup.LastSuccessfulSnapshot.Timestamp.Time
+	}
+	return nil
+}
+
+// takeEtcdSnapshot takes a snapshot of the etcd cluster and stores it.
+func (r *KubeadmControlPlaneReconciler) takeEtcdSnapshot(ctx context.Context, controlPlane *internal.ControlPlane, store etcdbackup.Store, snapshot *etcdbackup.Snapshot) (reterr error) {
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create client to workload cluster")
+	}
+
+	// NOTE: The timeout applies only to the snapshot stream, so the store can still clean up partially stored
+	// snapshots using ctx when the timeout expires.
+	snapshotCtx, cancel := context.WithTimeout(ctx, etcdSnapshotTimeout)
+	defer cancel()
+
+	data, err := workloadCluster.EtcdSnapshot(snapshotCtx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get etcd snapshot")
+	}
+	defer func() {
+		if err := data.Close(); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if err := store.Save(ctx, controlPlane.KCP, snapshot, data); err != nil {
+		if errors.Is(snapshotCtx.Err(), context.DeadlineExceeded) {
+			return errors.Wrapf(err, "timed out after %s while streaming etcd snapshot", etcdSnapshotTimeout)
+		}
+		return err
+	}
+	return nil
+}
+
+// deleteExpiredEtcdSnapshots deletes the oldest etcd snapshots exceeding the number of snapshots to retain.
+func (r *KubeadmControlPlaneReconciler) deleteExpiredEtcdSnapshots(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, store etcdbackup.Store) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	maxSnapshots := controlplanev1.DefaultEtcdBackupMaxSnapshots
+	if kcp.Spec.EtcdBackup.MaxSnapshots != nil {
+		maxSnapshots = int(*kcp.Spec.EtcdBackup.MaxSnapshots)
+	}
+
+	snapshots, err := store.List(ctx, kcp)
+	if err != nil {
+		return errors.Wrap(err, "failed to list etcd snapshots")
+	}
+
+	errs := []error{}
+	for i := 0; i < len(snapshots)-maxSnapshots; i++ {
+		if err := store.Delete(ctx, kcp, snapshots[i].Name); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to delete etcd snapshot %s", snapshots[i].Name))
+			continue
+		}
+		log.Info("Deleted expired etcd snapshot", "snapshot", snapshots[i].Name)
+	}
+	return kerrors.NewAggregate(errs)
+}
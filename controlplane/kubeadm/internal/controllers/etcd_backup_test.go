@@ -0,0 +1,229 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcdbackup"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestKubeadmControlPlaneReconciler_reconcileEtcdBackup(t *testing.T) {
+	etcdBackup := &controlplanev1.EtcdBackup{
+		Interval:     metav1.Duration{Duration: time.Hour},
+		MaxSnapshots: pointer.Int32(1),
+		Sink: controlplanev1.EtcdBackupSink{
+			Secret: &controlplanev1.EtcdBackupSecretSink{},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		etcdBackup          *controlplanev1.EtcdBackup
+		externalEtcd        bool
+		lastSnapshotAge     time.Duration
+		lastAttemptAge      time.Duration
+		snapshotErr         error
+		snapshotHangs       bool
+		wantResult          ctrl.Result
+		wantSnapshot        bool
+		wantConditionStatus corev1.ConditionStatus
+	}{
+		{
+			name:       "does nothing if etcd backups are not configured",
+			etcdBackup: nil,
+			wantResult: ctrl.Result{},
+		},
+		{
+			name:         "does nothing if etcd is external",
+			etcdBackup:   etcdBackup,
+			externalEtcd: true,
+			wantResult:   ctrl.Result{},
+		},
+		{
+			name:            "waits for the interval since the last snapshot to elapse",
+			etcdBackup:      etcdBackup,
+			lastSnapshotAge: 30 * time.Minute,
+			wantResult:      ctrl.Result{RequeueAfter: 30 * time.Minute},
+		},
+		{
+			name:            "waits for the interval since the last failed attempt to elapse",
+			etcdBackup:      etcdBackup,
+			lastSnapshotAge: 2 * time.Hour,
+			lastAttemptAge:  15 * time.Minute,
+			wantResult:      ctrl.Result{RequeueAfter: 45 * time.Minute},
+		},
+		{
+			name:                "takes the first snapshot",
+			etcdBackup:          etcdBackup,
+			wantResult:          ctrl.Result{RequeueAfter: time.Hour},
+			wantSnapshot:        true,
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name:                "takes a snapshot after the interval since the last snapshot elapsed",
+			etcdBackup:          etcdBackup,
+			lastSnapshotAge:     2 * time.Hour,
+			wantResult:          ctrl.Result{RequeueAfter: time.Hour},
+			wantSnapshot:        true,
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name:                "takes a snapshot after the interval since the last failed attempt elapsed",
+			etcdBackup:          etcdBackup,
+			lastSnapshotAge:     3 * time.Hour,
+			lastAttemptAge:      2 * time.Hour,
+			wantResult:          ctrl.Result{RequeueAfter: time.Hour},
+			wantSnapshot:        true,
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name:                "reports failures in taking the snapshot and retries after the interval",
+			etcdBackup:          etcdBackup,
+			lastSnapshotAge:     2 * time.Hour,
+			snapshotErr:         errors.New("failed to get snapshot"),
+			wantResult:          ctrl.Result{RequeueAfter: time.Hour},
+			wantConditionStatus: corev1.ConditionFalse,
+		},
+		{
+			name:                "reports a timeout in taking the snapshot and retries after the interval",
+			etcdBackup:          etcdBackup,
+			snapshotHangs:       true,
+			wantResult:          ctrl.Result{RequeueAfter: time.Hour},
+			wantConditionStatus: corev1.ConditionFalse,
+		},
+	}
+
+	defer func(timeout time.Duration) {
+		etcdSnapshotTimeout = timeout
+	}(etcdSnapshotTimeout)
+	etcdSnapshotTimeout = 100 * time.Millisecond
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "kcp",
+					UID:       "kcp-uid",
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdBackup: tt.etcdBackup,
+				},
+			}
+			if tt.externalEtcd {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+				}
+			}
+			if tt.lastSnapshotAge != 0 {
+				kcp.Status.EtcdBackup = &controlplanev1.EtcdBackupStatus{
+					LastSuccessfulSnapshot: &controlplanev1.EtcdSnapshot{
+						Name:      "last",
+						Timestamp: metav1.NewTime(time.Now().Add(-tt.lastSnapshotAge)),
+					},
+				}
+			}
+			if tt.lastAttemptAge != 0 {
+				kcp.Status.EtcdBackup.LastAttempt = &metav1.Time{Time: time.Now().Add(-tt.lastAttemptAge)}
+			}
+
+			fakeClient := newFakeClient()
+			r := &KubeadmControlPlaneReconciler{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(32),
+				managementCluster: &fakeManagementCluster{
+					Workload: fakeWorkloadCluster{
+						EtcdSnapshotData:  []byte("snapshot"),
+						EtcdSnapshotErr:   tt.snapshotErr,
+						EtcdSnapshotHangs: tt.snapshotHangs,
+					},
+				},
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP:     kcp,
+				Cluster: &clusterv1.Cluster{},
+			}
+			controlPlane.InjectTestManagementCluster(r.managementCluster)
+
+			// Store a snapshot taken before, which should be deleted given that only one snapshot is retained.
+			if tt.etcdBackup != nil {
+				store, err := etcdbackup.NewStore(fakeClient, tt.etcdBackup.Sink)
+				g.Expect(err).ToNot(HaveOccurred())
+				oldSnapshot := &etcdbackup.Snapshot{Name: "old", Timestamp: time.Now().Add(-24 * time.Hour)}
+				g.Expect(store.Save(ctx, kcp, oldSnapshot, bytes.NewReader([]byte("old-snapshot")))).To(Succeed())
+			}
+
+			result, err := r.reconcileEtcdBackup(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.Requeue).To(Equal(tt.wantResult.Requeue))
+			g.Expect(result.RequeueAfter).To(BeNumerically("~", tt.wantResult.RequeueAfter, time.Minute))
+
+			if tt.wantConditionStatus == "" {
+				g.Expect(conditions.Has(kcp, controlplanev1.EtcdBackupSucceededCondition)).To(BeFalse())
+			} else {
+				g.Expect(conditions.Get(kcp, controlplanev1.EtcdBackupSucceededCondition).Status).To(Equal(tt.wantConditionStatus))
+			}
+
+			// Check the attempt is recorded only when a snapshot is attempted.
+			if tt.wantConditionStatus != "" {
+				g.Expect(kcp.Status.EtcdBackup.LastAttempt).ToNot(BeNil())
+				g.Expect(kcp.Status.EtcdBackup.LastAttempt.Time).To(BeTemporally("~", time.Now(), time.Minute))
+			} else if tt.lastAttemptAge != 0 {
+				g.Expect(kcp.Status.EtcdBackup.LastAttempt.Time).To(BeTemporally("~", time.Now().Add(-tt.lastAttemptAge), time.Minute))
+			}
+
+			if !tt.wantSnapshot {
+				if tt.lastSnapshotAge != 0 {
+					g.Expect(kcp.Status.EtcdBackup.LastSuccessfulSnapshot.Name).To(Equal("last"))
+				}
+				return
+			}
+
+			g.Expect(kcp.Status.EtcdBackup).ToNot(BeNil())
+			g.Expect(kcp.Status.EtcdBackup.LastSuccessfulSnapshot).ToNot(BeNil())
+			g.Expect(kcp.Status.EtcdBackup.LastSuccessfulSnapshot.Size).To(Equal(int64(len("snapshot"))))
+
+			secrets := &corev1.SecretList{}
+			g.Expect(fakeClient.List(ctx, secrets)).To(Succeed())
+			g.Expect(secrets.Items).ToNot(BeEmpty())
+			// Check the old snapshot is deleted.
+			for _, s := range secrets.Items {
+				g.Expect(s.Labels).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotLabel, kcp.Status.EtcdBackup.LastSuccessfulSnapshot.Name))
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// etcdDefragmentationRequeueAfter is how long to wait after defragmenting an etcd member before checking
+	// the next one, so the defragmented member can catch up with the rest of the etcd cluster.
+	etcdDefragmentationRequeueAfter = 30 * time.Second
+
+	// etcdMinReclaimablePercent is the minimum percentage of the backend database of an etcd member that must be
+	// reclaimable to defragment the member; this prevents defragmenting members over and over when the
+	// space in use exceeds the size threshold.
+	etcdMinReclaimablePercent = 10
+
+	// etcdMinFreeQuotaPercent is the minimum percentage of the backend quota that must be free in the backend database
+	// of all the etcd members to disarm NOSPACE alarms; this prevents disarming alarms which would be raised again
+	// on the next writes.
+	etcdMinFreeQuotaPercent = 10
+
+	// defaultEtcdQuotaBackendBytes is the backend quota used by etcd when quota-backend-bytes is not set.
+	defaultEtcdQuotaBackendBytes = 2 * 1024 * 1024 * 1024
+)
+
+// reconcileEtcdDefragmentation defragments the etcd members if etcd defragmentation is configured.
+// Members are defragmented one at a time, given that a member cannot serve requests while it is being defragmented;
+// once there are no members left to defragment, NOSPACE alarms are disarmed if all the members have enough free space
+// in the backend quota; otherwise the alarm is kept and reported, given that disarming it would not free any space.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdDefragmentation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	if kcp.Spec.EtcdDefragmentation == nil || !controlPlane.IsEtcdManaged() {
+		conditions.Delete(kcp, controlplanev1.EtcdDefragmentedCondition)
+		return ctrl.Result{}, nil
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create client to workload cluster")
+	}
+
+	// Defragmenting a member makes it temporarily unavailable, so members are defragmented only if all the etcd members
+	// are reachable, are hosted on control plane nodes and have no alarms other than NOSPACE.
+	// NOTE: The EtcdClusterHealthy condition cannot be used here, given that it reports members with NOSPACE alarms
+	// as unhealthy, and NOSPACE alarms are what defragmentation is meant to remediate.
+	members, err := workloadCluster.EtcdMembersDBStatus(ctx)
+	if err != nil {
+		log.Info("Waiting for all the etcd members to be reachable before checking if etcd members require defragmentation", "reason", err.Error())
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+	if member, alarm := firstEtcdAlarmExcept(members, etcd.AlarmOK, etcd.AlarmNoSpace); member != "" {
+		log.Info("Waiting for etcd alarms to be resolved before checking if etcd members require defragmentation", "member", member, "alarm", etcd.AlarmTypeName[alarm])
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+
+	noSpaceAlarm := hasEtcdAlarm(members, etcd.AlarmNoSpace)
+	toDefragment := etcdMembersToDefragment(members, kcp.Spec.EtcdDefragmentation.DBSizeThreshold, noSpaceAlarm)
+	if len(toDefragment) > 0 {
+		member := toDefragment[0]
+		log.Info("Defragmenting etcd member", "member", member.Name, "dbSize", member.DBSize, "dbSizeInUse", member.DBSizeInUse)
+		if err := workloadCluster.DefragmentEtcdMember(ctx, member.Name); err != nil {
+			conditions.MarkFalse(kcp, controlplanev1.EtcdDefragmentedCondition, controlplanev1.EtcdDefragmentationFailedReason, clusterv1.ConditionSeverityWarning,
+				"Failed to defragment etcd member %s: %v", member.Name, err)
+			r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdDefragmentation", "Failed to defragment etcd member %s: %v", member.Name, err)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to defragment etcd member %s", member.Name)
+		}
+
+		r.recorder.Eventf(kcp, corev1.EventTypeNormal, "SuccessfulEtcdDefragmentation", "Defragmented etcd member %s", member.Name)
+		conditions.MarkFalse(kcp, controlplanev1.EtcdDefragmentedCondition, controlplanev1.EtcdDefragmentationInProgressReason, clusterv1.ConditionSeverityInfo,
+			"Defragmented etcd member %s, %d members left to defragment", member.Name, len(toDefragment)-1)
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+
+	if noSpaceAlarm {
+		// Disarm NOSPACE alarms only if the backend database of all the members fits in the backend quota with
+		// enough free space, given that etcd raises the alarm again as soon as there is a write otherwise.
+		quota := etcdQuotaBackendBytes(kcp)
+		if member := firstEtcdMemberWithoutFreeQuota(members, quota); member != nil {
+			conditions.MarkFalse(kcp, controlplanev1.EtcdDefragmentedCondition, controlplanev1.EtcdNoSpaceAlarmReason, clusterv1.ConditionSeverityError,
+				"NOSPACE alarm is raised, but the backend database of etcd member %s uses %d bytes of the %d bytes quota and there is not enough space to be reclaimed by defragmentation", member.Name, member.DBSize, quota)
+			log.Info("NOSPACE alarm is raised, but there is not enough space to be reclaimed by defragmentation", "member", member.Name, "dbSize", member.DBSize, "quotaBackendBytes", quota)
+			return ctrl.Result{}, nil
+		}
+		if err := workloadCluster.DisarmEtcdAlarms(ctx, etcd.AlarmNoSpace); err != nil {
+			conditions.MarkFalse(kcp, controlplanev1.EtcdDefragmentedCondition, controlplanev1.EtcdDefragmentationFailedReason, clusterv1.ConditionSeverityWarning,
+				"Failed to disarm etcd NOSPACE alarms: %v", err)
+			r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdDefragmentation", "Failed to disarm etcd NOSPACE alarms: %v", err)
+			return ctrl.Result{}, errors.Wrap(err, "failed to disarm etcd NOSPACE alarms")
+		}
+		log.Info("Disarmed etcd NOSPACE alarms")
+		r.recorder.Event(kcp, corev1.EventTypeNormal, "DisarmedEtcdAlarms", "Disarmed etcd NOSPACE alarms")
+	}
+
+	conditions.MarkTrue(kcp, controlplanev1.EtcdDefragmentedCondition)
+	return ctrl.Result{}, nil
+}
+
+// hasEtcdAlarm returns true if an alarm of the given type is raised for any of the etcd members.
+func hasEtcdAlarm(members []internal.EtcdMemberDBStatus, alarmType etcd.AlarmType) bool {
+	for _, member := range members {
+		for _, alarm := range member.Alarms {
+			if alarm == alarmType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// etcdQuotaBackendBytes returns the backend quota of the etcd members, as configured by the quota-backend-bytes
+// extra arg of the local etcd in the KubeadmControlPlane, or the etcd default if not set.
+func etcdQuotaBackendBytes(kcp *controlplanev1.KubeadmControlPlane) int64 {
+	clusterConfiguration := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if clusterConfiguration != nil && clusterConfiguration.Etcd.Local != nil {
+		if quota, err := strconv.ParseInt(clusterConfiguration.Etcd.Local.ExtraArgs["quota-backend-bytes"], 10, 64); err == nil && quota > 0 {
+			return quota
+		}
+	}
+	return defaultEtcdQuotaBackendBytes
+}
+
+// firstEtcdMemberWithoutFreeQuota returns the first etcd member whose backend database leaves less than
+// etcdMinFreeQuotaPercent of the backend quota free.
+func firstEtcdMemberWithoutFreeQuota(members []internal.EtcdMemberDBStatus, quota int64) *internal.EtcdMemberDBStatus {
+	for i := range members {
+		if (quota-members[i].DBSize)*100 < quota*etcdMinFreeQuotaPercent {
+			return &members[i]
+		}
+	}
+	return nil
+}
+
+// firstEtcdAlarmExcept returns the name of the first etcd member with an alarm not in the given alarm types, and the alarm.
+func firstEtcdAlarmExcept(members []internal.EtcdMemberDBStatus, alarmTypes ...etcd.AlarmType) (string, etcd.AlarmType) {
+	ignored := sets.New[etcd.AlarmType](alarmTypes...)
+	for _, member := range members {
+		for _, alarm := range member.Alarms {
+			if !ignored.Has(alarm) {
+				return member.Name, alarm
+			}
+		}
+	}
+	return "", etcd.AlarmOK
+}
+
+// etcdMembersToDefragment returns the etcd members to defragment, i.e. all the members when a NOSPACE alarm is raised,
+// otherwise the members whose backend database exceeds the size threshold; in both cases members without enough space
+// to be reclaimed are skipped, e.g. members that have already been defragmented.
+// The etcd leader is returned last, given that defragmenting the leader is more disruptive than defragmenting a follower.
+func etcdMembersToDefragment(members []internal.EtcdMemberDBStatus, dbSizeThreshold *resource.Quantity, noSpaceAlarm bool) []internal.EtcdMemberDBStatus {
+	toDefragment := []internal.EtcdMemberDBStatus{}
+	for _, member := range members {
+		if !noSpaceAlarm && (dbSizeThreshold == nil || member.DBSize <= dbSizeThreshold.Value()) {
+			continue
+		}
+		if (member.DBSize-member.DBSizeInUse)*100 < member.DBSize*etcdMinReclaimablePercent {
+			continue
+		}
+		toDefragment = append(toDefragment, member)
+	}
+
+	sort.SliceStable(toDefragment, func(i, j int) bool {
+		return !toDefragment[i].IsLeader && toDefragment[j].IsLeader
+	})
+	return toDefragment
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestKubeadmControlPlaneReconciler_reconcileEtcdDefragmentation(t *testing.T) {
+	threshold := resource.MustParse("100")
+	withThreshold := &controlplanev1.EtcdDefragmentation{DBSizeThreshold: &threshold}
+
+	// etcdClusterNoSpace is the EtcdClusterHealthy condition reported by UpdateEtcdConditions when a member has a NOSPACE alarm.
+	etcdClusterNoSpace := conditions.FalseCondition(controlplanev1.EtcdClusterHealthyCondition, controlplanev1.EtcdClusterUnhealthyReason, clusterv1.ConditionSeverityError, "Following machines are reporting etcd member errors: %s", "m1")
+	defragmentationInProgress := conditions.FalseCondition(controlplanev1.EtcdDefragmentedCondition, controlplanev1.EtcdDefragmentationInProgressReason, clusterv1.ConditionSeverityInfo, "")
+
+	tests := []struct {
+		name                  string
+		etcdDefragmentation   *controlplanev1.EtcdDefragmentation
+		quotaBackendBytes     string
+		etcdClusterHealthy    *clusterv1.Condition
+		defragmentedCondition *clusterv1.Condition
+		members               []internal.EtcdMemberDBStatus
+		membersErr            error
+		defragmentErr         error
+		wantResult            ctrl.Result
+		wantErr               bool
+		wantDefragmented      []string
+		wantDisarmed          []etcd.AlarmType
+		wantConditionStatus   corev1.ConditionStatus
+		wantConditionReason   string
+	}{
+		{
+			name: "does nothing if etcd defragmentation is not configured",
+		},
+		{
+			name:                "waits for all the etcd members to be reachable",
+			etcdDefragmentation: withThreshold,
+			membersErr:          errors.New("failed to connect to the etcd member"),
+			wantResult:          ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+		},
+		{
+			name:                "waits for alarms other than NOSPACE to be resolved",
+			etcdDefragmentation: withThreshold,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 200, DBSizeInUse: 50, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace, etcd.AlarmCorrupt}},
+			},
+			wantResult: ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+		},
+		{
+			name:                "does nothing if no member exceeds the threshold",
+			etcdDefragmentation: withThreshold,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 100, DBSizeInUse: 50},
+				{Name: "m2", DBSize: 80, DBSizeInUse: 50},
+			},
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name:                "does nothing if members exceeding the threshold have no space to reclaim",
+			etcdDefragmentation: withThreshold,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 200, DBSizeInUse: 195},
+			},
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name:                "defragments only one member exceeding the threshold, with the leader last",
+			etcdDefragmentation: withThreshold,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", IsLeader: true, DBSize: 200, DBSizeInUse: 50},
+				{Name: "m2", DBSize: 80, DBSizeInUse: 50},
+				{Name: "m3", DBSize: 200, DBSizeInUse: 50},
+			},
+			wantResult:          ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+			wantDefragmented:    []string{"m3"},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: controlplanev1.EtcdDefragmentationInProgressReason,
+		},
+		{
+			name:                "defragments members when a NOSPACE alarm is raised, even without threshold and with the etcd cluster reported as unhealthy",
+			etcdDefragmentation: &controlplanev1.EtcdDefragmentation{},
+			etcdClusterHealthy:  etcdClusterNoSpace,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 80, DBSizeInUse: 50, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+				{Name: "m2", DBSize: 80, DBSizeInUse: 78},
+			},
+			wantResult:          ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+			wantDefragmented:    []string{"m1"},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: controlplanev1.EtcdDefragmentationInProgressReason,
+		},
+		{
+			name:                  "disarms NOSPACE alarms when there are no members left to defragment",
+			etcdDefragmentation:   &controlplanev1.EtcdDefragmentation{},
+			quotaBackendBytes:     "100",
+			etcdClusterHealthy:    etcdClusterNoSpace,
+			defragmentedCondition: defragmentationInProgress,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 52, DBSizeInUse: 50, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+				{Name: "m2", DBSize: 80, DBSizeInUse: 78},
+			},
+			wantDisarmed:        []etcd.AlarmType{etcd.AlarmNoSpace},
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name:                "disarms NOSPACE alarms when all the members have enough free quota, even if they were not defragmented by KCP",
+			etcdDefragmentation: &controlplanev1.EtcdDefragmentation{},
+			etcdClusterHealthy:  etcdClusterNoSpace,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 52, DBSizeInUse: 50, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+				{Name: "m2", DBSize: 80, DBSizeInUse: 78},
+			},
+			wantDisarmed:        []etcd.AlarmType{etcd.AlarmNoSpace},
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name:                "keeps NOSPACE alarms and reports them when there are no members with enough space to be reclaimed",
+			etcdDefragmentation: &controlplanev1.EtcdDefragmentation{},
+			quotaBackendBytes:   "100",
+			etcdClusterHealthy:  etcdClusterNoSpace,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 95, DBSizeInUse: 94, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+				{Name: "m2", DBSize: 80, DBSizeInUse: 78},
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: controlplanev1.EtcdNoSpaceAlarmReason,
+		},
+		{
+			name:                  "keeps NOSPACE alarms after defragmenting the members if they don't have enough free quota",
+			etcdDefragmentation:   &controlplanev1.EtcdDefragmentation{},
+			quotaBackendBytes:     "100",
+			etcdClusterHealthy:    etcdClusterNoSpace,
+			defragmentedCondition: defragmentationInProgress,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 52, DBSizeInUse: 50, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+				{Name: "m2", DBSize: 92, DBSizeInUse: 90},
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: controlplanev1.EtcdNoSpaceAlarmReason,
+		},
+		{
+			name:                "reports a failure to defragment a member",
+			etcdDefragmentation: withThreshold,
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "m1", DBSize: 200, DBSizeInUse: 50},
+			},
+			defragmentErr:       errors.New("failed to defragment"),
+			wantErr:             true,
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: controlplanev1.EtcdDefragmentationFailedReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "kcp",
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdDefragmentation: tt.etcdDefragmentation,
+				},
+			}
+			if tt.quotaBackendBytes != "" {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{
+						Local: &bootstrapv1.LocalEtcd{
+							ExtraArgs: map[string]string{"quota-backend-bytes": tt.quotaBackendBytes},
+						},
+					},
+				}
+			}
+			if tt.etcdClusterHealthy != nil {
+				conditions.Set(kcp, tt.etcdClusterHealthy)
+			} else {
+				conditions.MarkTrue(kcp, controlplanev1.EtcdClusterHealthyCondition)
+			}
+			if tt.defragmentedCondition != nil {
+				conditions.Set(kcp, tt.defragmentedCondition)
+			}
+
+			defragmented := []string{}
+			disarmed := []etcd.AlarmType{}
+			r := &KubeadmControlPlaneReconciler{
+				Client:   newFakeClient(),
+				recorder: record.NewFakeRecorder(32),
+				managementCluster: &fakeManagementCluster{
+					Workload: fakeWorkloadCluster{
+						EtcdMembersDBStatusResult: tt.members,
+						EtcdMembersDBStatusErr:    tt.membersErr,
+						EtcdDefragmentErr:         tt.defragmentErr,
+						DefragmentedEtcdMembers:   &defragmented,
+						DisarmedEtcdAlarms:        &disarmed,
+					},
+				},
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP:     kcp,
+				Cluster: &clusterv1.Cluster{},
+			}
+			controlPlane.InjectTestManagementCluster(r.managementCluster)
+
+			result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(defragmented).To(ConsistOf(tt.wantDefragmented))
+			g.Expect(disarmed).To(ConsistOf(tt.wantDisarmed))
+
+			if tt.wantConditionStatus == "" {
+				g.Expect(conditions.Has(kcp, controlplanev1.EtcdDefragmentedCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.Get(kcp, controlplanev1.EtcdDefragmentedCondition).Status).To(Equal(tt.wantConditionStatus))
+			g.Expect(conditions.Get(kcp, controlplanev1.EtcdDefragmentedCondition).Reason).To(Equal(tt.wantConditionReason))
+		})
+	}
+}
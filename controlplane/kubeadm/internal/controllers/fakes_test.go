@@ -17,7 +17,9 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -27,6 +29,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/collections"
 )
@@ -70,6 +73,14 @@ type fakeWorkloadCluster struct {
 	*internal.Workload
 	Status                     internal.ClusterStatus
 	EtcdMembersResult          []string
+	EtcdSnapshotData           []byte
+	EtcdSnapshotErr            error
+	EtcdSnapshotHangs          bool
+	EtcdMembersDBStatusResult  []internal.EtcdMemberDBStatus
+	EtcdMembersDBStatusErr     error
+	EtcdDefragmentErr          error
+	DefragmentedEtcdMembers    *[]string
+	DisarmedEtcdAlarms         *[]etcd.AlarmType
 	APIServerCertificateExpiry *time.Time
 }
 
@@ -128,6 +139,51 @@ func (f fakeWorkloadCluster) EtcdMembers(_ context.Context) ([]string, error) {
 	return f.EtcdMembersResult, nil
 }
 
+func (f fakeWorkloadCluster) EtcdSnapshot(ctx context.Context) (io.ReadCloser, error) {
+	if f.EtcdSnapshotErr != nil {
+		return nil, f.EtcdSnapshotErr
+	}
+	if f.EtcdSnapshotHangs {
+		// Simulate a snapshot stream which does not complete until the context is done.
+		return io.NopCloser(ctxReader{ctx: ctx}), nil
+	}
+	return io.NopCloser(bytes.NewReader(f.EtcdSnapshotData)), nil
+}
+
+// ctxReader is a reader blocking until the context is done.
+type ctxReader struct {
+	ctx context.Context
+}
+
+func (r ctxReader) Read(_ []byte) (int, error) {
+	<-r.ctx.Done()
+	return 0, r.ctx.Err()
+}
+
+func (f fakeWorkloadCluster) EtcdMembersDBStatus(_ context.Context) ([]internal.EtcdMemberDBStatus, error) {
+	if f.EtcdMembersDBStatusErr != nil {
+		return nil, f.EtcdMembersDBStatusErr
+	}
+	return f.EtcdMembersDBStatusResult, nil
+}
+
+func (f fakeWorkloadCluster) DefragmentEtcdMember(_ context.Context, nodeName string) error {
+	if f.EtcdDefragmentErr != nil {
+		return f.EtcdDefragmentErr
+	}
+	if f.DefragmentedEtcdMembers != nil {
+		*f.DefragmentedEtcdMembers = append(*f.DefragmentedEtcdMembers, nodeName)
+	}
+	return nil
+}
+
+func (f fakeWorkloadCluster) DisarmEtcdAlarms(_ context.Context, alarmType etcd.AlarmType) error {
+	if f.DisarmedEtcdAlarms != nil {
+		*f.DisarmedEtcdAlarms = append(*f.DisarmedEtcdAlarms, alarmType)
+	}
+	return nil
+}
+
 type fakeMigrator struct {
 	migrateCalled    bool
 	migrateErr       error
@@ -360,6 +360,11 @@ func (r *KubeadmControlPlaneReconciler) computeDesiredMachine(kcp *controlplanev
 		// NOTE: This is required in order to track remediation retries.
 		if remediationData, ok := kcp.Annotations[controlplanev1.RemediationInProgressAnnotation]; ok {
 			annotations[controlplanev1.RemediationForAnnotation] = remediationData
+
+			// Also track the remediated machine for MachineHealthChecks, which record replacements in their remediation history.
+			if data, err := RemediationDataFromAnnotation(remediationData); err == nil {
+				annotations[clusterv1.ReplacementForAnnotation] = data.Machine
+			}
 		}
 	} else {
 		// Updating an existing machine
@@ -379,6 +384,9 @@ func (r *KubeadmControlPlaneReconciler) computeDesiredMachine(kcp *controlplanev
 		if remediationData, ok := existingMachine.Annotations[controlplanev1.RemediationForAnnotation]; ok {
 			annotations[controlplanev1.RemediationForAnnotation] = remediationData
 		}
+		if replacementFor, ok := existingMachine.Annotations[clusterv1.ReplacementForAnnotation]; ok {
+			annotations[clusterv1.ReplacementForAnnotation] = replacementFor
+		}
 	}
 
 	// Construct the basic Machine.
@@ -430,6 +438,7 @@ func (r *KubeadmControlPlaneReconciler) computeDesiredMachine(kcp *controlplanev
 	desiredMachine.Spec.NodeDrainTimeout = kcp.Spec.MachineTemplate.NodeDrainTimeout
 	desiredMachine.Spec.NodeDeletionTimeout = kcp.Spec.MachineTemplate.NodeDeletionTimeout
 	desiredMachine.Spec.NodeVolumeDetachTimeout = kcp.Spec.MachineTemplate.NodeVolumeDetachTimeout
+	desiredMachine.Spec.NodeDrainPolicy = kcp.Spec.MachineTemplate.NodeDrainPolicy
 
 	return desiredMachine, nil
 }
@@ -591,6 +591,7 @@ func TestKubeadmControlPlaneReconciler_computeDesiredMachine(t *testing.T) {
 				Annotations: map[string]string{
 					controlplanev1.KubeadmClusterConfigurationAnnotation: existingClusterConfigurationString,
 					controlplanev1.RemediationForAnnotation:              remediationData,
+					clusterv1.ReplacementForAnnotation:                   "remediated-machine",
 				},
 			},
 			Spec: clusterv1.MachineSpec{
@@ -647,6 +648,7 @@ func TestKubeadmControlPlaneReconciler_computeDesiredMachine(t *testing.T) {
 		}
 		expectedAnnotations[controlplanev1.KubeadmClusterConfigurationAnnotation] = existingClusterConfigurationString
 		expectedAnnotations[controlplanev1.RemediationForAnnotation] = remediationData
+		expectedAnnotations[clusterv1.ReplacementForAnnotation] = "remediated-machine"
 		g.Expect(updatedMachine.Annotations).To(Equal(expectedAnnotations))
 
 		// Verify that machineTemplate.ObjectMeta in KCP has not been modified.
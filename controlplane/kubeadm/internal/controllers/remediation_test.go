@@ -753,6 +753,10 @@ func TestReconcileUnhealthyMachines(t *testing.T) {
 		g.Expect(remediationData.Machine).To(Equal(m1.Name))
 		g.Expect(remediationData.RetryCount).To(Equal(0))
 
+		g.Expect(controlPlane.KCP.Status.RemediationHistory).To(HaveLen(1))
+		g.Expect(controlPlane.KCP.Status.RemediationHistory[0].Machine).To(Equal(m1.Name))
+		g.Expect(controlPlane.KCP.Status.RemediationHistory[0].Reason).To(Equal(clusterv1.MachineHasFailureReason))
+
 		assertMachineCondition(ctx, g, m1, clusterv1.MachineOwnerRemediatedCondition, corev1.ConditionFalse, clusterv1.RemediationInProgressReason, clusterv1.ConditionSeverityWarning, "")
 
 		err = env.Get(ctx, client.ObjectKey{Namespace: m1.Namespace, Name: m1.Name}, m1)
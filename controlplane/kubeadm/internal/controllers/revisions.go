@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+)
+
+// revisionHistoryLimit is the number of revisions of a KubeadmControlPlane, including the current one,
+// kept in the revision history.
+const revisionHistoryLimit = 10
+
+// revisionData is the subset of a KubeadmControlPlane recorded in a revision, i.e. the fields triggering a rollout
+// of the control plane machines; it has the same JSON representation as the corresponding KubeadmControlPlane fields.
+type revisionData struct {
+	Spec revisionSpec `json:"spec"`
+}
+
+type revisionSpec struct {
+	Version           string                        `json:"version"`
+	MachineTemplate   revisionMachineTemplate       `json:"machineTemplate"`
+	KubeadmConfigSpec bootstrapv1.KubeadmConfigSpec `json:"kubeadmConfigSpec"`
+}
+
+type revisionMachineTemplate struct {
+	InfrastructureRef corev1.ObjectReference `json:"infrastructureRef"`
+}
+
+// reconcileRevisionHistory records the Kubernetes version, the infrastructure machine template and the kubeadm config
+// spec of a KubeadmControlPlane as a ControllerRevision, so they can be restored e.g. with `clusterctl alpha rollout undo`.
+// If the current spec matches a previous revision, the previous revision becomes the current revision; the oldest
+// revisions exceeding revisionHistoryLimit are deleted.
+func (r *KubeadmControlPlaneReconciler) reconcileRevisionHistory(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	data, err := json.Marshal(revisionData{
+		Spec: revisionSpec{
+			Version:           kcp.Spec.Version,
+			MachineTemplate:   revisionMachineTemplate{InfrastructureRef: kcp.Spec.MachineTemplate.InfrastructureRef},
+			KubeadmConfigSpec: kcp.Spec.KubeadmConfigSpec,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal revision data")
+	}
+
+	revisions, err := r.getRevisions(ctx, kcp)
+	if err != nil {
+		return err
+	}
+
+	var current *appsv1.ControllerRevision
+	nextRevision := int64(1)
+	for _, revision := range revisions {
+		if bytes.Equal(revision.Data.Raw, data) {
+			current = revision
+		}
+		if revision.Revision >= nextRevision {
+			nextRevision = revision.Revision + 1
+		}
+	}
+
+	switch {
+	case current == nil:
+		hasher := fnv.New32a()
+		_, _ = hasher.Write(data)
+		current = &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", kcp.Name, hasher.Sum32()),
+				Namespace: kcp.Namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:             controlPlane.Cluster.Name,
+					clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(kcp.Name),
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind)),
+				},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: nextRevision,
+		}
+		if err := r.Client.Create(ctx, current); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// The revision has been created by a previous reconcile, but it is not in the cache yet.
+				return nil
+			}
+			return errors.Wrapf(err, "failed to create revision %d", nextRevision)
+		}
+		log.V(4).Info("Created revision", "ControllerRevision", current.Name, "revision", current.Revision)
+		revisions = append(revisions, current)
+	case current.Revision != nextRevision-1:
+		// The spec has been rolled back to a previous revision, which becomes the current revision.
+		patch := client.MergeFrom(current.DeepCopy())
+		current.Revision = nextRevision
+		if err := r.Client.Patch(ctx, current, patch); err != nil {
+			return errors.Wrapf(err, "failed to update revision %s", current.Name)
+		}
+		log.V(4).Info("Updated revision", "ControllerRevision", current.Name, "revision", current.Revision)
+	}
+
+	if len(revisions) <= revisionHistoryLimit {
+		return nil
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	var errs []error
+	for _, revision := range revisions[:len(revisions)-revisionHistoryLimit] {
+		if err := r.Client.Delete(ctx, revision); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete revision %s", revision.Name))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// getRevisions returns the ControllerRevisions recording the revision history of a KubeadmControlPlane.
+func (r *KubeadmControlPlaneReconciler) getRevisions(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]*appsv1.ControllerRevision, error) {
+	revisionList := &appsv1.ControllerRevisionList{}
+	if err := r.Client.List(ctx, revisionList, client.InNamespace(kcp.Namespace), client.MatchingLabels{clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(kcp.Name)}); err != nil {
+		return nil, errors.Wrap(err, "failed to list revisions")
+	}
+
+	revisions := []*appsv1.ControllerRevision{}
+	for i := range revisionList.Items {
+		if metav1.IsControlledBy(&revisionList.Items[i], kcp) {
+			revisions = append(revisions, &revisionList.Items[i])
+		}
+	}
+	return revisions, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+)
+
+func TestKubeadmControlPlaneReconciler_reconcileRevisionHistory(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "cluster",
+		},
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "kcp",
+			UID:       "kcp-uid",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.27.1",
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{
+					Kind: "GenericInfrastructureMachineTemplate",
+					Name: "infra-1",
+				},
+			},
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				Files: []bootstrapv1.File{{Path: "/etc/foo", Content: "foo"}},
+			},
+		},
+	}
+	controlPlane := &internal.ControlPlane{
+		KCP:     kcp,
+		Cluster: cluster,
+	}
+
+	r := &KubeadmControlPlaneReconciler{
+		Client: fake.NewClientBuilder().Build(),
+	}
+
+	// revisions returns the revisions of the KubeadmControlPlane, indexed by revision number.
+	revisions := func() map[int64]*revisionData {
+		revisionList := &appsv1.ControllerRevisionList{}
+		g.Expect(r.Client.List(ctx, revisionList, client.InNamespace(kcp.Namespace))).To(Succeed())
+		ret := map[int64]*revisionData{}
+		for _, revision := range revisionList.Items {
+			g.Expect(metav1.IsControlledBy(&revision, kcp)).To(BeTrue())
+			g.Expect(revision.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, cluster.Name))
+			g.Expect(revision.Labels).To(HaveKeyWithValue(clusterv1.MachineControlPlaneNameLabel, kcp.Name))
+			data := &revisionData{}
+			g.Expect(json.Unmarshal(revision.Data.Raw, data)).To(Succeed())
+			ret[revision.Revision] = data
+		}
+		return ret
+	}
+
+	// Records the first revision.
+	g.Expect(r.reconcileRevisionHistory(ctx, controlPlane)).To(Succeed())
+	g.Expect(revisions()).To(HaveLen(1))
+	g.Expect(revisions()[1].Spec.Version).To(Equal("v1.27.1"))
+	g.Expect(revisions()[1].Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("infra-1"))
+	g.Expect(revisions()[1].Spec.KubeadmConfigSpec).To(Equal(kcp.Spec.KubeadmConfigSpec))
+
+	// Does nothing if the spec did not change.
+	g.Expect(r.reconcileRevisionHistory(ctx, controlPlane)).To(Succeed())
+	g.Expect(revisions()).To(HaveLen(1))
+
+	// Records a new revision if the spec changes.
+	kcp.Spec.Version = "v1.28.0"
+	kcp.Spec.MachineTemplate.InfrastructureRef.Name = "infra-2"
+	g.Expect(r.reconcileRevisionHistory(ctx, controlPlane)).To(Succeed())
+	g.Expect(revisions()).To(HaveLen(2))
+	g.Expect(revisions()[2].Spec.Version).To(Equal("v1.28.0"))
+	g.Expect(revisions()[2].Spec.MachineTemplate.InfrastructureRef.Name).To(Equal("infra-2"))
+
+	// Makes a previous revision the current revision if the spec is rolled back.
+	kcp.Spec.Version = "v1.27.1"
+	kcp.Spec.MachineTemplate.InfrastructureRef.Name = "infra-1"
+	g.Expect(r.reconcileRevisionHistory(ctx, controlPlane)).To(Succeed())
+	g.Expect(revisions()).To(HaveLen(2))
+	g.Expect(revisions()).To(HaveKey(int64(2)))
+	g.Expect(revisions()[3].Spec.Version).To(Equal("v1.27.1"))
+
+	// Deletes the oldest revisions exceeding the revision history limit.
+	for i := 0; i < revisionHistoryLimit; i++ {
+		kcp.Spec.MachineTemplate.InfrastructureRef.Name = fmt.Sprintf("infra-%d", i+3)
+		g.Expect(r.reconcileRevisionHistory(ctx, controlPlane)).To(Succeed())
+	}
+	g.Expect(revisions()).To(HaveLen(revisionHistoryLimit))
+	g.Expect(revisions()).ToNot(HaveKey(int64(3)))
+	g.Expect(revisions()).To(HaveKey(int64(4)))
+	g.Expect(revisions()).To(HaveKey(int64(3 + revisionHistoryLimit)))
+}
@@ -101,6 +101,21 @@ func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 		return ctrl.Result{}, errors.New("failed to pick control plane Machine to delete")
 	}
 
+	// If the control plane is being scaled in place, i.e. the machine is deleted before its replacement is created, the control plane
+	// is going to have less machines than the desired replicas; in this case ensure etcd retains quorum after removing the member.
+	if controlPlane.IsEtcdManaged() && controlPlane.KCP.Spec.Replicas != nil && int32(controlPlane.Machines.Len()) <= *controlPlane.KCP.Spec.Replicas {
+		canSafelyRemoveMember, err := r.canSafelyRemoveEtcdMember(ctx, controlPlane, machineToDelete)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to check if the etcd member can be safely removed")
+		}
+		if !canSafelyRemoveMember {
+			r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "ControlPlaneUnhealthy",
+				"Waiting for etcd to be able to retain quorum before deleting control plane Machine %s", machineToDelete.Name)
+			logger.Info("Waiting for etcd to be able to retain quorum before deleting control plane Machine", "Machine", klog.KObj(machineToDelete))
+			return ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}, nil
+		}
+	}
+
 	// If KCP should manage etcd, If etcd leadership is on machine that is about to be deleted, move it to the newest member available.
 	if controlPlane.IsEtcdManaged() {
 		etcdLeaderCandidate := controlPlane.Machines.Newest()
@@ -20,10 +20,13 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/internal/util/remediation"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
@@ -31,6 +34,8 @@ import (
 // updateStatus is called after every reconcilitation loop in a defer statement to always make sure we have the
 // resource status subresourcs up-to-date.
 func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	log := ctrl.LoggerFrom(ctx)
+
 	selector := collections.ControlPlaneSelectorForCluster(controlPlane.Cluster.Name)
 	// Copy label selector to its status counterpart in string format.
 	// This is necessary for CRDs including scale subresources.
@@ -118,7 +123,8 @@ func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, contro
 			if v, ok := m.Annotations[controlplanev1.RemediationForAnnotation]; ok {
 				remediationData, err := RemediationDataFromAnnotation(v)
 				if err != nil {
-					return err
+					log.Error(err, "Failed to get the remediation data of a Machine", "Machine", klog.KObj(m))
+					continue
 				}
 				if lastRemediation == nil || lastRemediation.Timestamp.Time.Before(remediationData.Timestamp.Time) {
 					lastRemediation = remediationData
@@ -130,5 +136,18 @@ func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, contro
 	if lastRemediation != nil {
 		controlPlane.KCP.Status.LastRemediation = lastRemediation.ToStatus()
 	}
+
+	// Surface the Machines created as a replacement of remediated Machines in the remediation history.
+	// NOTE: Invalid remediation data on Machines is logged and skipped, so it doesn't block the status update.
+	for _, m := range controlPlane.Machines.UnsortedList() {
+		if v, ok := m.Annotations[controlplanev1.RemediationForAnnotation]; ok {
+			remediationData, err := RemediationDataFromAnnotation(v)
+			if err != nil {
+				log.Error(err, "Failed to get the remediation data of a Machine", "Machine", klog.KObj(m))
+				continue
+			}
+			remediation.SetReplacedBy(controlPlane.KCP.Status.RemediationHistory, remediationData.Machine, m.Name)
+		}
+	}
 	return nil
 }
@@ -120,7 +120,9 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 	switch controlPlane.KCP.Spec.RolloutStrategy.Type {
 	case controlplanev1.RollingUpdateStrategyType:
 		// RolloutStrategy is currently defaulted and validated to be RollingUpdate
-		// We can ignore MaxUnavailable because we are enforcing health checks before we get here.
+		// NOTE: MaxSurge and MaxUnavailable are validated so exactly one of them is 1; when MaxSurge is 0, the control plane
+		// is scaled in place, i.e. an outdated machine is deleted before creating its replacement, and scaleDownControlPlane
+		// ensures etcd retains quorum while the control plane has less machines than the desired replicas.
 		maxNodes := *controlPlane.KCP.Spec.Replicas + int32(controlPlane.KCP.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntValue())
 		if int32(controlPlane.Machines.Len()) < maxNodes {
 			// scaleUp ensures that we don't continue scaling up while waiting for Machines to have NodeRefs
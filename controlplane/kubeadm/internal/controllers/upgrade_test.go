@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -188,12 +189,14 @@ func TestKubeadmControlPlaneReconciler_RolloutStrategy_ScaleDown(t *testing.T) {
 	cluster.Spec.ControlPlaneEndpoint.Port = 6443
 	kcp.Spec.Replicas = pointer.Int32(3)
 	kcp.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntVal = 0
+	kcp.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = &intstr.IntOrString{IntVal: 1}
 	setKCPHealthy(kcp)
 
 	fmc := &fakeManagementCluster{
 		Machines: collections.Machines{},
 		Workload: fakeWorkloadCluster{
-			Status: internal.ClusterStatus{Nodes: 3},
+			Status:            internal.ClusterStatus{Nodes: 3},
+			EtcdMembersResult: []string{"test-0", "test-1", "test-2"},
 		},
 	}
 	objs := []client.Object{builder.GenericInfrastructureMachineTemplateCRD, cluster.DeepCopy(), kcp.DeepCopy(), tmpl.DeepCopy()}
@@ -228,6 +231,7 @@ func TestKubeadmControlPlaneReconciler_RolloutStrategy_ScaleDown(t *testing.T) {
 	fakeClient := newFakeClient(objs...)
 	fmc.Reader = fakeClient
 	r := &KubeadmControlPlaneReconciler{
+		recorder:                  record.NewFakeRecorder(32),
 		Client:                    fakeClient,
 		SecretCachingClient:       fakeClient,
 		managementCluster:         fmc,
@@ -250,6 +254,7 @@ func TestKubeadmControlPlaneReconciler_RolloutStrategy_ScaleDown(t *testing.T) {
 	g.Expect(machineList.Items).To(HaveLen(3))
 	for i := range machineList.Items {
 		setMachineHealthy(&machineList.Items[i])
+		machineList.Items[i].Status.NodeRef.Name = machineList.Items[i].Name
 	}
 
 	// change the KCP spec so the machine becomes outdated
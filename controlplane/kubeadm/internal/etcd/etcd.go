@@ -19,6 +19,7 @@ package etcd
 import (
 	"context"
 	"crypto/tls"
+	"io"
 	"net"
 	"time"
 
@@ -37,13 +38,16 @@ type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context) (*clientv3.MemberListResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
 	MemberUpdate(ctx context.Context, id uint64, peerURLs []string) (*clientv3.MemberUpdateResponse, error)
 	MoveLeader(ctx context.Context, id uint64) (*clientv3.MoveLeaderResponse, error)
+	Snapshot(ctx context.Context) (io.ReadCloser, error)
 	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
 }
 
@@ -52,6 +56,9 @@ type Client struct {
 	EtcdClient  etcd
 	Endpoint    string
 	LeaderID    uint64
+	MemberID    uint64
+	DBSize      int64
+	DBSizeInUse int64
 	Errors      []string
 	CallTimeout time.Duration
 }
@@ -83,6 +90,10 @@ const (
 // for read and write operations to etcd.
 const DefaultCallTimeout = 15 * time.Second
 
+// DefragmentTimeout represents the duration that the etcd client waits at most
+// for the defragmentation of an etcd member, which is considerably slower than other operations.
+const DefragmentTimeout = 5 * time.Minute
+
 // AlarmTypeName provides a text translation for AlarmType codes.
 var AlarmTypeName = map[AlarmType]string{
 	AlarmOK:      "NONE",
@@ -189,6 +200,9 @@ func newEtcdClient(ctx context.Context, etcdClient etcd, callTimeout time.Durati
 		Endpoint:    endpoints[0],
 		EtcdClient:  etcdClient,
 		LeaderID:    status.Leader,
+		MemberID:    status.Header.GetMemberId(),
+		DBSize:      status.DbSize,
+		DBSizeInUse: status.DbSizeInUse,
 		Errors:      status.Errors,
 		CallTimeout: callTimeout,
 	}, nil
@@ -248,6 +262,26 @@ func (c *Client) RemoveMember(ctx context.Context, id uint64) error {
 	return errors.Wrapf(err, "failed to remove member: %v", id)
 }
 
+// Snapshot returns a reader streaming a point-in-time snapshot of the etcd backend database.
+// NOTE: The call timeout is not applied, given that the time required for reading the snapshot depends on the size of the database.
+func (c *Client) Snapshot(ctx context.Context) (io.ReadCloser, error) {
+	snapshot, err := c.EtcdClient.Snapshot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get snapshot for etcd cluster")
+	}
+	return snapshot, nil
+}
+
+// Defragment defragments the backend database of the etcd member the client is connected to.
+// NOTE: The member cannot serve requests while it is being defragmented.
+func (c *Client) Defragment(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, DefragmentTimeout)
+	defer cancel()
+
+	_, err := c.EtcdClient.Defragment(ctx, c.Endpoint)
+	return errors.Wrapf(err, "failed to defragment etcd member: %v", c.MemberID)
+}
+
 // UpdateMemberPeerURLs updates the list of peer URLs.
 func (c *Client) UpdateMemberPeerURLs(ctx context.Context, id uint64, peerURLs []string) ([]*Member, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.CallTimeout)
@@ -286,3 +320,15 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// DisarmAlarm disarms an alarm raised on a cluster member.
+func (c *Client) DisarmAlarm(ctx context.Context, alarm MemberAlarm) error {
+	ctx, cancel := context.WithTimeout(ctx, c.CallTimeout)
+	defer cancel()
+
+	_, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: alarm.MemberID,
+		Alarm:    etcdserverpb.AlarmType(alarm.Type),
+	})
+	return errors.Wrapf(err, "failed to disarm %s alarm for etcd member: %v", AlarmTypeName[alarm.Type], alarm.MemberID)
+}
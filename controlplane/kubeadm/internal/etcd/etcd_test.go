@@ -17,6 +17,7 @@ limitations under the License.
 package etcd
 
 import (
+	"io"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -61,6 +62,15 @@ func TestEtcdMembers_WithErrors(t *testing.T) {
 
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).To(HaveOccurred())
+
+	_, err = client.Snapshot(ctx)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.Defragment(ctx)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})
+	g.Expect(err).To(HaveOccurred())
 }
 
 func TestEtcdMembers_WithSuccess(t *testing.T) {
@@ -83,7 +93,13 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 		},
 		MemberRemoveResponse: &clientv3.MemberRemoveResponse{},
 		AlarmResponse:        &clientv3.AlarmResponse{},
-		StatusResponse:       &clientv3.StatusResponse{},
+		DefragmentResponse:   &clientv3.DefragmentResponse{},
+		StatusResponse: &clientv3.StatusResponse{
+			Header:      &etcdserverpb.ResponseHeader{MemberId: 1234},
+			DbSize:      2048,
+			DbSizeInUse: 1024,
+		},
+		SnapshotData: []byte("snapshot"),
 	}
 
 	client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
@@ -103,4 +119,21 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(updatedMembers[0].PeerURLs).To(HaveLen(2))
 	g.Expect(updatedMembers[0].PeerURLs).To(Equal([]string{"https://1.2.3.4:2000", "https://4.5.6.7:2000"}))
+
+	snapshot, err := client.Snapshot(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer snapshot.Close()
+	g.Expect(io.ReadAll(snapshot)).To(Equal([]byte("snapshot")))
+
+	g.Expect(client.MemberID).To(Equal(uint64(1234)))
+	g.Expect(client.DBSize).To(Equal(int64(2048)))
+	g.Expect(client.DBSizeInUse).To(Equal(int64(1024)))
+
+	err = client.Defragment(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeEtcdClient.DefragmentedEndpoint).To(Equal("https://etcd-instance:2379"))
+
+	err = client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeEtcdClient.DisarmedAlarm).To(Equal(&clientv3.AlarmMember{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}))
 }
@@ -0,0 +1,282 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+)
+
+const (
+	// defaultSecretChunkSize is the max size of the snapshot data stored in a single Secret; it is lower than
+	// the 1MiB size limit of Secrets in order to leave room for the Secret metadata.
+	defaultSecretChunkSize = 768 * 1024
+
+	// snapshotDataKey is the key of the Secret data storing a chunk of a snapshot.
+	snapshotDataKey = "snapshot"
+
+	// snapshotChunkAnnotation is the index of the chunk of a snapshot stored in a Secret.
+	snapshotChunkAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-chunk"
+
+	// snapshotTimestampAnnotation is the time when a snapshot was taken, in RFC3339 form.
+	snapshotTimestampAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-timestamp"
+
+	// snapshotChunksAnnotation is the number of chunks of a snapshot.
+	// NOTE: This annotation is set on the last chunk only, and thus it signals that all the chunks are stored.
+	snapshotChunksAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-chunks"
+
+	// snapshotSizeAnnotation is the size of a snapshot in bytes; it is set on the last chunk only.
+	snapshotSizeAnnotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-size"
+
+	// snapshotSHA256Annotation is the sha256 checksum of a snapshot; it is set on the last chunk only.
+	snapshotSHA256Annotation = "controlplane.cluster.x-k8s.io/etcd-snapshot-sha256"
+)
+
+// secretStore stores etcd snapshots in Secrets in the namespace of the KubeadmControlPlane; given the size limit
+// of Secrets, each snapshot is split into chunks stored in separate Secrets.
+type secretStore struct {
+	client     client.Client
+	namePrefix string
+	chunkSize  int
+}
+
+var _ Store = &secretStore{}
+
+// Save stores a snapshot in Secrets; if storing any of the chunks fails, the chunks already stored are deleted.
+func (s *secretStore) Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshot *Snapshot, data io.Reader) error {
+	hash := sha256.New()
+	size := int64(0)
+	buf := make([]byte, s.chunkSize)
+	for chunk := 0; ; chunk++ {
+		n, err := io.ReadFull(data, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return s.cleanup(ctx, kcp, snapshot.Name, errors.Wrapf(err, "failed to read etcd snapshot %s", snapshot.Name))
+		}
+		last := err != nil
+		hash.Write(buf[:n])
+		size += int64(n)
+
+		secret := s.newSecret(kcp, snapshot, chunk, buf[:n])
+		if last {
+			secret.Annotations[snapshotChunksAnnotation] = strconv.Itoa(chunk + 1)
+			secret.Annotations[snapshotSizeAnnotation] = strconv.FormatInt(size, 10)
+			secret.Annotations[snapshotSHA256Annotation] = hex.EncodeToString(hash.Sum(nil))
+		}
+		if err := s.client.Create(ctx, secret); err != nil {
+			return s.cleanup(ctx, kcp, snapshot.Name, errors.Wrapf(err, "failed to create Secret %s for etcd snapshot %s", secret.Name, snapshot.Name))
+		}
+
+		if last {
+			break
+		}
+	}
+
+	snapshot.Size = size
+	return nil
+}
+
+// cleanup deletes the chunks of a snapshot after a failure while saving it.
+func (s *secretStore) cleanup(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string, err error) error {
+	if deleteErr := s.Delete(ctx, kcp, name); deleteErr != nil {
+		return kerrors.NewAggregate([]error{err, deleteErr})
+	}
+	return err
+}
+
+// Load writes the content of a snapshot to w, verifying that all the chunks are stored and that the checksum matches.
+func (s *secretStore) Load(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string, w io.Writer) error {
+	secrets, err := s.listSecrets(ctx, kcp, name)
+	if err != nil {
+		return err
+	}
+	chunks, err := sortChunks(secrets)
+	if err != nil {
+		return errors.Wrapf(err, "invalid etcd snapshot %s", name)
+	}
+	last := chunks[len(chunks)-1]
+	if last.Annotations[snapshotChunksAnnotation] != strconv.Itoa(len(chunks)) {
+		return errors.Errorf("etcd snapshot %s is incomplete", name)
+	}
+
+	// NOTE: Chunks are read one at a time, so only a chunk of the snapshot is kept in memory.
+	hash := sha256.New()
+	for _, chunk := range chunks {
+		secret := &corev1.Secret{}
+		if err := s.client.Get(ctx, client.ObjectKey{Namespace: chunk.Namespace, Name: chunk.Name}, secret); err != nil {
+			return errors.Wrapf(err, "failed to get Secret %s for etcd snapshot %s", chunk.Name, name)
+		}
+		if _, err := io.MultiWriter(w, hash).Write(secret.Data[snapshotDataKey]); err != nil {
+			return errors.Wrapf(err, "failed to write etcd snapshot %s", name)
+		}
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != last.Annotations[snapshotSHA256Annotation] {
+		return errors.Errorf("checksum mismatch for etcd snapshot %s: expected sha256 %s, got %s", name, last.Annotations[snapshotSHA256Annotation], checksum)
+	}
+	return nil
+}
+
+// List returns the snapshots stored in Secrets; snapshots not completely stored are ignored.
+func (s *secretStore) List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]Snapshot, error) {
+	secrets, err := s.listSecrets(ctx, kcp, "")
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := map[string]int{}
+	for i := range secrets {
+		chunks[secrets[i].Labels[controlplanev1.EtcdSnapshotLabel]]++
+	}
+
+	snapshots := []Snapshot{}
+	for i := range secrets {
+		secret := secrets[i]
+		if secret.Annotations[snapshotChunksAnnotation] == "" {
+			continue
+		}
+		name := secret.Labels[controlplanev1.EtcdSnapshotLabel]
+		if secret.Annotations[snapshotChunksAnnotation] != strconv.Itoa(chunks[name]) {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, secret.Annotations[snapshotTimestampAnnotation])
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse timestamp of etcd snapshot %s", name)
+		}
+		size, err := strconv.ParseInt(secret.Annotations[snapshotSizeAnnotation], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse size of etcd snapshot %s", name)
+		}
+		snapshots = append(snapshots, Snapshot{Name: name, Timestamp: timestamp, Size: size})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if !snapshots[i].Timestamp.Equal(snapshots[j].Timestamp) {
+			return snapshots[i].Timestamp.Before(snapshots[j].Timestamp)
+		}
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots, nil
+}
+
+// Delete deletes all the Secrets storing chunks of a snapshot.
+func (s *secretStore) Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error {
+	secrets, err := s.listSecrets(ctx, kcp, name)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for i := range secrets {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: secrets[i].Namespace,
+				Name:      secrets[i].Name,
+			},
+		}
+		if err := s.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete Secret %s for etcd snapshot %s", secrets[i].Name, name))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// listSecrets returns the metadata of the Secrets storing snapshots for a KubeadmControlPlane; if name is set, only
+// the Secrets storing the corresponding snapshot are returned.
+// NOTE: Only metadata is listed to avoid loading the content of all the snapshots in memory.
+func (s *secretStore) listSecrets(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) ([]metav1.PartialObjectMetadata, error) {
+	listOptions := []client.ListOption{
+		client.InNamespace(kcp.Namespace),
+		client.MatchingLabels{clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(kcp.Name)},
+	}
+	if name != "" {
+		listOptions = append(listOptions, client.MatchingLabels{controlplanev1.EtcdSnapshotLabel: name})
+	} else {
+		listOptions = append(listOptions, client.HasLabels{controlplanev1.EtcdSnapshotLabel})
+	}
+
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := s.client.List(ctx, secrets, listOptions...); err != nil {
+		return nil, errors.Wrap(err, "failed to list Secrets for etcd snapshots")
+	}
+	return secrets.Items, nil
+}
+
+func (s *secretStore) newSecret(kcp *controlplanev1.KubeadmControlPlane, snapshot *Snapshot, chunk int, data []byte) *corev1.Secret {
+	namePrefix := s.namePrefix
+	if namePrefix == "" {
+		namePrefix = fmt.Sprintf("%s-etcd-snapshot", kcp.Name)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-%d", namePrefix, snapshot.Name, chunk),
+			Namespace: kcp.Namespace,
+			Labels: map[string]string{
+				clusterv1.MachineControlPlaneNameLabel: format.MustFormatValue(kcp.Name),
+				controlplanev1.EtcdSnapshotLabel:       snapshot.Name,
+			},
+			Annotations: map[string]string{
+				snapshotChunkAnnotation:     strconv.Itoa(chunk),
+				snapshotTimestampAnnotation: snapshot.Timestamp.UTC().Format(time.RFC3339),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane")),
+			},
+		},
+		Type: clusterv1.ClusterSecretType,
+		Data: map[string][]byte{
+			snapshotDataKey: bytes.Clone(data),
+		},
+	}
+}
+
+// sortChunks returns the Secrets storing the chunks of a snapshot sorted by chunk index, checking no chunks are missing.
+func sortChunks(secrets []metav1.PartialObjectMetadata) ([]metav1.PartialObjectMetadata, error) {
+	if len(secrets) == 0 {
+		return nil, errors.New("no chunks found")
+	}
+
+	chunks := make([]metav1.PartialObjectMetadata, len(secrets))
+	found := make([]bool, len(secrets))
+	for i := range secrets {
+		chunk, err := strconv.Atoi(secrets[i].Annotations[snapshotChunkAnnotation])
+		if err != nil || chunk < 0 || chunk >= len(secrets) || found[chunk] {
+			return nil, errors.Errorf("invalid chunk index %q in Secret %s", secrets[i].Annotations[snapshotChunkAnnotation], secrets[i].Name)
+		}
+		chunks[chunk] = secrets[i]
+		found[chunk] = true
+	}
+	return chunks, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+func newTestKCP() *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "kcp",
+			UID:       "kcp-uid",
+		},
+	}
+}
+
+func TestSecretStore_SaveAndLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		wantChunks int
+	}{
+		{
+			name:       "snapshot smaller than a chunk",
+			data:       []byte("sna"),
+			wantChunks: 1,
+		},
+		{
+			name:       "snapshot split into multiple chunks",
+			data:       []byte("snapshot-data"),
+			wantChunks: 4,
+		},
+		{
+			name:       "snapshot with size multiple of the chunk size",
+			data:       []byte("snapshot"),
+			wantChunks: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+
+			kcp := newTestKCP()
+			c := fake.NewClientBuilder().Build()
+			store := &secretStore{client: c, chunkSize: 4}
+
+			snapshot := &Snapshot{Name: "20230102030405", Timestamp: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)}
+			g.Expect(store.Save(ctx, kcp, snapshot, bytes.NewReader(tt.data))).To(Succeed())
+			g.Expect(snapshot.Size).To(Equal(int64(len(tt.data))))
+
+			secrets := &corev1.SecretList{}
+			g.Expect(c.List(ctx, secrets)).To(Succeed())
+			g.Expect(secrets.Items).To(HaveLen(tt.wantChunks))
+			for _, s := range secrets.Items {
+				g.Expect(s.Name).To(HavePrefix("kcp-etcd-snapshot-20230102030405-"))
+				g.Expect(s.Labels).To(HaveKeyWithValue(controlplanev1.EtcdSnapshotLabel, "20230102030405"))
+				g.Expect(s.OwnerReferences).To(HaveLen(1))
+				g.Expect(s.OwnerReferences[0].UID).To(Equal(kcp.UID))
+			}
+
+			snapshots, err := store.List(ctx, kcp)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(snapshots).To(HaveLen(1))
+			g.Expect(snapshots[0].Name).To(Equal(snapshot.Name))
+			g.Expect(snapshots[0].Timestamp.Equal(snapshot.Timestamp)).To(BeTrue())
+			g.Expect(snapshots[0].Size).To(Equal(int64(len(tt.data))))
+
+			loaded := &bytes.Buffer{}
+			g.Expect(store.Load(ctx, kcp, snapshot.Name, loaded)).To(Succeed())
+			g.Expect(loaded.Bytes()).To(Equal(tt.data))
+		})
+	}
+}
+
+func TestSecretStore_Load(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	kcp := newTestKCP()
+	c := fake.NewClientBuilder().Build()
+	store := &secretStore{client: c, namePrefix: "backup", chunkSize: 4}
+
+	snapshot := &Snapshot{Name: "snap", Timestamp: time.Now()}
+	g.Expect(store.Save(ctx, kcp, snapshot, bytes.NewReader([]byte("snapshot-data")))).To(Succeed())
+
+	// Fails if the snapshot does not exist.
+	g.Expect(store.Load(ctx, kcp, "does-not-exist", &bytes.Buffer{})).ToNot(Succeed())
+
+	// Fails if the content of a chunk is changed.
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: "backup-snap-1"}, secret)).To(Succeed())
+	secret.Data[snapshotDataKey] = []byte("XXXX")
+	g.Expect(c.Update(ctx, secret)).To(Succeed())
+	g.Expect(store.Load(ctx, kcp, "snap", &bytes.Buffer{})).ToNot(Succeed())
+
+	// Fails if a chunk is missing.
+	g.Expect(c.Delete(ctx, secret)).To(Succeed())
+	g.Expect(store.Load(ctx, kcp, "snap", &bytes.Buffer{})).ToNot(Succeed())
+}
+
+func TestSecretStore_ListAndDelete(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	kcp := newTestKCP()
+	otherKCP := newTestKCP()
+	otherKCP.Name = "other-kcp"
+	c := fake.NewClientBuilder().Build()
+	store := &secretStore{client: c, chunkSize: 4}
+
+	now := time.Now().Truncate(time.Second)
+	g.Expect(store.Save(ctx, kcp, &Snapshot{Name: "new", Timestamp: now}, bytes.NewReader([]byte("snapshot")))).To(Succeed())
+	g.Expect(store.Save(ctx, kcp, &Snapshot{Name: "old", Timestamp: now.Add(-time.Hour)}, bytes.NewReader([]byte("snapshot")))).To(Succeed())
+	g.Expect(store.Save(ctx, otherKCP, &Snapshot{Name: "other", Timestamp: now}, bytes.NewReader([]byte("snapshot")))).To(Succeed())
+
+	// Add a chunk of an incomplete snapshot, which should be ignored.
+	g.Expect(c.Create(ctx, store.newSecret(kcp, &Snapshot{Name: "incomplete", Timestamp: now}, 0, []byte("snap")))).To(Succeed())
+
+	snapshots, err := store.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(HaveLen(2))
+	g.Expect(snapshots[0].Name).To(Equal("old"))
+	g.Expect(snapshots[1].Name).To(Equal("new"))
+
+	g.Expect(store.Delete(ctx, kcp, "old")).To(Succeed())
+	snapshots, err = store.List(ctx, kcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(HaveLen(1))
+	g.Expect(snapshots[0].Name).To(Equal("new"))
+
+	snapshots, err = store.List(ctx, otherKCP)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(HaveLen(1))
+	g.Expect(snapshots[0].Name).To(Equal("other"))
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package etcdbackup implements the storage of etcd snapshots taken by the Kubeadm Control Plane.
+package etcdbackup
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+// Snapshot describes an etcd snapshot stored in a Store.
+type Snapshot struct {
+	// Name is the name identifying the snapshot in the Store.
+	Name string
+
+	// Timestamp is when the snapshot was taken.
+	Timestamp time.Time
+
+	// Size is the size of the snapshot in bytes.
+	Size int64
+}
+
+// Store stores etcd snapshots for a KubeadmControlPlane.
+type Store interface {
+	// Save stores a snapshot reading its content from data; the size of the snapshot is set after the content is stored.
+	Save(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, snapshot *Snapshot, data io.Reader) error
+
+	// Load writes the content of a snapshot to w.
+	Load(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string, w io.Writer) error
+
+	// List returns the snapshots stored for a KubeadmControlPlane, sorted from the oldest to the newest.
+	List(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) ([]Snapshot, error)
+
+	// Delete deletes a snapshot.
+	Delete(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, name string) error
+}
+
+// NewStore returns the Store for a sink defined in the etcd backup configuration of a KubeadmControlPlane.
+func NewStore(c client.Client, sink controlplanev1.EtcdBackupSink) (Store, error) {
+	if sink.Secret != nil {
+		return &secretStore{
+			client:     c,
+			namePrefix: sink.Secret.NamePrefix,
+			chunkSize:  defaultSecretChunkSize,
+		}, nil
+	}
+	return nil, errors.New("etcd backup sink is not defined")
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/coredns/corefile-migration/migration"
@@ -152,7 +153,10 @@ const (
 	diskSetup            = "diskSetup"
 )
 
-const minimumCertificatesExpiryDays = 7
+const (
+	minimumCertificatesExpiryDays = 7
+	minimumEtcdBackupInterval     = time.Minute
+)
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -203,6 +207,8 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, "machineTemplate", "nodeDrainTimeout"},
 		{spec, "machineTemplate", "nodeVolumeDetachTimeout"},
 		{spec, "machineTemplate", "nodeDeletionTimeout"},
+		{spec, "machineTemplate", "nodeDrainPolicy"},
+		{spec, "machineTemplate", "nodeDrainPolicy", "*"},
 		{spec, "replicas"},
 		{spec, "version"},
 		{spec, "remediationStrategy"},
@@ -212,6 +218,10 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, "rolloutBefore", "*"},
 		{spec, "rolloutStrategy"},
 		{spec, "rolloutStrategy", "*"},
+		{spec, "etcdBackup"},
+		{spec, "etcdBackup", "*"},
+		{spec, "etcdDefragmentation"},
+		{spec, "etcdDefragmentation", "*"},
 	}
 
 	oldK, ok := oldObj.(*controlplanev1.KubeadmControlPlane)
@@ -359,12 +369,17 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, n
 	// Validate the metadata of the MachineTemplate
 	allErrs = append(allErrs, s.MachineTemplate.ObjectMeta.Validate(pathPrefix.Child("machineTemplate", "metadata"))...)
 
+	// Validate the node drain policy of the MachineTemplate
+	allErrs = append(allErrs, s.MachineTemplate.NodeDrainPolicy.Validate(pathPrefix.Child("machineTemplate", "nodeDrainPolicy"))...)
+
 	if !version.KubeSemver.MatchString(s.Version) {
 		allErrs = append(allErrs, field.Invalid(pathPrefix.Child("version"), s.Version, "must be a valid semantic version"))
 	}
 
 	allErrs = append(allErrs, validateRolloutBefore(s.RolloutBefore, pathPrefix.Child("rolloutBefore"))...)
 	allErrs = append(allErrs, validateRolloutStrategy(s.RolloutStrategy, s.Replicas, pathPrefix.Child("rolloutStrategy"))...)
+	allErrs = append(allErrs, validateEtcdBackup(s.EtcdBackup, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("etcdBackup"))...)
+	allErrs = append(allErrs, validateEtcdDefragmentation(s.EtcdDefragmentation, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("etcdDefragmentation"))...)
 
 	return allErrs
 }
@@ -405,7 +420,13 @@ func validateRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy, re
 	ios1 := intstr.FromInt(1)
 	ios0 := intstr.FromInt(0)
 
-	if rolloutStrategy.RollingUpdate.MaxSurge.IntValue() == ios0.IntValue() && (replicas != nil && *replicas < int32(3)) {
+	// NOTE: MaxUnavailable is not defaulted, because its default value depends on MaxSurge; when MaxSurge is 0
+	// the control plane is always scaled in before creating replacement machines.
+	maxUnavailable := rolloutStrategy.RollingUpdate.MaxUnavailable
+	scaleIn := rolloutStrategy.RollingUpdate.MaxSurge.IntValue() == ios0.IntValue() ||
+		(maxUnavailable != nil && maxUnavailable.IntValue() == ios1.IntValue())
+
+	if scaleIn && (replicas != nil && *replicas < int32(3)) {
 		allErrs = append(
 			allErrs,
 			field.Required(
@@ -425,6 +446,101 @@ func validateRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy, re
 		)
 	}
 
+	if maxUnavailable != nil {
+		if maxUnavailable.IntValue() != ios1.IntValue() && maxUnavailable.IntValue() != ios0.IntValue() {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					pathPrefix.Child("rollingUpdate", "maxUnavailable"),
+					"value must be 1 or 0",
+				),
+			)
+		}
+
+		if maxUnavailable.IntValue() == rolloutStrategy.RollingUpdate.MaxSurge.IntValue() {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("rollingUpdate", "maxUnavailable"),
+					maxUnavailable.String(),
+					"exactly one of maxSurge and maxUnavailable must be 1",
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+func validateEtcdBackup(etcdBackup *controlplanev1.EtcdBackup, clusterConfiguration *bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if etcdBackup == nil {
+		return allErrs
+	}
+
+	if clusterConfiguration != nil && clusterConfiguration.Etcd.External != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix,
+				"cannot be set when using an external etcd cluster",
+			),
+		)
+	}
+
+	if etcdBackup.Interval.Duration < minimumEtcdBackupInterval {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("interval"),
+				etcdBackup.Interval.Duration.String(),
+				fmt.Sprintf("must be greater than or equal to %s", minimumEtcdBackupInterval),
+			),
+		)
+	}
+
+	if etcdBackup.Sink.Secret == nil {
+		allErrs = append(
+			allErrs,
+			field.Required(
+				pathPrefix.Child("sink"),
+				"exactly one sink must be set",
+			),
+		)
+	}
+
+	return allErrs
+}
+
+func validateEtcdDefragmentation(etcdDefragmentation *controlplanev1.EtcdDefragmentation, clusterConfiguration *bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if etcdDefragmentation == nil {
+		return allErrs
+	}
+
+	if clusterConfiguration != nil && clusterConfiguration.Etcd.External != nil {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				pathPrefix,
+				"cannot be set when using an external etcd cluster",
+			),
+		)
+	}
+
+	if etcdDefragmentation.DBSizeThreshold != nil && etcdDefragmentation.DBSizeThreshold.Sign() <= 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("dbSizeThreshold"),
+				etcdDefragmentation.DBSizeThreshold.String(),
+				"must be greater than 0",
+			),
+		)
+	}
+
 	return allErrs
 }
 
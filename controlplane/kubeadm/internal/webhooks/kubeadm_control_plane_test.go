@@ -23,6 +23,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -116,6 +117,23 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	val := intstr.FromString("1")
 	stringMaxSurge.Spec.RolloutStrategy.RollingUpdate.MaxSurge = &val
 
+	scaleInPlace := valid.DeepCopy()
+	scaleInPlace.Spec.Replicas = pointer.Int32(3)
+	scaleInPlace.Spec.RolloutStrategy.RollingUpdate.MaxSurge = &intstr.IntOrString{IntVal: 0}
+	scaleInPlace.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = &intstr.IntOrString{IntVal: 1}
+
+	scaleInPlaceWithOneReplica := scaleInPlace.DeepCopy()
+	scaleInPlaceWithOneReplica.Spec.Replicas = pointer.Int32(1)
+
+	invalidMaxUnavailable := scaleInPlace.DeepCopy()
+	invalidMaxUnavailable.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable.IntVal = int32(2)
+
+	bothMaxSurgeAndMaxUnavailable := scaleInPlace.DeepCopy()
+	bothMaxSurgeAndMaxUnavailable.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntVal = int32(1)
+
+	neitherMaxSurgeNorMaxUnavailable := scaleInPlace.DeepCopy()
+	neitherMaxSurgeNorMaxUnavailable.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable.IntVal = int32(0)
+
 	invalidNamespace := valid.DeepCopy()
 	invalidNamespace.Spec.MachineTemplate.InfrastructureRef.Namespace = invalidNamespaceName
 
@@ -154,6 +172,42 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 		CertificatesExpiryDays: pointer.Int32(5), // less than minimum
 	}
 
+	validEtcdBackup := valid.DeepCopy()
+	validEtcdBackup.Spec.EtcdBackup = &controlplanev1.EtcdBackup{
+		Interval: metav1.Duration{Duration: time.Hour},
+		Sink: controlplanev1.EtcdBackupSink{
+			Secret: &controlplanev1.EtcdBackupSecretSink{},
+		},
+	}
+
+	invalidEtcdBackupInterval := validEtcdBackup.DeepCopy()
+	invalidEtcdBackupInterval.Spec.EtcdBackup.Interval = metav1.Duration{Duration: 30 * time.Second}
+
+	invalidEtcdBackupSink := validEtcdBackup.DeepCopy()
+	invalidEtcdBackupSink.Spec.EtcdBackup.Sink = controlplanev1.EtcdBackupSink{}
+
+	invalidEtcdBackupExternalEtcd := validEtcdBackup.DeepCopy()
+	invalidEtcdBackupExternalEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd = bootstrapv1.Etcd{
+		External: &bootstrapv1.ExternalEtcd{},
+	}
+
+	dbSizeThreshold := resource.MustParse("2Gi")
+	validEtcdDefragmentation := valid.DeepCopy()
+	validEtcdDefragmentation.Spec.EtcdDefragmentation = &controlplanev1.EtcdDefragmentation{
+		DBSizeThreshold: &dbSizeThreshold,
+	}
+
+	zeroDBSizeThreshold := resource.MustParse("0")
+	invalidEtcdDefragmentationThreshold := valid.DeepCopy()
+	invalidEtcdDefragmentationThreshold.Spec.EtcdDefragmentation = &controlplanev1.EtcdDefragmentation{
+		DBSizeThreshold: &zeroDBSizeThreshold,
+	}
+
+	invalidEtcdDefragmentationExternalEtcd := validEtcdDefragmentation.DeepCopy()
+	invalidEtcdDefragmentationExternalEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd = bootstrapv1.Etcd{
+		External: &bootstrapv1.ExternalEtcd{},
+	}
+
 	invalidIgnitionConfiguration := valid.DeepCopy()
 	invalidIgnitionConfiguration.Spec.KubeadmConfigSpec.Ignition = &bootstrapv1.IgnitionSpec{}
 
@@ -237,11 +291,71 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: false,
 			kcp:       stringMaxSurge,
 		},
+		{
+			name:      "should succeed when scaling in place with maxSurge 0 and maxUnavailable 1",
+			expectErr: false,
+			kcp:       scaleInPlace,
+		},
+		{
+			name:      "should return error when scaling in place with replica count < 3",
+			expectErr: true,
+			kcp:       scaleInPlaceWithOneReplica,
+		},
+		{
+			name:      "should return error when maxUnavailable is not 0 or 1",
+			expectErr: true,
+			kcp:       invalidMaxUnavailable,
+		},
+		{
+			name:      "should return error when both maxSurge and maxUnavailable are 1",
+			expectErr: true,
+			kcp:       bothMaxSurgeAndMaxUnavailable,
+		},
+		{
+			name:      "should return error when both maxSurge and maxUnavailable are 0",
+			expectErr: true,
+			kcp:       neitherMaxSurgeNorMaxUnavailable,
+		},
 		{
 			name:      "should return error when given an invalid rolloutBefore.certificatesExpiryDays value",
 			expectErr: true,
 			kcp:       invalidRolloutBeforeCertificateExpiryDays,
 		},
+		{
+			name:      "should succeed when given a valid etcdBackup configuration",
+			expectErr: false,
+			kcp:       validEtcdBackup,
+		},
+		{
+			name:      "should return error when etcdBackup.interval is less than a minute",
+			expectErr: true,
+			kcp:       invalidEtcdBackupInterval,
+		},
+		{
+			name:      "should return error when etcdBackup.sink is not set",
+			expectErr: true,
+			kcp:       invalidEtcdBackupSink,
+		},
+		{
+			name:      "should return error when etcdBackup is set with an external etcd",
+			expectErr: true,
+			kcp:       invalidEtcdBackupExternalEtcd,
+		},
+		{
+			name:      "should succeed when given a valid etcdDefragmentation configuration",
+			expectErr: false,
+			kcp:       validEtcdDefragmentation,
+		},
+		{
+			name:      "should return error when etcdDefragmentation.dbSizeThreshold is not greater than 0",
+			expectErr: true,
+			kcp:       invalidEtcdDefragmentationThreshold,
+		},
+		{
+			name:      "should return error when etcdDefragmentation is set with an external etcd",
+			expectErr: true,
+			kcp:       invalidEtcdDefragmentationExternalEtcd,
+		},
 
 		{
 			name:                  "should return error when Ignition configuration is invalid",
@@ -377,6 +491,14 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	updateMaxSurgeVal.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntVal = int32(0)
 	updateMaxSurgeVal.Spec.Replicas = pointer.Int32(3)
 
+	enableEtcdBackup := before.DeepCopy()
+	enableEtcdBackup.Spec.EtcdBackup = &controlplanev1.EtcdBackup{
+		Interval: metav1.Duration{Duration: time.Hour},
+		Sink: controlplanev1.EtcdBackupSink{
+			Secret: &controlplanev1.EtcdBackupSecretSink{NamePrefix: "backup"},
+		},
+	}
+
 	wrongReplicaCountForScaleIn := before.DeepCopy()
 	wrongReplicaCountForScaleIn.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntVal = int32(0)
 
@@ -977,6 +1099,12 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       updateMaxSurgeVal,
 		},
+		{
+			name:      "should not return an error when etcdBackup is enabled",
+			expectErr: false,
+			before:    before,
+			kcp:       enableEtcdBackup,
+		},
 		{
 			name:      "should return an error when maxSurge value is updated to 0, but replica count is < 3",
 			expectErr: true,
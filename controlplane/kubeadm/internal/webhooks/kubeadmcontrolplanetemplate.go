@@ -132,10 +132,15 @@ func validateKubeadmControlPlaneTemplateResourceSpec(s controlplanev1.KubeadmCon
 
 	allErrs = append(allErrs, validateRolloutBefore(s.RolloutBefore, pathPrefix.Child("rolloutBefore"))...)
 	allErrs = append(allErrs, validateRolloutStrategy(s.RolloutStrategy, nil, pathPrefix.Child("rolloutStrategy"))...)
+	allErrs = append(allErrs, validateEtcdBackup(s.EtcdBackup, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("etcdBackup"))...)
+	allErrs = append(allErrs, validateEtcdDefragmentation(s.EtcdDefragmentation, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("etcdDefragmentation"))...)
 
 	if s.MachineTemplate != nil {
 		// Validate the metadata of the MachineTemplate
 		allErrs = append(allErrs, s.MachineTemplate.ObjectMeta.Validate(pathPrefix.Child("machineTemplate", "metadata"))...)
+
+		// Validate the node drain policy of the MachineTemplate
+		allErrs = append(allErrs, s.MachineTemplate.NodeDrainPolicy.Validate(pathPrefix.Child("machineTemplate", "nodeDrainPolicy"))...)
 	}
 
 	return allErrs
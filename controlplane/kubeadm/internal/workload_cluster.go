@@ -25,6 +25,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 	"time"
@@ -46,6 +47,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
 	"sigs.k8s.io/cluster-api/internal/util/kubeadm"
 	"sigs.k8s.io/cluster-api/util"
@@ -123,6 +125,14 @@ type WorkloadCluster interface {
 
 	// State recovery tasks.
 	ReconcileEtcdMembers(ctx context.Context, nodeNames []string, version semver.Version) ([]string, error)
+
+	// Backup related tasks.
+	EtcdSnapshot(ctx context.Context) (io.ReadCloser, error)
+
+	// Maintenance related tasks.
+	EtcdMembersDBStatus(ctx context.Context) ([]EtcdMemberDBStatus, error)
+	DefragmentEtcdMember(ctx context.Context, nodeName string) error
+	DisarmEtcdAlarms(ctx context.Context, alarmType etcd.AlarmType) error
 }
 
 // Workload defines operations on workload clusters.
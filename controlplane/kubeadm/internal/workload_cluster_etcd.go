@@ -18,10 +18,13 @@ package internal
 
 import (
 	"context"
+	"io"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -247,3 +250,168 @@ func (w *Workload) EtcdMembers(ctx context.Context) ([]string, error) {
 	}
 	return names, nil
 }
+
+// EtcdMemberDBStatus contains information about the backend database of a single etcd member.
+type EtcdMemberDBStatus struct {
+	// Name is the name of the etcd member, which matches the name of the Node hosting it.
+	Name string
+
+	// IsLeader is true if the member is the etcd leader.
+	IsLeader bool
+
+	// DBSize is the size of the backend database of the member, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database of the member actually in use, in bytes;
+	// the difference with DBSize can be reclaimed by defragmenting the member.
+	DBSizeInUse int64
+
+	// Alarms is the list of alarms raised for the member.
+	Alarms []etcd.AlarmType
+}
+
+// EtcdMembersDBStatus returns information about the backend database of the etcd members hosted on control plane nodes.
+// An error is returned if any of the etcd members is not reachable, reports errors, or is not hosted on a control plane node.
+func (w *Workload) EtcdMembersDBStatus(ctx context.Context) ([]EtcdMemberDBStatus, error) {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list control plane nodes")
+	}
+
+	statuses := make([]EtcdMemberDBStatus, 0, len(nodes.Items))
+	nodeNames := sets.Set[string]{}
+	memberNames := sets.Set[string]{}
+	for _, node := range nodes.Items {
+		status, nodeMembers, err := w.etcdMemberDBStatus(ctx, node.Name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+		nodeNames.Insert(node.Name)
+		for _, member := range nodeMembers {
+			memberNames.Insert(member.Name)
+		}
+	}
+
+	// Make sure that all the etcd members are hosted on control plane nodes, e.g. there are no members
+	// left behind by a node which has been deleted.
+	// NOTE: The members reported by all the nodes are checked, given that members could disagree on the
+	// member list, e.g. while a member is being added or removed.
+	for _, name := range sets.List(memberNames) {
+		if !nodeNames.Has(name) {
+			return nil, errors.Errorf("etcd member %q is not hosted on a control plane node", name)
+		}
+	}
+	return statuses, nil
+}
+
+func (w *Workload) etcdMemberDBStatus(ctx context.Context, nodeName string) (*EtcdMemberDBStatus, []*etcd.Member, error) {
+	// Create the etcd Client for the etcd Pod scheduled on the Node, so the status reported by the client
+	// is the status of the etcd member hosted on the Node.
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create etcd client for node %q", nodeName)
+	}
+	defer etcdClient.Close()
+
+	// While creating a new client, forFirstAvailableNode retrieves the status for the endpoint; check if the endpoint has errors.
+	if len(etcdClient.Errors) > 0 {
+		return nil, nil, errors.Errorf("etcd member on node %q reports errors: %s", nodeName, strings.Join(etcdClient.Errors, ", "))
+	}
+
+	members, err := etcdClient.Members(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to list etcd members using etcd client")
+	}
+	member := etcdutil.MemberForName(members, nodeName)
+	if member == nil {
+		return nil, nil, errors.Errorf("failed to get etcd member from node %q", nodeName)
+	}
+
+	return &EtcdMemberDBStatus{
+		Name:        nodeName,
+		IsLeader:    member.ID == etcdClient.LeaderID,
+		DBSize:      etcdClient.DBSize,
+		DBSizeInUse: etcdClient.DBSizeInUse,
+		Alarms:      member.Alarms,
+	}, members, nil
+}
+
+// DefragmentEtcdMember defragments the backend database of the etcd member hosted on the given node.
+func (w *Workload) DefragmentEtcdMember(ctx context.Context, nodeName string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create etcd client for node %q", nodeName)
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.Defragment(ctx)
+}
+
+// DisarmEtcdAlarms disarms all the alarms of the given type raised in the etcd cluster.
+func (w *Workload) DisarmEtcdAlarms(ctx context.Context, alarmType etcd.AlarmType) error {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list control plane nodes")
+	}
+	nodeNames := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	alarms, err := etcdClient.Alarms(ctx)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for _, alarm := range alarms {
+		if alarm.Type != alarmType {
+			continue
+		}
+		if err := etcdClient.DisarmAlarm(ctx, alarm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// EtcdSnapshot returns a reader streaming a snapshot of the etcd cluster taken from the first available etcd member.
+// NOTE: The caller is responsible for closing the reader, which also closes the underlying etcd client.
+func (w *Workload) EtcdSnapshot(ctx context.Context) (io.ReadCloser, error) {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list control plane nodes")
+	}
+	nodeNames := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etcd client")
+	}
+
+	snapshot, err := etcdClient.Snapshot(ctx)
+	if err != nil {
+		closeErr := etcdClient.Close()
+		return nil, kerrors.NewAggregate([]error{err, closeErr})
+	}
+	return &etcdSnapshotReader{ReadCloser: snapshot, etcdClient: etcdClient}, nil
+}
+
+// etcdSnapshotReader is a reader for an etcd snapshot which closes the etcd client when closed.
+type etcdSnapshotReader struct {
+	io.ReadCloser
+	etcdClient *etcd.Client
+}
+
+// Close closes the snapshot reader and the etcd client.
+func (r *etcdSnapshotReader) Close() error {
+	return kerrors.NewAggregate([]error{r.ReadCloser.Close(), r.etcdClient.Close()})
+}
@@ -19,6 +19,7 @@ package internal
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/blang/semver/v4"
@@ -692,6 +693,316 @@ func TestReconcileEtcdMembers(t *testing.T) {
 	}
 }
 
+func TestEtcdSnapshot(t *testing.T) {
+	cp1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp1",
+			Labels: map[string]string{
+				labelNodeRoleControlPlane: "",
+			},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		wantSnapshot        []byte
+		expectErr           bool
+	}{
+		{
+			name:                "returns an error if it fails to create the etcd client",
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no client")},
+			expectErr:           true,
+		},
+		{
+			name: "returns an error if the client errors getting the snapshot",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						ErrorResponse: errors.New("cannot get snapshot"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "returns the snapshot",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						SnapshotData: []byte("snapshot"),
+					},
+				},
+			},
+			wantSnapshot: []byte("snapshot"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := fake.NewClientBuilder().WithObjects(cp1).Build()
+			w := &Workload{
+				Client:              fakeClient,
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			snapshot, err := w.EtcdSnapshot(ctx)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(io.ReadAll(snapshot)).To(Equal(tt.wantSnapshot))
+			g.Expect(snapshot.Close()).To(Succeed())
+		})
+	}
+}
+
+func TestEtcdMembersDBStatus(t *testing.T) {
+	cp1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp1",
+			Labels: map[string]string{
+				labelNodeRoleControlPlane: "",
+			},
+		},
+	}
+	cp2 := cp1.DeepCopy()
+	cp2.Name = "cp2"
+
+	memberListResponse := &clientv3.MemberListResponse{
+		Header: &pb.ResponseHeader{},
+		Members: []*pb.Member{
+			{Name: "cp1", ID: uint64(1)},
+			{Name: "cp2", ID: uint64(2)},
+		},
+	}
+	alarmResponse := &clientv3.AlarmResponse{
+		Alarms: []*pb.AlarmMember{
+			{MemberID: uint64(2), Alarm: pb.AlarmType_NOSPACE},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		want                []EtcdMemberDBStatus
+		expectErr           bool
+	}{
+		{
+			name:                "returns an error if it fails to create the etcd client",
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no client")},
+			expectErr:           true,
+		},
+		{
+			name: "returns an error if the etcd member of a node is missing",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						MemberListResponse: &clientv3.MemberListResponse{
+							Header:  &pb.ResponseHeader{},
+							Members: []*pb.Member{{Name: "cp1", ID: uint64(1)}},
+						},
+						AlarmResponse: &clientv3.AlarmResponse{},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "returns an error if the etcd member of a node reports errors",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						MemberListResponse: memberListResponse,
+						AlarmResponse:      &clientv3.AlarmResponse{},
+					},
+					Errors: []string{"some error"},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "returns an error if an etcd member is not hosted on a control plane node",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						MemberListResponse: &clientv3.MemberListResponse{
+							Header: &pb.ResponseHeader{},
+							Members: []*pb.Member{
+								{Name: "cp1", ID: uint64(1)},
+								{Name: "cp2", ID: uint64(2)},
+								{Name: "cp3", ID: uint64(3)},
+							},
+						},
+						AlarmResponse: &clientv3.AlarmResponse{},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "returns an error if an etcd member not hosted on a control plane node is reported by any node",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					switch n[0] {
+					case "cp1":
+						return &etcd.Client{
+							EtcdClient: &fake2.FakeEtcdClient{
+								MemberListResponse: &clientv3.MemberListResponse{
+									Header: &pb.ResponseHeader{},
+									Members: []*pb.Member{
+										{Name: "cp1", ID: uint64(1)},
+										{Name: "cp2", ID: uint64(2)},
+										{Name: "cp3", ID: uint64(3)},
+									},
+								},
+								AlarmResponse: &clientv3.AlarmResponse{},
+							},
+							MemberID: 1,
+						}, nil
+					default:
+						return &etcd.Client{
+							EtcdClient: &fake2.FakeEtcdClient{
+								MemberListResponse: memberListResponse,
+								AlarmResponse:      &clientv3.AlarmResponse{},
+							},
+							MemberID: 2,
+						}, nil
+					}
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "returns the status of the etcd member hosted on each node",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					switch n[0] {
+					case "cp1":
+						return &etcd.Client{
+							EtcdClient: &fake2.FakeEtcdClient{
+								MemberListResponse: memberListResponse,
+								AlarmResponse:      alarmResponse,
+							},
+							LeaderID:    1,
+							MemberID:    1,
+							DBSize:      100,
+							DBSizeInUse: 50,
+						}, nil
+					default:
+						return &etcd.Client{
+							EtcdClient: &fake2.FakeEtcdClient{
+								MemberListResponse: memberListResponse,
+								AlarmResponse:      alarmResponse,
+							},
+							LeaderID:    1,
+							MemberID:    2,
+							DBSize:      200,
+							DBSizeInUse: 150,
+						}, nil
+					}
+				},
+			},
+			want: []EtcdMemberDBStatus{
+				{Name: "cp1", IsLeader: true, DBSize: 100, DBSizeInUse: 50, Alarms: []etcd.AlarmType{}},
+				{Name: "cp2", IsLeader: false, DBSize: 200, DBSizeInUse: 150, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := fake.NewClientBuilder().WithObjects(cp1, cp2).Build()
+			w := &Workload{
+				Client:              fakeClient,
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			statuses, err := w.EtcdMembersDBStatus(ctx)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(statuses).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDefragmentEtcdMember(t *testing.T) {
+	t.Run("returns an error if the client errors defragmenting the member", func(t *testing.T) {
+		g := NewWithT(t)
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						ErrorResponse: errors.New("cannot defragment"),
+					},
+				},
+			},
+		}
+		g.Expect(w.DefragmentEtcdMember(ctx, "cp1")).ToNot(Succeed())
+	})
+	t.Run("defragments the etcd member hosted on the node", func(t *testing.T) {
+		g := NewWithT(t)
+		fakeEtcdClient := &fake2.FakeEtcdClient{
+			DefragmentResponse: &clientv3.DefragmentResponse{},
+		}
+		var nodeNames []string
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					nodeNames = n
+					return &etcd.Client{EtcdClient: fakeEtcdClient, Endpoint: "etcd-cp1"}, nil
+				},
+			},
+		}
+		g.Expect(w.DefragmentEtcdMember(ctx, "cp1")).To(Succeed())
+		g.Expect(nodeNames).To(Equal([]string{"cp1"}))
+		g.Expect(fakeEtcdClient.DefragmentedEndpoint).To(Equal("etcd-cp1"))
+	})
+}
+
+func TestDisarmEtcdAlarms(t *testing.T) {
+	cp1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp1",
+			Labels: map[string]string{
+				labelNodeRoleControlPlane: "",
+			},
+		},
+	}
+
+	t.Run("returns an error if it fails to create the etcd client", func(t *testing.T) {
+		g := NewWithT(t)
+		w := &Workload{
+			Client:              fake.NewClientBuilder().WithObjects(cp1).Build(),
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no client")},
+		}
+		g.Expect(w.DisarmEtcdAlarms(ctx, etcd.AlarmNoSpace)).ToNot(Succeed())
+	})
+	t.Run("disarms only alarms of the given type", func(t *testing.T) {
+		g := NewWithT(t)
+		fakeEtcdClient := &fake2.FakeEtcdClient{
+			AlarmResponse: &clientv3.AlarmResponse{
+				Alarms: []*pb.AlarmMember{
+					{MemberID: uint64(1), Alarm: pb.AlarmType_CORRUPT},
+					{MemberID: uint64(2), Alarm: pb.AlarmType_NOSPACE},
+				},
+			},
+		}
+		w := &Workload{
+			Client: fake.NewClientBuilder().WithObjects(cp1).Build(),
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+			},
+		}
+		g.Expect(w.DisarmEtcdAlarms(ctx, etcd.AlarmNoSpace)).To(Succeed())
+		g.Expect(fakeEtcdClient.DisarmedAlarm).To(Equal(&clientv3.AlarmMember{MemberID: uint64(2), Alarm: pb.AlarmType_NOSPACE}))
+	})
+}
+
 func TestRemoveNodeFromKubeadmConfigMap(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -210,6 +210,8 @@ func main() {
 
 	req, _ := labels.NewRequirement(clusterv1.ClusterNameLabel, selection.Exists, nil)
 	clusterSecretCacheSelector := labels.NewSelector().Add(*req)
+	req, _ = labels.NewRequirement(clusterv1.MachineControlPlaneNameLabel, selection.Exists, nil)
+	controlPlaneRevisionCacheSelector := labels.NewSelector().Add(*req)
 
 	ctrlOptions := ctrl.Options{
 		Scheme:                     scheme,
@@ -232,6 +234,10 @@ func main() {
 				&corev1.Secret{}: {
 					Label: clusterSecretCacheSelector,
 				},
+				// Note: Only ControllerRevisions recording the revision history of a KubeadmControlPlane are cached.
+				&appsv1.ControllerRevision{}: {
+					Label: controlPlaneRevisionCacheSelector,
+				},
 			},
 		},
 		Client: client.Options{
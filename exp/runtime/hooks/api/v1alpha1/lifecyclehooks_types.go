@@ -196,6 +196,100 @@ type BeforeClusterDeleteResponse struct {
 // and before the cluster and its underlying objects are deleted.
 func BeforeClusterDelete(*BeforeClusterDeleteRequest, *BeforeClusterDeleteResponse) {}
 
+// BeforeMachineDeploymentCutOverRequest is the request of the BeforeMachineDeploymentCutOver hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeploymentCutOverRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// Cluster is the cluster object the lifecycle hook corresponds to.
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// MachineDeployment is the MachineDeployment object being rolled out.
+	MachineDeployment clusterv1.MachineDeployment `json:"machineDeployment"`
+
+	// NewMachineSetName is the name of the new MachineSet the MachineDeployment is going to cut over to.
+	NewMachineSetName string `json:"newMachineSetName"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDeploymentCutOverResponse{}
+
+// BeforeMachineDeploymentCutOverResponse is the response of the BeforeMachineDeploymentCutOver hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeploymentCutOverResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDeploymentCutOver is the hook that is called during a blue/green rollout of a MachineDeployment,
+// after all the machines of the new MachineSet are available and before the old MachineSets are scaled down.
+func BeforeMachineDeploymentCutOver(*BeforeMachineDeploymentCutOverRequest, *BeforeMachineDeploymentCutOverResponse) {
+}
+
+// BeforeMachineDrainRequest is the request of the BeforeMachineDrain hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDrainRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// Cluster is the cluster object the lifecycle hook corresponds to.
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// Machine is the Machine object being deleted.
+	Machine clusterv1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDrainResponse{}
+
+// BeforeMachineDrainResponse is the response of the BeforeMachineDrain hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDrainResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDrain is the hook that is called after delete is issued on a Machine
+// and before the node of the Machine is drained.
+func BeforeMachineDrain(*BeforeMachineDrainRequest, *BeforeMachineDrainResponse) {}
+
+// BeforeMachineDeleteRequest is the request of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// Cluster is the cluster object the lifecycle hook corresponds to.
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// Machine is the Machine object being deleted.
+	Machine clusterv1.Machine `json:"machine"`
+}
+
+var _ RetryResponseObject = &BeforeMachineDeleteResponse{}
+
+// BeforeMachineDeleteResponse is the response of the BeforeMachineDelete hook.
+// +kubebuilder:object:root=true
+type BeforeMachineDeleteResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineDelete is the hook that is called after the node of a Machine is drained
+// and before the infrastructure of the Machine is deleted.
+func BeforeMachineDelete(*BeforeMachineDeleteRequest, *BeforeMachineDeleteResponse) {}
+
 func init() {
 	catalogBuilder.RegisterHook(BeforeClusterCreate, &runtimecatalog.HookMeta{
 		Tags:    []string{"Lifecycle Hooks"},
@@ -271,4 +365,43 @@ func init() {
 			"- This is a blocking hook; Runtime Extension implementers can use this hook  to execute " +
 			"tasks before objects of the Cluster are deleted",
 	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDeploymentCutOver, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a MachineDeployment cuts over to a new MachineSet",
+		Description: "Cluster API Runtime will call this hook during a blue/green rollout of a MachineDeployment, " +
+			"after all the machines of the new MachineSet are available and immediately before the old MachineSets are scaled down.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only for MachineDeployments using the BlueGreen strategy with a RuntimeHook cut-over gate\n" +
+			"- The call's request contains the Cluster object, the MachineDeployment object and the name of the new MachineSet\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks, e.g. validating the new machines, before the old machines are deleted",
+	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDrain, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before the node of a Machine is drained",
+		Description: "Cluster API Runtime will call this hook after the Machine deletion has been triggered, " +
+			"and immediately before the node of the Machine is going to be drained.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called only if the node of the Machine is going to be deleted, " +
+			"in the same cases the pre-drain.delete.hook.machine.cluster.x-k8s.io annotations are honored\n" +
+			"- The call's request contains the Cluster object and the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the node is drained, e.g. deregistering the node from a load balancer",
+	})
+
+	catalogBuilder.RegisterHook(BeforeMachineDelete, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before the infrastructure of a Machine is deleted",
+		Description: "Cluster API Runtime will call this hook after the node of a Machine being deleted has been drained, " +
+			"and immediately before the infrastructure of the Machine is going to be deleted.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- The call's request contains the Cluster object and the Machine object\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to execute " +
+			"tasks before the infrastructure is deleted, e.g. detaching storage",
+	})
 }
@@ -312,3 +312,10 @@ func (c *ControlPlaneMachineTemplate) NodeDeletionTimeout() *Duration {
 		path: Path{"spec", "machineTemplate", "nodeDeletionTimeout"},
 	}
 }
+
+// NodeDrainPolicy provides access to the nodeDrainPolicy of a MachineTemplate.
+func (c *ControlPlaneMachineTemplate) NodeDrainPolicy() *NodeDrainPolicy {
+	return &NodeDrainPolicy{
+		path: Path{"spec", "machineTemplate", "nodeDrainPolicy"},
+	}
+}
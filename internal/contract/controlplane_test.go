@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -266,6 +267,33 @@ func TestControlPlane(t *testing.T) {
 		g.Expect(found).To(BeTrue())
 		g.Expect(durationString).To(Equal(expectedDurationString))
 	})
+
+	t.Run("Manages spec.machineTemplate.nodeDrainPolicy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		policy := &clusterv1.NodeDrainPolicy{
+			SkipPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+			EvictionOrder: []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"stateless": "true"}},
+			},
+			Mode:               clusterv1.NodeDrainModeDelete,
+			GracePeriodSeconds: pointer.Int64(30),
+		}
+		g.Expect(ControlPlane().MachineTemplate().NodeDrainPolicy().Path()).To(Equal(Path{"spec", "machineTemplate", "nodeDrainPolicy"}))
+
+		err := ControlPlane().MachineTemplate().NodeDrainPolicy().Set(obj, policy)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		got, err := ControlPlane().MachineTemplate().NodeDrainPolicy().Get(obj)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(Equal(policy))
+
+		// Check that the mode is set as a string.
+		mode, found, err := unstructured.NestedString(obj.UnstructuredContent(), "spec", "machineTemplate", "nodeDrainPolicy", "mode")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(found).To(BeTrue())
+		g.Expect(mode).To(Equal("Delete"))
+	})
 }
 
 func TestControlPlaneIsUpgrading(t *testing.T) {
@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contract
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// NodeDrainPolicy provides a helper struct for working with NodeDrainPolicy.
+type NodeDrainPolicy struct {
+	path Path
+}
+
+// Path returns the path of the NodeDrainPolicy.
+func (n *NodeDrainPolicy) Path() Path {
+	return n.path
+}
+
+// Get gets the NodeDrainPolicy object.
+func (n *NodeDrainPolicy) Get(obj *unstructured.Unstructured) (*clusterv1.NodeDrainPolicy, error) {
+	value, ok, err := unstructured.NestedMap(obj.UnstructuredContent(), n.path...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s from object", "."+strings.Join(n.path, "."))
+	}
+	if !ok {
+		return nil, errors.Wrapf(ErrFieldNotFound, "path %s", "."+strings.Join(n.path, "."))
+	}
+
+	policy := &clusterv1.NodeDrainPolicy{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(value, policy); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s from object", "."+strings.Join(n.path, "."))
+	}
+	return policy, nil
+}
+
+// Set sets the NodeDrainPolicy value in the path.
+func (n *NodeDrainPolicy) Set(obj *unstructured.Unstructured, policy *clusterv1.NodeDrainPolicy) error {
+	value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(policy)
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert NodeDrainPolicy for path %s of object %v", "."+strings.Join(n.path, "."), obj.GroupVersionKind())
+	}
+	if err := unstructured.SetNestedMap(obj.UnstructuredContent(), value, n.path...); err != nil {
+		return errors.Wrapf(err, "failed to set path %s of object %v", "."+strings.Join(n.path, "."), obj.GroupVersionKind())
+	}
+	return nil
+}
@@ -32,10 +32,13 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -55,6 +58,7 @@ import (
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses;clusterclasses/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;patch;delete
 
 // Reconciler reconciles the ClusterClass object.
 type Reconciler struct {
@@ -81,6 +85,11 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 			&runtimev1.ExtensionConfig{},
 			handler.EnqueueRequestsFromMapFunc(r.extensionConfigToClusterClass),
 		).
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterClass),
+			builder.WithPredicates(clusterClassRevisionChanged()),
+		).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
 
@@ -139,6 +148,9 @@ func (r *Reconciler) reconcile(ctx context.Context, clusterClass *clusterv1.Clus
 	if err != nil {
 		return err
 	}
+	if err := r.reconcileRevisions(ctx, clusterClass); err != nil {
+		return err
+	}
 
 	reconcileConditions(clusterClass, outdatedRefs)
 
@@ -147,36 +159,7 @@ func (r *Reconciler) reconcile(ctx context.Context, clusterClass *clusterv1.Clus
 
 func (r *Reconciler) reconcileExternalReferences(ctx context.Context, clusterClass *clusterv1.ClusterClass) (map[*corev1.ObjectReference]*corev1.ObjectReference, error) {
 	// Collect all the reference from the ClusterClass to templates.
-	refs := []*corev1.ObjectReference{}
-
-	if clusterClass.Spec.Infrastructure.Ref != nil {
-		refs = append(refs, clusterClass.Spec.Infrastructure.Ref)
-	}
-
-	if clusterClass.Spec.ControlPlane.Ref != nil {
-		refs = append(refs, clusterClass.Spec.ControlPlane.Ref)
-	}
-	if clusterClass.Spec.ControlPlane.MachineInfrastructure != nil && clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref != nil {
-		refs = append(refs, clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
-	}
-
-	for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
-		if mdClass.Template.Bootstrap.Ref != nil {
-			refs = append(refs, mdClass.Template.Bootstrap.Ref)
-		}
-		if mdClass.Template.Infrastructure.Ref != nil {
-			refs = append(refs, mdClass.Template.Infrastructure.Ref)
-		}
-	}
-
-	for _, mpClass := range clusterClass.Spec.Workers.MachinePools {
-		if mpClass.Template.Bootstrap.Ref != nil {
-			refs = append(refs, mpClass.Template.Bootstrap.Ref)
-		}
-		if mpClass.Template.Infrastructure.Ref != nil {
-			refs = append(refs, mpClass.Template.Infrastructure.Ref)
-		}
-	}
+	refs := templateRefs(clusterClass)
 
 	// Ensure all referenced objects are owned by the ClusterClass.
 	// Nb. Some external objects can be referenced multiple times in the ClusterClass,
@@ -390,10 +373,76 @@ func (r *Reconciler) reconcileExternal(ctx context.Context, clusterClass *cluste
 	return nil
 }
 
+// templateRefs returns the references from the ClusterClass to templates.
+func templateRefs(clusterClass *clusterv1.ClusterClass) []*corev1.ObjectReference {
+	refs := []*corev1.ObjectReference{}
+
+	if clusterClass.Spec.Infrastructure.Ref != nil {
+		refs = append(refs, clusterClass.Spec.Infrastructure.Ref)
+	}
+
+	if clusterClass.Spec.ControlPlane.Ref != nil {
+		refs = append(refs, clusterClass.Spec.ControlPlane.Ref)
+	}
+	if clusterClass.Spec.ControlPlane.MachineInfrastructure != nil && clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref != nil {
+		refs = append(refs, clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
+	}
+
+	for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
+		if mdClass.Template.Bootstrap.Ref != nil {
+			refs = append(refs, mdClass.Template.Bootstrap.Ref)
+		}
+		if mdClass.Template.Infrastructure.Ref != nil {
+			refs = append(refs, mdClass.Template.Infrastructure.Ref)
+		}
+	}
+
+	for _, mpClass := range clusterClass.Spec.Workers.MachinePools {
+		if mpClass.Template.Bootstrap.Ref != nil {
+			refs = append(refs, mpClass.Template.Bootstrap.Ref)
+		}
+		if mpClass.Template.Infrastructure.Ref != nil {
+			refs = append(refs, mpClass.Template.Infrastructure.Ref)
+		}
+	}
+	return refs
+}
+
 func uniqueObjectRefKey(ref *corev1.ObjectReference) string {
 	return fmt.Sprintf("Name:%s, Namespace:%s, Kind:%s, APIVersion:%s", ref.Name, ref.Namespace, ref.Kind, ref.APIVersion)
 }
 
+// clusterToClusterClass maps a Cluster to its ClusterClass, so the templates referenced by the revision the Cluster
+// is pinned to are retained.
+func (r *Reconciler) clusterToClusterClass(_ context.Context, o client.Object) []reconcile.Request {
+	cluster, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Cluster but got a %T", o))
+	}
+	if cluster.Spec.Topology == nil {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.Topology.Class}}}
+}
+
+// clusterClassRevisionChanged returns a predicate filtering the events for Clusters which are pinned to
+// a revision of their ClusterClass, or which change the revision they are pinned to.
+func clusterClassRevisionChanged() predicate.Funcs {
+	classRevision := func(o client.Object) string {
+		cluster, ok := o.(*clusterv1.Cluster)
+		if !ok || cluster.Spec.Topology == nil {
+			return ""
+		}
+		return cluster.Spec.Topology.ClassRevision
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return classRevision(e.Object) != "" },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return classRevision(e.ObjectOld) != classRevision(e.ObjectNew) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return classRevision(e.Object) != "" },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
 // extensionConfigToClusterClass maps an ExtensionConfigs to the corresponding ClusterClass to reconcile them on updates
 // of the ExtensionConfig.
 func (r *Reconciler) extensionConfigToClusterClass(ctx context.Context, o client.Object) []reconcile.Request {
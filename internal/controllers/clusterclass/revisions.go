@@ -0,0 +1,296 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclass
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	tlog "sigs.k8s.io/cluster-api/internal/log"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// revisionHistoryLimit is the number of revisions of a ClusterClass, including the current one, kept in the
+// revision history. Revisions Clusters are pinned to are kept in addition to those.
+const revisionHistoryLimit = 10
+
+// reconcileRevisions records the spec and the variable definitions of a ClusterClass as a ControllerRevision, so
+// Clusters can be pinned to it; the name of the revision is surfaced as the current revision in the ClusterClass status.
+// If the current ClusterClass matches a previous revision, the previous revision becomes the current revision; the oldest
+// revisions exceeding revisionHistoryLimit are deleted, unless a Cluster is pinned to them. The templates referenced by the
+// revisions Clusters are pinned to are retained until the Clusters are rebased.
+func (r *Reconciler) reconcileRevisions(ctx context.Context, clusterClass *clusterv1.ClusterClass) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	data, err := revisions.Data(clusterClass)
+	if err != nil {
+		return err
+	}
+
+	classRevisions, err := r.getRevisions(ctx, clusterClass)
+	if err != nil {
+		return err
+	}
+
+	var current *appsv1.ControllerRevision
+	nextRevision := int64(1)
+	for _, revision := range classRevisions {
+		if bytes.Equal(revision.Data.Raw, data) {
+			current = revision
+		}
+		if revision.Revision >= nextRevision {
+			nextRevision = revision.Revision + 1
+		}
+	}
+
+	switch {
+	case current == nil:
+		current = &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      revisions.Name(clusterClass, data),
+				Namespace: clusterClass.Namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterClassNameLabel: revisions.LabelValue(clusterClass),
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(clusterClass, clusterv1.GroupVersion.WithKind("ClusterClass")),
+				},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: nextRevision,
+		}
+		if err := r.Client.Create(ctx, current); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "failed to create revision %d", nextRevision)
+			}
+			// The revision has been created by a previous reconcile, but it is not in the cache yet.
+			clusterClass.Status.CurrentRevision = current.Name
+			return nil
+		}
+		log.V(4).Info("Created revision", "ControllerRevision", current.Name, "revision", current.Revision)
+		classRevisions = append(classRevisions, current)
+	case current.Revision != nextRevision-1:
+		// The ClusterClass has been rolled back to a previous revision, which becomes the current revision.
+		patch := client.MergeFrom(current.DeepCopy())
+		current.Revision = nextRevision
+		if err := r.Client.Patch(ctx, current, patch); err != nil {
+			return errors.Wrapf(err, "failed to update revision %s", current.Name)
+		}
+		log.V(4).Info("Updated revision", "ControllerRevision", current.Name, "revision", current.Revision)
+	}
+	clusterClass.Status.CurrentRevision = current.Name
+
+	pinnedRevisions, err := r.getPinnedRevisions(ctx, clusterClass)
+	if err != nil {
+		return err
+	}
+
+	if err := r.reconcilePinnedTemplates(ctx, clusterClass, classRevisions, pinnedRevisions); err != nil {
+		return err
+	}
+
+	if len(classRevisions) <= revisionHistoryLimit {
+		return nil
+	}
+
+	sort.Slice(classRevisions, func(i, j int) bool {
+		return classRevisions[i].Revision < classRevisions[j].Revision
+	})
+	var errs []error
+	for _, revision := range classRevisions[:len(classRevisions)-revisionHistoryLimit] {
+		if pinnedRevisions.Has(revision.Name) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, revision); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete revision %s", revision.Name))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// reconcilePinnedTemplates adds the ClusterClassRevisionFinalizer to the templates referenced by the revisions Clusters
+// are pinned to, given that revisions record the references to the templates and not the templates themselves; the
+// finalizer is removed from the templates which are referenced only by revisions no Cluster is pinned to, unless the
+// templates are shared with other ClusterClasses which have revisions Clusters are pinned to.
+func (r *Reconciler) reconcilePinnedTemplates(ctx context.Context, clusterClass *clusterv1.ClusterClass, classRevisions []*appsv1.ControllerRevision, pinnedRevisions sets.Set[string]) error {
+	pinnedRefs := map[string]*corev1.ObjectReference{}
+	unpinnedRefs := map[string]*corev1.ObjectReference{}
+	for _, revision := range classRevisions {
+		revisionClusterClass, err := revisions.FromRevision(clusterClass, revision)
+		if err != nil {
+			return err
+		}
+		for _, ref := range templateRefs(revisionClusterClass) {
+			if pinnedRevisions.Has(revision.Name) {
+				pinnedRefs[uniqueObjectRefKey(ref)] = ref
+			} else {
+				unpinnedRefs[uniqueObjectRefKey(ref)] = ref
+			}
+		}
+	}
+
+	var errs []error
+	for _, ref := range pinnedRefs {
+		if err := r.reconcileTemplateFinalizer(ctx, clusterClass, ref, true); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for key := range pinnedRefs {
+		delete(unpinnedRefs, key)
+	}
+	if len(unpinnedRefs) == 0 {
+		return kerrors.NewAggregate(errs)
+	}
+
+	pinnedByOtherClasses, err := r.getTemplatesPinnedByOtherClusterClasses(ctx, clusterClass)
+	if err != nil {
+		return kerrors.NewAggregate(append(errs, err))
+	}
+	for key, ref := range unpinnedRefs {
+		if pinnedByOtherClasses.Has(key) {
+			continue
+		}
+		if err := r.reconcileTemplateFinalizer(ctx, clusterClass, ref, false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// getTemplatesPinnedByOtherClusterClasses returns the keys of the templates referenced by the revisions of the other
+// ClusterClasses in the namespace of a ClusterClass that Clusters are pinned to, given that templates can be shared
+// by multiple ClusterClasses and the ClusterClassRevisionFinalizer is shared by all of them.
+func (r *Reconciler) getTemplatesPinnedByOtherClusterClasses(ctx context.Context, clusterClass *clusterv1.ClusterClass) (sets.Set[string], error) {
+	clusterList := &clusterv1.ClusterList{}
+	if err := r.Client.List(ctx, clusterList, client.InNamespace(clusterClass.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	pinnedRefs := sets.Set[string]{}
+	seenRevisions := sets.Set[string]{}
+	for _, cluster := range clusterList.Items {
+		if cluster.Spec.Topology == nil || cluster.Spec.Topology.Class == clusterClass.Name || cluster.Spec.Topology.ClassRevision == "" {
+			continue
+		}
+		if seenRevisions.Has(cluster.Spec.Topology.ClassRevision) {
+			continue
+		}
+		seenRevisions.Insert(cluster.Spec.Topology.ClassRevision)
+
+		otherClusterClass := &clusterv1.ClusterClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: clusterClass.Namespace,
+				Name:      cluster.Spec.Topology.Class,
+			},
+		}
+		revisionClusterClass, err := revisions.ClusterClass(ctx, r.Client, otherClusterClass, cluster.Spec.Topology.ClassRevision)
+		if err != nil {
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				continue
+			}
+			return nil, err
+		}
+		for _, ref := range templateRefs(revisionClusterClass) {
+			pinnedRefs.Insert(uniqueObjectRefKey(ref))
+		}
+	}
+	return pinnedRefs, nil
+}
+
+// reconcileTemplateFinalizer adds or removes the ClusterClassRevisionFinalizer from a template.
+func (r *Reconciler) reconcileTemplateFinalizer(ctx context.Context, clusterClass *clusterv1.ClusterClass, ref *corev1.ObjectReference, pinned bool) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	obj, err := external.Get(ctx, r.UnstructuredCachingClient, ref, clusterClass.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			if pinned {
+				log.Info("Template referenced by a pinned revision not found", "refGroupVersionKind", ref.GroupVersionKind(), "refName", ref.Name)
+			}
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get template %s %s", ref.Kind, ref.Name)
+	}
+
+	if controllerutil.ContainsFinalizer(obj, clusterv1.ClusterClassRevisionFinalizer) == pinned {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create patch helper for %s", tlog.KObj{Obj: obj})
+	}
+	if pinned {
+		controllerutil.AddFinalizer(obj, clusterv1.ClusterClassRevisionFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(obj, clusterv1.ClusterClassRevisionFinalizer)
+	}
+	if err := patchHelper.Patch(ctx, obj); err != nil {
+		return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: obj})
+	}
+	return nil
+}
+
+// getRevisions returns the ControllerRevisions recording the revisions of a ClusterClass.
+func (r *Reconciler) getRevisions(ctx context.Context, clusterClass *clusterv1.ClusterClass) ([]*appsv1.ControllerRevision, error) {
+	revisionList := &appsv1.ControllerRevisionList{}
+	if err := r.Client.List(ctx, revisionList, client.InNamespace(clusterClass.Namespace), client.MatchingLabels{clusterv1.ClusterClassNameLabel: revisions.LabelValue(clusterClass)}); err != nil {
+		return nil, errors.Wrap(err, "failed to list revisions")
+	}
+
+	classRevisions := []*appsv1.ControllerRevision{}
+	for i := range revisionList.Items {
+		if metav1.IsControlledBy(&revisionList.Items[i], clusterClass) {
+			classRevisions = append(classRevisions, &revisionList.Items[i])
+		}
+	}
+	return classRevisions, nil
+}
+
+// getPinnedRevisions returns the names of the revisions of a ClusterClass the Clusters using the ClusterClass are pinned to.
+// NOTE: Clusters are not listed using the ClusterClass name index, given that the index is not available
+// when the controller is run by clusterctl in dry-run mode.
+func (r *Reconciler) getPinnedRevisions(ctx context.Context, clusterClass *clusterv1.ClusterClass) (sets.Set[string], error) {
+	clusterList := &clusterv1.ClusterList{}
+	if err := r.Client.List(ctx, clusterList, client.InNamespace(clusterClass.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	pinnedRevisions := sets.Set[string]{}
+	for _, cluster := range clusterList.Items {
+		if cluster.Spec.Topology != nil && cluster.Spec.Topology.Class == clusterClass.Name && cluster.Spec.Topology.ClassRevision != "" {
+			pinnedRevisions.Insert(cluster.Spec.Topology.ClassRevision)
+		}
+	}
+	return pinnedRevisions, nil
+}
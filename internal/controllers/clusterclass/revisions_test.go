@@ -0,0 +1,250 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclass
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
+)
+
+func TestReconciler_reconcileRevisions(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "class",
+			UID:       "class-uid",
+		},
+		Spec: clusterv1.ClusterClassSpec{
+			ControlPlane: clusterv1.ControlPlaneClass{
+				Metadata: clusterv1.ObjectMeta{Labels: map[string]string{"foo": "1"}},
+			},
+		},
+		Status: clusterv1.ClusterClassStatus{
+			Variables: []clusterv1.ClusterClassStatusVariable{{Name: "location"}},
+		},
+	}
+	pinnedCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "cluster",
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Class: clusterClass.Name,
+			},
+		},
+	}
+
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(fakeScheme).Build(),
+	}
+
+	// classRevisions returns the ClusterClasses recorded in the revisions, indexed by revision number.
+	classRevisions := func() map[int64]*clusterv1.ClusterClass {
+		revisionList := &appsv1.ControllerRevisionList{}
+		g.Expect(r.Client.List(ctx, revisionList, client.InNamespace(clusterClass.Namespace))).To(Succeed())
+		ret := map[int64]*clusterv1.ClusterClass{}
+		for _, revision := range revisionList.Items {
+			g.Expect(metav1.IsControlledBy(&revision, clusterClass)).To(BeTrue())
+			g.Expect(revision.Labels).To(HaveKeyWithValue(clusterv1.ClusterClassNameLabel, clusterClass.Name))
+			revisionClusterClass, err := revisions.ClusterClass(ctx, r.Client, clusterClass, revision.Name)
+			g.Expect(err).ToNot(HaveOccurred())
+			ret[revision.Revision] = revisionClusterClass
+		}
+		return ret
+	}
+
+	// Records the first revision.
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	g.Expect(classRevisions()).To(HaveLen(1))
+	g.Expect(classRevisions()[1].Spec).To(Equal(clusterClass.Spec))
+	g.Expect(classRevisions()[1].Status.Variables).To(Equal(clusterClass.Status.Variables))
+	firstRevision := clusterClass.Status.CurrentRevision
+	g.Expect(firstRevision).To(HavePrefix("class-"))
+
+	// Does nothing if the ClusterClass did not change.
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	g.Expect(classRevisions()).To(HaveLen(1))
+	g.Expect(clusterClass.Status.CurrentRevision).To(Equal(firstRevision))
+
+	// Records a new revision if the ClusterClass changes.
+	clusterClass.Spec.ControlPlane.Metadata.Labels["foo"] = "2"
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	g.Expect(classRevisions()).To(HaveLen(2))
+	g.Expect(classRevisions()[2].Spec.ControlPlane.Metadata.Labels).To(HaveKeyWithValue("foo", "2"))
+	g.Expect(clusterClass.Status.CurrentRevision).ToNot(Equal(firstRevision))
+
+	// Makes a previous revision the current revision if the ClusterClass is rolled back.
+	clusterClass.Spec.ControlPlane.Metadata.Labels["foo"] = "1"
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	g.Expect(classRevisions()).To(HaveLen(2))
+	g.Expect(classRevisions()).To(HaveKey(int64(2)))
+	g.Expect(classRevisions()[3].Spec.ControlPlane.Metadata.Labels).To(HaveKeyWithValue("foo", "1"))
+	g.Expect(clusterClass.Status.CurrentRevision).To(Equal(firstRevision))
+
+	// Deletes the oldest revisions exceeding the revision history limit, unless a Cluster is pinned to them.
+	pinnedCluster.Spec.Topology.ClassRevision = firstRevision
+	g.Expect(r.Client.Create(ctx, pinnedCluster)).To(Succeed())
+	for i := 0; i < revisionHistoryLimit; i++ {
+		clusterClass.Spec.ControlPlane.Metadata.Labels["foo"] = fmt.Sprintf("%d", i+3)
+		g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	}
+	g.Expect(classRevisions()).To(HaveLen(revisionHistoryLimit + 1))
+	g.Expect(classRevisions()).To(HaveKey(int64(3)))
+	g.Expect(classRevisions()).ToNot(HaveKey(int64(2)))
+	g.Expect(classRevisions()).To(HaveKey(int64(4)))
+	g.Expect(classRevisions()).To(HaveKey(int64(3 + revisionHistoryLimit)))
+}
+
+func TestReconciler_reconcileRevisionsRetainsPinnedTemplates(t *testing.T) {
+	g := NewWithT(t)
+
+	oldTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra-cluster-template-1").Build()
+	newTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra-cluster-template-2").Build()
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class").
+		WithInfrastructureClusterTemplate(oldTemplate).
+		Build()
+	clusterClass.UID = "class-uid"
+
+	fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(oldTemplate, newTemplate).Build()
+	r := &Reconciler{
+		Client:                    fakeClient,
+		UnstructuredCachingClient: fakeClient,
+	}
+
+	// getTemplate returns the template with the given name, if it exists.
+	getTemplate := func(template *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(template.GroupVersionKind())
+		err := fakeClient.Get(ctx, client.ObjectKeyFromObject(template), obj)
+		return obj, err
+	}
+
+	// Pin a Cluster to the first revision.
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	pinnedRevision := clusterClass.Status.CurrentRevision
+	pinnedCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "cluster",
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Class:         clusterClass.Name,
+				ClassRevision: pinnedRevision,
+			},
+		},
+	}
+	g.Expect(fakeClient.Create(ctx, pinnedCluster)).To(Succeed())
+
+	// Rotate the template; the template referenced by the pinned revision is retained.
+	clusterClass.Spec.Infrastructure.Ref.Name = newTemplate.GetName()
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	g.Expect(clusterClass.Status.CurrentRevision).ToNot(Equal(pinnedRevision))
+
+	obj, err := getTemplate(oldTemplate)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obj.GetFinalizers()).To(ContainElement(clusterv1.ClusterClassRevisionFinalizer))
+	obj, err = getTemplate(newTemplate)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obj.GetFinalizers()).ToNot(ContainElement(clusterv1.ClusterClassRevisionFinalizer))
+
+	// Deleting the rotated template does not affect the pinned Cluster.
+	g.Expect(fakeClient.Delete(ctx, oldTemplate.DeepCopy())).To(Succeed())
+	revisionClusterClass, err := revisions.ClusterClass(ctx, fakeClient, clusterClass, pinnedRevision)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(revisionClusterClass.Spec.Infrastructure.Ref.Name).To(Equal(oldTemplate.GetName()))
+	_, err = getTemplate(oldTemplate)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Rebase the Cluster; the rotated template is released.
+	pinnedCluster.Spec.Topology.ClassRevision = ""
+	g.Expect(fakeClient.Update(ctx, pinnedCluster)).To(Succeed())
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	_, err = getTemplate(oldTemplate)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestReconciler_reconcileRevisionsRetainsTemplatesSharedWithOtherClusterClasses(t *testing.T) {
+	g := NewWithT(t)
+
+	sharedTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra-cluster-template").Build()
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class").
+		WithInfrastructureClusterTemplate(sharedTemplate).
+		Build()
+	clusterClass.UID = "class-uid"
+	otherClusterClass := builder.ClusterClass(metav1.NamespaceDefault, "other-class").
+		WithInfrastructureClusterTemplate(sharedTemplate).
+		Build()
+	otherClusterClass.UID = "other-class-uid"
+
+	fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(sharedTemplate).Build()
+	r := &Reconciler{
+		Client:                    fakeClient,
+		UnstructuredCachingClient: fakeClient,
+	}
+
+	// sharedTemplateFinalizers returns the finalizers of the shared template.
+	sharedTemplateFinalizers := func() []string {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(sharedTemplate.GroupVersionKind())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(sharedTemplate), obj)).To(Succeed())
+		return obj.GetFinalizers()
+	}
+
+	// Pin a Cluster to the revision of the other ClusterClass.
+	g.Expect(r.reconcileRevisions(ctx, otherClusterClass)).To(Succeed())
+	pinnedCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "cluster",
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Class:         otherClusterClass.Name,
+				ClassRevision: otherClusterClass.Status.CurrentRevision,
+			},
+		},
+	}
+	g.Expect(fakeClient.Create(ctx, pinnedCluster)).To(Succeed())
+	g.Expect(r.reconcileRevisions(ctx, otherClusterClass)).To(Succeed())
+	g.Expect(sharedTemplateFinalizers()).To(ContainElement(clusterv1.ClusterClassRevisionFinalizer))
+
+	// The ClusterClass no Cluster is pinned to does not release the shared template.
+	g.Expect(r.reconcileRevisions(ctx, clusterClass)).To(Succeed())
+	g.Expect(sharedTemplateFinalizers()).To(ContainElement(clusterv1.ClusterClassRevisionFinalizer))
+
+	// Rebase the Cluster; the shared template is released.
+	pinnedCluster.Spec.Topology.ClassRevision = ""
+	g.Expect(fakeClient.Update(ctx, pinnedCluster)).To(Succeed())
+	g.Expect(r.reconcileRevisions(ctx, otherClusterClass)).To(Succeed())
+	g.Expect(sharedTemplateFinalizers()).ToNot(ContainElement(clusterv1.ClusterClassRevisionFinalizer))
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// applyNodeDrainPolicy configures the drainer according to the NodeDrainPolicy of a Machine, and returns the
+// selectors defining the order in which pods are drained.
+func applyNodeDrainPolicy(drainer *kubedrain.Helper, policy *clusterv1.NodeDrainPolicy) ([]labels.Selector, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	drainer.DisableEviction = policy.Mode == clusterv1.NodeDrainModeDelete
+	if policy.GracePeriodSeconds != nil {
+		drainer.GracePeriodSeconds = int(*policy.GracePeriodSeconds)
+	}
+
+	if policy.SkipPodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policy.SkipPodSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid nodeDrainPolicy.skipPodSelector")
+		}
+		drainer.AdditionalFilters = append(drainer.AdditionalFilters, skipPodsFilter(selector))
+	}
+
+	evictionOrder := make([]labels.Selector, 0, len(policy.EvictionOrder))
+	for i := range policy.EvictionOrder {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.EvictionOrder[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid nodeDrainPolicy.evictionOrder[%d]", i)
+		}
+		evictionOrder = append(evictionOrder, selector)
+	}
+	return evictionOrder, nil
+}
+
+// skipPodsFilter returns a pod filter skipping the pods matching the given selector.
+func skipPodsFilter(selector labels.Selector) kubedrain.PodFilter {
+	return func(pod corev1.Pod) kubedrain.PodDeleteStatus {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return kubedrain.MakePodDeleteStatusSkip()
+		}
+		return kubedrain.MakePodDeleteStatusOkay()
+	}
+}
+
+// groupPodsByEvictionOrder splits the pods into groups to be drained one after the other; each pod goes into
+// the group of the first selector it matches, and pods not matching any selector are drained last.
+// Empty groups are dropped.
+func groupPodsByEvictionOrder(pods []corev1.Pod, evictionOrder []labels.Selector) [][]corev1.Pod {
+	groups := make([][]corev1.Pod, len(evictionOrder)+1)
+	for _, pod := range pods {
+		i := 0
+		for ; i < len(evictionOrder); i++ {
+			if evictionOrder[i].Matches(labels.Set(pod.Labels)) {
+				break
+			}
+		}
+		groups[i] = append(groups[i], pod)
+	}
+
+	ret := [][]corev1.Pod{}
+	for _, group := range groups {
+		if len(group) > 0 {
+			ret = append(ret, group)
+		}
+	}
+	return ret
+}
+
+// runNodeDrain drains a node like kubedrain.RunNodeDrain, but drains the pods one group at a time following
+// the eviction order; pods of a group are only drained once all the pods of the previous groups are gone.
+func runNodeDrain(drainer *kubedrain.Helper, nodeName string, evictionOrder []labels.Selector) error {
+	list, errs := drainer.GetPodsForDeletion(nodeName)
+	if errs != nil {
+		return kerrors.NewAggregate(errs)
+	}
+	if warnings := list.Warnings(); warnings != "" {
+		fmt.Fprintf(drainer.ErrOut, "WARNING: %s\n", warnings)
+	}
+
+	for _, group := range groupPodsByEvictionOrder(list.Pods(), evictionOrder) {
+		if err := drainer.DeleteOrEvictPods(group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestApplyNodeDrainPolicy(t *testing.T) {
+	tests := []struct {
+		name                   string
+		policy                 *clusterv1.NodeDrainPolicy
+		wantErr                bool
+		wantDisableEviction    bool
+		wantGracePeriodSeconds int
+		wantFilters            int
+		wantEvictionOrder      int
+	}{
+		{
+			name:                   "nil policy keeps the defaults",
+			policy:                 nil,
+			wantGracePeriodSeconds: -1,
+		},
+		{
+			name: "sets mode, grace period, skip filter and eviction order",
+			policy: &clusterv1.NodeDrainPolicy{
+				Mode:               clusterv1.NodeDrainModeDelete,
+				GracePeriodSeconds: pointer.Int64(30),
+				SkipPodSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"skip": "true"}},
+				EvictionOrder: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{"tier": "stateless"}},
+					{MatchLabels: map[string]string{"tier": "stateful"}},
+				},
+			},
+			wantDisableEviction:    true,
+			wantGracePeriodSeconds: 30,
+			wantFilters:            1,
+			wantEvictionOrder:      2,
+		},
+		{
+			name: "evict mode uses eviction",
+			policy: &clusterv1.NodeDrainPolicy{
+				Mode: clusterv1.NodeDrainModeEvict,
+			},
+			wantGracePeriodSeconds: -1,
+		},
+		{
+			name: "fails for an invalid skip pod selector",
+			policy: &clusterv1.NodeDrainPolicy{
+				SkipPodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "foo", Operator: "Invalid"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for an invalid eviction order selector",
+			policy: &clusterv1.NodeDrainPolicy{
+				EvictionOrder: []metav1.LabelSelector{{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "foo", Operator: "Invalid"}}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			drainer := &kubedrain.Helper{GracePeriodSeconds: -1}
+			evictionOrder, err := applyNodeDrainPolicy(drainer, tt.policy)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(drainer.DisableEviction).To(Equal(tt.wantDisableEviction))
+			g.Expect(drainer.GracePeriodSeconds).To(Equal(tt.wantGracePeriodSeconds))
+			g.Expect(drainer.AdditionalFilters).To(HaveLen(tt.wantFilters))
+			g.Expect(evictionOrder).To(HaveLen(tt.wantEvictionOrder))
+		})
+	}
+}
+
+func TestSkipPodsFilter(t *testing.T) {
+	g := NewWithT(t)
+
+	filter := skipPodsFilter(labels.SelectorFromSet(labels.Set{"skip": "true"}))
+
+	g.Expect(filter(pod("skipped", map[string]string{"skip": "true"}))).To(Equal(kubedrain.MakePodDeleteStatusSkip()))
+	g.Expect(filter(pod("drained", map[string]string{"skip": "false"}))).To(Equal(kubedrain.MakePodDeleteStatusOkay()))
+	g.Expect(filter(pod("no-labels", nil))).To(Equal(kubedrain.MakePodDeleteStatusOkay()))
+}
+
+func TestGroupPodsByEvictionOrder(t *testing.T) {
+	stateless := labels.SelectorFromSet(labels.Set{"tier": "stateless"})
+	stateful := labels.SelectorFromSet(labels.Set{"tier": "stateful"})
+
+	tests := []struct {
+		name          string
+		pods          []corev1.Pod
+		evictionOrder []labels.Selector
+		want          [][]string
+	}{
+		{
+			name:          "no pods",
+			pods:          nil,
+			evictionOrder: []labels.Selector{stateless},
+			want:          [][]string{},
+		},
+		{
+			name: "no eviction order drains all the pods at once",
+			pods: []corev1.Pod{
+				pod("a", map[string]string{"tier": "stateful"}),
+				pod("b", nil),
+			},
+			want: [][]string{{"a", "b"}},
+		},
+		{
+			name: "pods are grouped by the first matching selector, unmatched pods are drained last",
+			pods: []corev1.Pod{
+				pod("a", map[string]string{"tier": "stateful"}),
+				pod("b", nil),
+				pod("c", map[string]string{"tier": "stateless"}),
+				pod("d", map[string]string{"tier": "stateful"}),
+			},
+			evictionOrder: []labels.Selector{stateless, stateful},
+			want:          [][]string{{"c"}, {"a", "d"}, {"b"}},
+		},
+		{
+			name: "empty groups are dropped",
+			pods: []corev1.Pod{
+				pod("a", map[string]string{"tier": "stateful"}),
+			},
+			evictionOrder: []labels.Selector{stateless, stateful},
+			want:          [][]string{{"a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := [][]string{}
+			for _, group := range groupPodsByEvictionOrder(tt.pods, tt.evictionOrder) {
+				names := []string{}
+				for _, p := range group {
+					names = append(names, p.Name)
+				}
+				got = append(got, names)
+			}
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func pod(name string, podLabels map[string]string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels:    podLabels,
+		},
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+// callBeforeMachineDrainHook calls the BeforeMachineDrain hook; if the hook is blocking, it returns
+// the duration after which the hook should be called again.
+func (r *Reconciler) callBeforeMachineDrainHook(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.Machine) (time.Duration, error) {
+	hookRequest := &runtimehooksv1.BeforeMachineDrainRequest{
+		Cluster: *cluster,
+		Machine: *m,
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineDrainResponse{}
+	return r.callMachineDeletionHook(ctx, runtimehooksv1.BeforeMachineDrain, cluster, hookRequest, hookResponse)
+}
+
+// callBeforeMachineDeleteHook calls the BeforeMachineDelete hook; if the hook is blocking, it returns
+// the duration after which the hook should be called again.
+func (r *Reconciler) callBeforeMachineDeleteHook(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.Machine) (time.Duration, error) {
+	hookRequest := &runtimehooksv1.BeforeMachineDeleteRequest{
+		Cluster: *cluster,
+		Machine: *m,
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineDeleteResponse{}
+	return r.callMachineDeletionHook(ctx, runtimehooksv1.BeforeMachineDelete, cluster, hookRequest, hookResponse)
+}
+
+func (r *Reconciler) callMachineDeletionHook(ctx context.Context, hook runtimecatalog.Hook, cluster *clusterv1.Cluster, hookRequest runtimehooksv1.RequestObject, hookResponse runtimehooksv1.RetryResponseObject) (time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !feature.Gates.Enabled(feature.RuntimeSDK) {
+		return 0, nil
+	}
+
+	if err := r.RuntimeClient.CallAllExtensions(ctx, hook, cluster, hookRequest, hookResponse); err != nil {
+		return 0, err
+	}
+	if hookResponse.GetRetryAfterSeconds() != 0 {
+		log.Info(fmt.Sprintf("Machine deletion is blocked by %q hook", runtimecatalog.HookName(hook)))
+		return time.Duration(hookResponse.GetRetryAfterSeconds()) * time.Second, nil
+	}
+	return 0, nil
+}
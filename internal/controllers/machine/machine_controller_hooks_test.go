@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileDeleteRuntimeHooks(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)()
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	beforeMachineDrainGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDrain)
+	if err != nil {
+		panic(err)
+	}
+	beforeMachineDeleteGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDelete)
+	if err != nil {
+		panic(err)
+	}
+
+	commonRetryResponse := func(retryAfterSeconds int32) runtimehooksv1.CommonRetryResponse {
+		return runtimehooksv1.CommonRetryResponse{
+			RetryAfterSeconds: retryAfterSeconds,
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusSuccess,
+			},
+		}
+	}
+
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
+	}
+	controlPlaneMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cp1",
+			Namespace: metav1.NamespaceDefault,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:         "test-cluster",
+				clusterv1.MachineControlPlaneLabel: "",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test-cluster",
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: "cp1-node"},
+		},
+	}
+	machine := func(nodeRef *corev1.ObjectReference, succeededConditions ...clusterv1.ConditionType) *clusterv1.Machine {
+		dt := metav1.Now()
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "delete123",
+				Namespace:         metav1.NamespaceDefault,
+				Labels:            map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+				Annotations:       map[string]string{clusterv1.ExcludeNodeDrainingAnnotation: ""},
+				Finalizers:        []string{clusterv1.MachineFinalizer},
+				DeletionTimestamp: &dt,
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: "test-cluster",
+				InfrastructureRef: corev1.ObjectReference{
+					APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+					Kind:       "GenericInfrastructureMachine",
+					Name:       "infra-config1",
+				},
+				Bootstrap: clusterv1.Bootstrap{DataSecretName: pointer.String("data")},
+			},
+			Status: clusterv1.MachineStatus{
+				NodeRef: nodeRef,
+			},
+		}
+		for _, condition := range succeededConditions {
+			conditions.MarkTrue(m, condition)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name                     string
+		machine                  *clusterv1.Machine
+		drainHookResponse        *runtimehooksv1.BeforeMachineDrainResponse
+		deleteHookResponse       *runtimehooksv1.BeforeMachineDeleteResponse
+		wantResult               ctrl.Result
+		wantDrainHookToBeCalled  bool
+		wantDeleteHookToBeCalled bool
+		wantBlockedCondition     clusterv1.ConditionType
+	}{
+		{
+			name:                    "should requeue if the BeforeMachineDrain hook returns a blocking response",
+			machine:                 machine(&corev1.ObjectReference{Name: "node1"}),
+			drainHookResponse:       &runtimehooksv1.BeforeMachineDrainResponse{CommonRetryResponse: commonRetryResponse(10)},
+			deleteHookResponse:      &runtimehooksv1.BeforeMachineDeleteResponse{CommonRetryResponse: commonRetryResponse(0)},
+			wantResult:              ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantDrainHookToBeCalled: true,
+			wantBlockedCondition:    clusterv1.PreDrainDeleteHookSucceededCondition,
+		},
+		{
+			name:                     "should not call the BeforeMachineDrain hook if the node is not going to be deleted",
+			machine:                  machine(nil),
+			drainHookResponse:        &runtimehooksv1.BeforeMachineDrainResponse{CommonRetryResponse: commonRetryResponse(10)},
+			deleteHookResponse:       &runtimehooksv1.BeforeMachineDeleteResponse{CommonRetryResponse: commonRetryResponse(0)},
+			wantResult:               ctrl.Result{},
+			wantDeleteHookToBeCalled: true,
+		},
+		{
+			name:                     "should requeue if the BeforeMachineDelete hook returns a blocking response",
+			machine:                  machine(nil),
+			drainHookResponse:        &runtimehooksv1.BeforeMachineDrainResponse{CommonRetryResponse: commonRetryResponse(0)},
+			deleteHookResponse:       &runtimehooksv1.BeforeMachineDeleteResponse{CommonRetryResponse: commonRetryResponse(20)},
+			wantResult:               ctrl.Result{RequeueAfter: 20 * time.Second},
+			wantDeleteHookToBeCalled: true,
+			wantBlockedCondition:     clusterv1.PreTerminateDeleteHookSucceededCondition,
+		},
+		{
+			name:               "should not call the BeforeMachineDelete hook again once it returned a non-blocking response",
+			machine:            machine(nil, clusterv1.PreTerminateDeleteHookSucceededCondition),
+			drainHookResponse:  &runtimehooksv1.BeforeMachineDrainResponse{CommonRetryResponse: commonRetryResponse(0)},
+			deleteHookResponse: &runtimehooksv1.BeforeMachineDeleteResponse{CommonRetryResponse: commonRetryResponse(20)},
+			wantResult:         ctrl.Result{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					beforeMachineDrainGVH:  tt.drainHookResponse,
+					beforeMachineDeleteGVH: tt.deleteHookResponse,
+				}).
+				WithCatalog(catalog).
+				Build()
+
+			c := fake.NewClientBuilder().WithObjects(testCluster, controlPlaneMachine, tt.machine).WithStatusSubresource(&clusterv1.Machine{}).Build()
+			r := &Reconciler{
+				Client:                    c,
+				UnstructuredCachingClient: c,
+				RuntimeClient:             fakeRuntimeClient,
+				recorder:                  record.NewFakeRecorder(32),
+			}
+
+			res, err := r.reconcileDelete(ctx, testCluster, tt.machine)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res).To(Equal(tt.wantResult))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.BeforeMachineDrain) == 1).To(Equal(tt.wantDrainHookToBeCalled))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.BeforeMachineDelete) == 1).To(Equal(tt.wantDeleteHookToBeCalled))
+			if tt.wantBlockedCondition != "" {
+				g.Expect(conditions.IsFalse(tt.machine, tt.wantBlockedCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(tt.machine, tt.wantBlockedCondition)).To(Equal(clusterv1.WaitingExternalHookReason))
+				g.Expect(tt.machine.Finalizers).To(ContainElement(clusterv1.MachineFinalizer))
+			} else {
+				g.Expect(tt.machine.Finalizers).ToNot(ContainElement(clusterv1.MachineFinalizer))
+			}
+		})
+	}
+}
@@ -740,6 +740,78 @@ func TestMachineSetToDeployments(t *testing.T) {
 	}
 }
 
+func TestMachineToDeployments(t *testing.T) {
+	g := NewWithT(t)
+
+	rollingOut := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rolling-out",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Status: clusterv1.MachineDeploymentStatus{
+			Rollout: &clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new-ms"},
+		},
+	}
+	notRollingOut := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "not-rolling-out",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	newMachine := func(mdName string) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "machine",
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{},
+			},
+		}
+		if mdName != "" {
+			m.Labels[clusterv1.MachineDeploymentNameLabel] = mdName
+		}
+		return m
+	}
+
+	testsCases := []struct {
+		name      string
+		mapObject client.Object
+		expected  []reconcile.Request
+	}{
+		{
+			name:      "enqueues the MachineDeployment of the Machine while rolling out using steps",
+			mapObject: newMachine(rollingOut.Name),
+			expected: []reconcile.Request{
+				{NamespacedName: client.ObjectKeyFromObject(rollingOut)},
+			},
+		},
+		{
+			name:      "does not enqueue the MachineDeployment of the Machine if not rolling out using steps",
+			mapObject: newMachine(notRollingOut.Name),
+			expected:  nil,
+		},
+		{
+			name:      "does not enqueue anything if the MachineDeployment of the Machine does not exist",
+			mapObject: newMachine("does-not-exist"),
+			expected:  nil,
+		},
+		{
+			name:      "does not enqueue anything for Machines not belonging to a MachineDeployment",
+			mapObject: newMachine(""),
+			expected:  nil,
+		},
+	}
+
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithObjects(rollingOut, notRollingOut).Build(),
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	for _, tc := range testsCases {
+		got := r.MachineToDeployments(ctx, tc.mapObject)
+		g.Expect(got).To(BeComparableTo(tc.expected), tc.name)
+	}
+}
+
 func TestGetMachineDeploymentsForMachineSet(t *testing.T) {
 	g := NewWithT(t)
 
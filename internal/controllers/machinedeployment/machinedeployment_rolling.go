@@ -30,46 +30,57 @@ import (
 )
 
 // rolloutRolling implements the logic for rolling a new MachineSet.
-func (r *Reconciler) rolloutRolling(ctx context.Context, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) error {
+func (r *Reconciler) rolloutRolling(ctx context.Context, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) (ctrl.Result, error) {
 	newMS, oldMSs, err := r.getAllMachineSetsAndSyncRevision(ctx, md, msList, true)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// newMS can be nil in case there is already a MachineSet associated with this deployment,
 	// but there are only either changes in annotations or MinReadySeconds. Or in other words,
 	// this can be nil if there are changes, but no replacement of existing machines is needed.
 	if newMS == nil {
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	allMSs := append(oldMSs, newMS)
 
+	// Move through the steps of the rollout, if any; the current step limits how far
+	// the new MachineSet can be scaled up and the old MachineSets can be scaled down.
+	result, err := r.reconcileRolloutSteps(ctx, md, newMS, oldMSs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if md.Status.Rollout != nil && md.Status.Rollout.Aborted {
+		return result, r.syncDeploymentStatus(allMSs, newMS, md)
+	}
+
 	// Scale up, if we can.
 	if err := r.reconcileNewMachineSet(ctx, allMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// Scale down, if we can.
 	if err := r.reconcileOldMachineSets(ctx, allMSs, oldMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if mdutil.DeploymentComplete(md, &md.Status) {
 		if err := r.cleanupDeployment(ctx, oldMSs, md); err != nil {
-			return err
+			return ctrl.Result{}, err
 		}
 	}
 
-	return nil
+	return result, nil
 }
 
 func (r *Reconciler) reconcileNewMachineSet(ctx context.Context, allMSs []*clusterv1.MachineSet, newMS *clusterv1.MachineSet, deployment *clusterv1.MachineDeployment) error {
@@ -95,6 +106,10 @@ func (r *Reconciler) reconcileNewMachineSet(ctx context.Context, allMSs []*clust
 	if err != nil {
 		return err
 	}
+	if maxReplicas, ok := mdutil.RolloutStepMaxReplicas(deployment, newMS.Name); ok && newReplicasCount > maxReplicas {
+		// Do not exceed the replicas allowed by the current step of the rollout.
+		newReplicasCount = integer.Int32Max(maxReplicas, *newMS.Spec.Replicas)
+	}
 	return r.scaleMachineSet(ctx, newMS, newReplicasCount, deployment)
 }
 
@@ -155,6 +170,10 @@ func (r *Reconciler) reconcileOldMachineSets(ctx context.Context, allMSs []*clus
 	minAvailable := *(deployment.Spec.Replicas) - maxUnavailable
 	newMSUnavailableMachineCount := *(newMS.Spec.Replicas) - newMS.Status.AvailableReplicas
 	maxScaledDown := allMachinesCount - minAvailable - newMSUnavailableMachineCount
+	if maxReplicas, ok := mdutil.RolloutStepMaxReplicas(deployment, newMS.Name); ok {
+		// Do not scale down old MachineSets below the replicas required by the current step of the rollout.
+		maxScaledDown = integer.Int32Min(maxScaledDown, oldMachinesCount-(*(deployment.Spec.Replicas)-maxReplicas))
+	}
 	if maxScaledDown <= 0 {
 		return nil
 	}
@@ -171,7 +190,7 @@ func (r *Reconciler) reconcileOldMachineSets(ctx context.Context, allMSs []*clus
 	// Scale down old MachineSets, need check maxUnavailable to ensure we can scale down
 	allMSs = oldMSs
 	allMSs = append(allMSs, newMS)
-	scaledDownCount, err := r.scaleDownOldMachineSetsForRollingUpdate(ctx, allMSs, oldMSs, deployment)
+	scaledDownCount, err := r.scaleDownOldMachineSetsForRollingUpdate(ctx, allMSs, oldMSs, newMS, deployment)
 	if err != nil {
 		return err
 	}
@@ -237,7 +256,7 @@ func (r *Reconciler) cleanupUnhealthyReplicas(ctx context.Context, oldMSs []*clu
 
 // scaleDownOldMachineSetsForRollingUpdate scales down old MachineSets when deployment strategy is "RollingUpdate".
 // Need check maxUnavailable to ensure availability.
-func (r *Reconciler) scaleDownOldMachineSetsForRollingUpdate(ctx context.Context, allMSs []*clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet, deployment *clusterv1.MachineDeployment) (int32, error) {
+func (r *Reconciler) scaleDownOldMachineSetsForRollingUpdate(ctx context.Context, allMSs []*clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet, newMS *clusterv1.MachineSet, deployment *clusterv1.MachineDeployment) (int32, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	if deployment.Spec.Replicas == nil {
@@ -262,6 +281,11 @@ func (r *Reconciler) scaleDownOldMachineSetsForRollingUpdate(ctx context.Context
 
 	totalScaledDown := int32(0)
 	totalScaleDownCount := availableMachineCount - minAvailable
+	if maxReplicas, ok := mdutil.RolloutStepMaxReplicas(deployment, newMS.Name); ok {
+		// Do not scale down old MachineSets below the replicas required by the current step of the rollout.
+		oldMachinesCount := mdutil.GetReplicaCountForMachineSets(oldMSs)
+		totalScaleDownCount = integer.Int32Min(totalScaleDownCount, oldMachinesCount-(*(deployment.Spec.Replicas)-maxReplicas))
+	}
 	for _, targetMS := range oldMSs {
 		if targetMS.Spec.Replicas == nil {
 			return 0, errors.Errorf("spec.replicas for MachineSet %v is nil, this is unexpected", client.ObjectKeyFromObject(targetMS))
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+)
+
+// rolloutBlueGreen implements the logic for the BlueGreen MachineDeploymentStrategyType.
+func (r *Reconciler) rolloutBlueGreen(ctx context.Context, cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) (ctrl.Result, error) {
+	newMS, oldMSs, err := r.getAllMachineSetsAndSyncRevision(ctx, md, msList, true)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// newMS can be nil in case there is already a MachineSet associated with this deployment,
+	// but there are only either changes in annotations or MinReadySeconds. Or in other words,
+	// this can be nil if there are changes, but no replacement of existing machines is needed.
+	if newMS == nil {
+		return ctrl.Result{}, nil
+	}
+
+	allMSs := append(oldMSs, newMS)
+
+	// Scale up the new MachineSet to the desired number of replicas in one step.
+	if err := r.reconcileNewMachineSet(ctx, allMSs, newMS, md); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Scale down the old MachineSets, if the new MachineSet is ready to take over.
+	result, err := r.reconcileCutOver(ctx, cluster, md, newMS, oldMSs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if mdutil.DeploymentComplete(md, &md.Status) {
+		if err := r.cleanupDeployment(ctx, oldMSs, md); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// reconcileCutOver scales down all the old MachineSets to zero in one step once all the machines
+// of the new MachineSet are available and the cut-over gate, if any, has passed.
+func (r *Reconciler) reconcileCutOver(ctx context.Context, cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx, "MachineSet", klog.KObj(newMS))
+
+	if mdutil.GetReplicaCountForMachineSets(oldMSs) == 0 {
+		// Nothing to cut over.
+		return ctrl.Result{}, nil
+	}
+
+	if md.Spec.Replicas == nil {
+		return ctrl.Result{}, errors.Errorf("spec.replicas for MachineDeployment %v is nil, this is unexpected", client.ObjectKeyFromObject(md))
+	}
+
+	if newMS.Spec.Replicas == nil {
+		return ctrl.Result{}, errors.Errorf("spec.replicas for MachineSet %v is nil, this is unexpected", client.ObjectKeyFromObject(newMS))
+	}
+
+	// Wait for all the machines of the new MachineSet to be available.
+	if *newMS.Spec.Replicas != *md.Spec.Replicas ||
+		newMS.Status.ObservedGeneration < newMS.Generation ||
+		newMS.Status.AvailableReplicas < *md.Spec.Replicas {
+		log.V(4).Info("Waiting for all the machines of the new MachineSet to be available before cutting over",
+			"available-replicas", newMS.Status.AvailableReplicas, "desired-replicas", *md.Spec.Replicas)
+		return ctrl.Result{}, nil
+	}
+
+	allowed, requeueAfter, err := r.isCutOverAllowed(ctx, cluster, md, newMS)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !allowed {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	for _, oldMS := range oldMSs {
+		if oldMS.Spec.Replicas == nil || *oldMS.Spec.Replicas <= 0 {
+			continue
+		}
+		log.V(4).Info("Scaling down old MachineSet", "old-machineset", klog.KObj(oldMS))
+		if err := r.scaleMachineSet(ctx, oldMS, 0, md); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	log.Info("Cut over to the new MachineSet")
+	r.recorder.Eventf(md, corev1.EventTypeNormal, "SuccessfulCutOver", "Cut over to MachineSet %v", client.ObjectKeyFromObject(newMS))
+	return ctrl.Result{}, nil
+}
+
+// isCutOverAllowed checks the cut-over gate of a MachineDeployment, if any.
+// If the cut-over is not allowed, it also returns the duration after which the gate should be checked again, if any.
+func (r *Reconciler) isCutOverAllowed(ctx context.Context, cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet) (bool, time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx, "MachineSet", klog.KObj(newMS))
+
+	if md.Spec.Strategy.BlueGreen == nil || md.Spec.Strategy.BlueGreen.CutOverGate == nil {
+		return true, 0, nil
+	}
+
+	switch gateType := md.Spec.Strategy.BlueGreen.CutOverGate.Type; gateType {
+	case clusterv1.AnnotationCutOverGateType:
+		if md.Annotations[clusterv1.CutOverAnnotation] != newMS.Name {
+			// NOTE: Setting the annotation triggers a new reconcile of the MachineDeployment, so there is no need to requeue.
+			log.Info("Cut-over to the new MachineSet is waiting for the cut-over annotation", "annotation", clusterv1.CutOverAnnotation)
+			return false, 0, nil
+		}
+		return true, 0, nil
+	case clusterv1.RuntimeHookCutOverGateType:
+		if !feature.Gates.Enabled(feature.RuntimeSDK) {
+			return false, 0, errors.Errorf("the %s cut-over gate requires the RuntimeSDK feature flag to be enabled", gateType)
+		}
+		hookRequest := &runtimehooksv1.BeforeMachineDeploymentCutOverRequest{
+			Cluster:           *cluster,
+			MachineDeployment: *md,
+			NewMachineSetName: newMS.Name,
+		}
+		hookResponse := &runtimehooksv1.BeforeMachineDeploymentCutOverResponse{}
+		if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.BeforeMachineDeploymentCutOver, cluster, hookRequest, hookResponse); err != nil {
+			return false, 0, err
+		}
+		if hookResponse.RetryAfterSeconds != 0 {
+			log.Info(fmt.Sprintf("Cut-over to the new MachineSet is blocked by %q hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineDeploymentCutOver)))
+			return false, time.Duration(hookResponse.RetryAfterSeconds) * time.Second, nil
+		}
+		return true, 0, nil
+	default:
+		return false, 0, errors.Errorf("unexpected cut-over gate type: %s", gateType)
+	}
+}
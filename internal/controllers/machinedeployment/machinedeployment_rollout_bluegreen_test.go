@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+)
+
+func TestReconcileCutOver(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)()
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	beforeMachineDeploymentCutOverGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineDeploymentCutOver)
+	if err != nil {
+		panic(err)
+	}
+
+	blockingResponse := &runtimehooksv1.BeforeMachineDeploymentCutOverResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			RetryAfterSeconds: int32(10),
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusSuccess,
+			},
+		},
+	}
+	nonBlockingResponse := &runtimehooksv1.BeforeMachineDeploymentCutOverResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			RetryAfterSeconds: int32(0),
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusSuccess,
+			},
+		},
+	}
+
+	machineDeployment := func(gate *clusterv1.MachineDeploymentCutOverGate, annotations map[string]string) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "foo",
+				Name:        "bar",
+				Annotations: annotations,
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: pointer.Int32(3),
+				Strategy: &clusterv1.MachineDeploymentStrategy{
+					Type: clusterv1.BlueGreenMachineDeploymentStrategyType,
+					BlueGreen: &clusterv1.MachineBlueGreenDeployment{
+						CutOverGate: gate,
+					},
+				},
+			},
+		}
+	}
+	machineSet := func(name string, replicas, availableReplicas int32) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      name,
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: pointer.Int32(replicas),
+			},
+			Status: clusterv1.MachineSetStatus{
+				AvailableReplicas: availableReplicas,
+			},
+		}
+	}
+
+	tests := []struct {
+		name                    string
+		machineDeployment       *clusterv1.MachineDeployment
+		newMachineSet           *clusterv1.MachineSet
+		hookResponse            *runtimehooksv1.BeforeMachineDeploymentCutOverResponse
+		wantResult              ctrl.Result
+		wantHookToBeCalled      bool
+		wantOldMachineSetScaled bool
+	}{
+		{
+			name:              "should wait for all the machines of the new MachineSet to be available",
+			machineDeployment: machineDeployment(nil, nil),
+			newMachineSet:     machineSet("new", 3, 2),
+		},
+		{
+			name:                    "should cut over if all the machines of the new MachineSet are available and there is no gate",
+			machineDeployment:       machineDeployment(nil, nil),
+			newMachineSet:           machineSet("new", 3, 3),
+			wantOldMachineSetScaled: true,
+		},
+		{
+			name:              "should wait for the cut-over annotation",
+			machineDeployment: machineDeployment(&clusterv1.MachineDeploymentCutOverGate{Type: clusterv1.AnnotationCutOverGateType}, nil),
+			newMachineSet:     machineSet("new", 3, 3),
+		},
+		{
+			name: "should wait for the cut-over annotation to match the new MachineSet",
+			machineDeployment: machineDeployment(&clusterv1.MachineDeploymentCutOverGate{Type: clusterv1.AnnotationCutOverGateType},
+				map[string]string{clusterv1.CutOverAnnotation: "previous"}),
+			newMachineSet: machineSet("new", 3, 3),
+		},
+		{
+			name: "should cut over if the cut-over annotation matches the new MachineSet",
+			machineDeployment: machineDeployment(&clusterv1.MachineDeploymentCutOverGate{Type: clusterv1.AnnotationCutOverGateType},
+				map[string]string{clusterv1.CutOverAnnotation: "new"}),
+			newMachineSet:           machineSet("new", 3, 3),
+			wantOldMachineSetScaled: true,
+		},
+		{
+			name:               "should requeue if the BeforeMachineDeploymentCutOver hook returns a blocking response",
+			machineDeployment:  machineDeployment(&clusterv1.MachineDeploymentCutOverGate{Type: clusterv1.RuntimeHookCutOverGateType}, nil),
+			newMachineSet:      machineSet("new", 3, 3),
+			hookResponse:       blockingResponse,
+			wantResult:         ctrl.Result{RequeueAfter: time.Duration(10) * time.Second},
+			wantHookToBeCalled: true,
+		},
+		{
+			name:                    "should cut over if the BeforeMachineDeploymentCutOver hook returns a non-blocking response",
+			machineDeployment:       machineDeployment(&clusterv1.MachineDeploymentCutOverGate{Type: clusterv1.RuntimeHookCutOverGateType}, nil),
+			newMachineSet:           machineSet("new", 3, 3),
+			hookResponse:            nonBlockingResponse,
+			wantHookToBeCalled:      true,
+			wantOldMachineSetScaled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			oldMachineSet := machineSet("old", 3, 3)
+			fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					beforeMachineDeploymentCutOverGVH: tt.hookResponse,
+				}).
+				WithCatalog(catalog).
+				Build()
+
+			r := &Reconciler{
+				Client:        fake.NewClientBuilder().WithObjects(tt.machineDeployment, tt.newMachineSet, oldMachineSet).Build(),
+				RuntimeClient: fakeRuntimeClient,
+				recorder:      record.NewFakeRecorder(32),
+			}
+
+			res, err := r.reconcileCutOver(ctx, &clusterv1.Cluster{}, tt.machineDeployment, tt.newMachineSet, []*clusterv1.MachineSet{oldMachineSet})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res).To(Equal(tt.wantResult))
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.BeforeMachineDeploymentCutOver) == 1).To(Equal(tt.wantHookToBeCalled))
+
+			freshOldMachineSet := &clusterv1.MachineSet{}
+			g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(oldMachineSet), freshOldMachineSet)).To(Succeed())
+			if tt.wantOldMachineSetScaled {
+				g.Expect(*freshOldMachineSet.Spec.Replicas).To(BeEquivalentTo(0))
+			} else {
+				g.Expect(*freshOldMachineSet.Spec.Replicas).To(BeEquivalentTo(3))
+			}
+		})
+	}
+}
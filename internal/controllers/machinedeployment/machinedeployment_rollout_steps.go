@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileRolloutSteps moves a rollout using steps through its steps, and keeps track of the progress in
+// the MachineDeployment status. The rolling update is not allowed to go past the current step; if machines
+// of the new MachineSet are reported unhealthy before all the steps have been completed, the rollout is aborted.
+func (r *Reconciler) reconcileRolloutSteps(ctx context.Context, md *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx, "MachineSet", klog.KObj(newMS))
+
+	steps := md.Spec.Strategy.Steps
+	if len(steps) == 0 {
+		md.Status.Rollout = nil
+		return ctrl.Result{}, nil
+	}
+
+	if md.Spec.Replicas == nil {
+		return ctrl.Result{}, errors.Errorf("spec.replicas for MachineDeployment %v is nil, this is unexpected", client.ObjectKeyFromObject(md))
+	}
+
+	if newMS.Spec.Replicas == nil {
+		return ctrl.Result{}, errors.Errorf("spec.replicas for MachineSet %v is nil, this is unexpected", client.ObjectKeyFromObject(newMS))
+	}
+
+	// Start tracking the rollout of a new MachineSet; if there are no old machines to replace,
+	// e.g. when the MachineDeployment is created, the steps are skipped.
+	if md.Status.Rollout == nil || md.Status.Rollout.MachineSetName != newMS.Name {
+		md.Status.Rollout = &clusterv1.MachineDeploymentRolloutStatus{MachineSetName: newMS.Name}
+		if mdutil.GetReplicaCountForMachineSets(oldMSs) == 0 {
+			md.Status.Rollout.CurrentStepIndex = int32(len(steps))
+		}
+	}
+	rollout := md.Status.Rollout
+
+	if rollout.Aborted {
+		if !consumeRolloutResumeAnnotation(md) {
+			return ctrl.Result{}, r.abortRollout(ctx, md, newMS, oldMSs)
+		}
+		log.Info("Restarting aborted rollout")
+		rollout.Aborted = false
+		rollout.CurrentStepIndex = 0
+		rollout.PausedAt = nil
+	}
+
+	if int(rollout.CurrentStepIndex) >= len(steps) {
+		return ctrl.Result{}, nil
+	}
+
+	unhealthy, err := r.getUnhealthyMachineNames(ctx, newMS)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(unhealthy) > 0 {
+		log.Info("Aborting rollout, machines of the new MachineSet have been reported unhealthy", "machines", unhealthy)
+		r.recorder.Eventf(md, corev1.EventTypeWarning, "RolloutAborted", "Aborted rollout of MachineSet %v at step %d: machines %v have been reported unhealthy",
+			client.ObjectKeyFromObject(newMS), rollout.CurrentStepIndex, unhealthy)
+		rollout.Aborted = true
+		rollout.PausedAt = nil
+		return ctrl.Result{}, r.abortRollout(ctx, md, newMS, oldMSs)
+	}
+
+	for int(rollout.CurrentStepIndex) < len(steps) {
+		step := steps[rollout.CurrentStepIndex]
+		switch {
+		case step.SetWeight != nil:
+			maxReplicas, _ := mdutil.RolloutStepMaxReplicas(md, newMS.Name)
+			if *newMS.Spec.Replicas < maxReplicas ||
+				newMS.Status.AvailableReplicas < maxReplicas ||
+				mdutil.GetReplicaCountForMachineSets(oldMSs) > *(md.Spec.Replicas)-maxReplicas {
+				// Let the rolling update make progress towards the weight of the current step.
+				return ctrl.Result{}, nil
+			}
+		case step.Pause != nil:
+			if rollout.PausedAt == nil {
+				now := metav1.Now()
+				rollout.PausedAt = &now
+				log.Info("Pausing rollout", "step", rollout.CurrentStepIndex)
+			}
+			if !consumeRolloutResumeAnnotation(md) {
+				if step.Pause.Duration == nil {
+					// NOTE: Setting the resume annotation triggers a new reconcile of the MachineDeployment, so there is no need to requeue.
+					return ctrl.Result{}, nil
+				}
+				if remaining := time.Until(rollout.PausedAt.Add(step.Pause.Duration.Duration)); remaining > 0 {
+					return ctrl.Result{RequeueAfter: remaining}, nil
+				}
+			}
+			rollout.PausedAt = nil
+		}
+		rollout.CurrentStepIndex++
+		log.V(4).Info("Moving rollout to the next step", "step", rollout.CurrentStepIndex)
+	}
+
+	log.Info("Completed all the steps of the rollout")
+	return ctrl.Result{}, nil
+}
+
+// consumeRolloutResumeAnnotation returns true if the MachineDeployment has the resume annotation for the
+// current step of the rollout; in this case the annotation is removed.
+// NOTE: The MachineDeployment is patched at the end of the reconcile.
+func consumeRolloutResumeAnnotation(md *clusterv1.MachineDeployment) bool {
+	value, ok := md.Annotations[clusterv1.RolloutResumeAnnotation]
+	if !ok || value != strconv.Itoa(int(md.Status.Rollout.CurrentStepIndex)) {
+		return false
+	}
+	delete(md.Annotations, clusterv1.RolloutResumeAnnotation)
+	return true
+}
+
+// getUnhealthyMachineNames returns the names of the machines of a MachineSet reported unhealthy by a MachineHealthCheck.
+func (r *Reconciler) getUnhealthyMachineNames(ctx context.Context, ms *clusterv1.MachineSet) ([]string, error) {
+	machines := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machines, client.InNamespace(ms.Namespace), client.MatchingLabels(ms.Spec.Selector.MatchLabels)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list machines of MachineSet %s", klog.KObj(ms))
+	}
+
+	unhealthy := []string{}
+	for i := range machines.Items {
+		if conditions.IsFalse(&machines.Items[i], clusterv1.MachineHealthCheckSucceededCondition) {
+			unhealthy = append(unhealthy, machines.Items[i].Name)
+		}
+	}
+	sort.Strings(unhealthy)
+	return unhealthy, nil
+}
+
+// abortRollout replaces the machines of the new MachineSet with machines of the old MachineSets.
+// The newest old MachineSet is scaled up first, and the new MachineSet is scaled down as machines
+// of the old MachineSet become available, honoring MaxUnavailable like a rolling update with the
+// roles of the new and old MachineSets swapped.
+func (r *Reconciler) abortRollout(ctx context.Context, md *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet) error {
+	log := ctrl.LoggerFrom(ctx, "MachineSet", klog.KObj(newMS))
+
+	if len(oldMSs) == 0 {
+		log.Info("Cannot roll back aborted rollout, there are no old MachineSets")
+		return nil
+	}
+
+	sort.Sort(mdutil.MachineSetsByCreationTimestamp(oldMSs))
+	targetMS := oldMSs[len(oldMSs)-1]
+	if targetMS.Spec.Replicas == nil {
+		return errors.Errorf("spec.replicas for MachineSet %v is nil, this is unexpected", client.ObjectKeyFromObject(targetMS))
+	}
+
+	otherOldMachinesCount := mdutil.GetReplicaCountForMachineSets(oldMSs) - *(targetMS.Spec.Replicas)
+	if replicas := *(md.Spec.Replicas) - otherOldMachinesCount; replicas > *(targetMS.Spec.Replicas) {
+		log.V(4).Info("Scaling up old MachineSet", "old-machineset", klog.KObj(targetMS))
+		if err := r.scaleMachineSet(ctx, targetMS, replicas, md); err != nil {
+			return err
+		}
+	}
+
+	if *(newMS.Spec.Replicas) == 0 {
+		return nil
+	}
+
+	// Scale down the new MachineSet as if it were an old MachineSet being replaced by the newest old MachineSet.
+	// NOTE: The steps are dropped from the copy of the MachineDeployment used for scaling down, so the new MachineSet
+	// is not kept at the replicas of the current step of the aborted rollout.
+	rollbackMD := md.DeepCopy()
+	rollbackMD.Spec.Strategy.Steps = nil
+	allMSs := append(oldMSs, newMS)
+	return r.reconcileOldMachineSets(ctx, allMSs, []*clusterv1.MachineSet{newMS}, targetMS, rollbackMD)
+}
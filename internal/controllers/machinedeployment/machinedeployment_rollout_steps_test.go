@@ -0,0 +1,256 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileRolloutSteps(t *testing.T) {
+	steps := []clusterv1.MachineDeploymentRolloutStep{
+		{SetWeight: pointer.Int32(20)},
+		{Pause: &clusterv1.MachineDeploymentRolloutPause{}},
+		{Pause: &clusterv1.MachineDeploymentRolloutPause{Duration: &metav1.Duration{Duration: time.Hour}}},
+		{SetWeight: pointer.Int32(60)},
+	}
+
+	machineDeployment := func(rollout *clusterv1.MachineDeploymentRolloutStatus, annotations map[string]string) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "foo",
+				Name:        "bar",
+				Annotations: annotations,
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: pointer.Int32(5),
+				Strategy: &clusterv1.MachineDeploymentStrategy{
+					Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+					RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+						MaxSurge:       intOrStrPtr(1),
+						MaxUnavailable: intOrStrPtr(1),
+					},
+					Steps: steps,
+				},
+			},
+			Status: clusterv1.MachineDeploymentStatus{
+				Rollout: rollout,
+			},
+		}
+	}
+	machineSet := func(name string, replicas, availableReplicas int32) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "foo",
+				Name:              name,
+				CreationTimestamp: metav1.Now(),
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: pointer.Int32(replicas),
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"machineset": name},
+				},
+			},
+			Status: clusterv1.MachineSetStatus{
+				AvailableReplicas: availableReplicas,
+			},
+		}
+	}
+	machine := func(name, machineSetName string, healthy bool) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      name,
+				Labels:    map[string]string{"machineset": machineSetName},
+			},
+		}
+		if healthy {
+			conditions.MarkTrue(m, clusterv1.MachineHealthCheckSucceededCondition)
+		} else {
+			conditions.MarkFalse(m, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityWarning, "")
+		}
+		return m
+	}
+	pausedAt := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(time.Now().Add(-d))
+		return &t
+	}
+
+	tests := []struct {
+		name                      string
+		machineDeployment         *clusterv1.MachineDeployment
+		newMachineSet             *clusterv1.MachineSet
+		oldMachineSet             *clusterv1.MachineSet
+		machines                  []client.Object
+		wantStepIndex             int32
+		wantPaused                bool
+		wantAborted               bool
+		wantRequeue               bool
+		wantResumeAnnotation      bool
+		wantNewMachineSetReplicas int32
+		wantOldMachineSetReplicas int32
+	}{
+		{
+			name:                      "should skip the steps if there are no old machines to replace",
+			machineDeployment:         machineDeployment(nil, nil),
+			newMachineSet:             machineSet("new", 5, 5),
+			oldMachineSet:             machineSet("old", 0, 0),
+			wantStepIndex:             4,
+			wantNewMachineSetReplicas: 5,
+			wantOldMachineSetReplicas: 0,
+		},
+		{
+			name:                      "should wait for the machines of the new MachineSet to be available",
+			machineDeployment:         machineDeployment(nil, nil),
+			newMachineSet:             machineSet("new", 1, 0),
+			oldMachineSet:             machineSet("old", 4, 4),
+			wantStepIndex:             0,
+			wantNewMachineSetReplicas: 1,
+			wantOldMachineSetReplicas: 4,
+		},
+		{
+			name:                      "should move to a pause step once the weight of the current step is reached",
+			machineDeployment:         machineDeployment(nil, nil),
+			newMachineSet:             machineSet("new", 1, 1),
+			oldMachineSet:             machineSet("old", 4, 4),
+			machines:                  []client.Object{machine("m1", "new", true)},
+			wantStepIndex:             1,
+			wantPaused:                true,
+			wantNewMachineSetReplicas: 1,
+			wantOldMachineSetReplicas: 4,
+		},
+		{
+			name: "should resume the rollout if the resume annotation matches the current step",
+			machineDeployment: machineDeployment(&clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 1, PausedAt: pausedAt(time.Minute)},
+				map[string]string{clusterv1.RolloutResumeAnnotation: "1"}),
+			newMachineSet:             machineSet("new", 1, 1),
+			oldMachineSet:             machineSet("old", 4, 4),
+			wantStepIndex:             2,
+			wantPaused:                true,
+			wantRequeue:               true,
+			wantNewMachineSetReplicas: 1,
+			wantOldMachineSetReplicas: 4,
+		},
+		{
+			name: "should not resume the rollout if the resume annotation does not match the current step",
+			machineDeployment: machineDeployment(&clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 1, PausedAt: pausedAt(time.Minute)},
+				map[string]string{clusterv1.RolloutResumeAnnotation: "0"}),
+			newMachineSet:             machineSet("new", 1, 1),
+			oldMachineSet:             machineSet("old", 4, 4),
+			wantStepIndex:             1,
+			wantPaused:                true,
+			wantResumeAnnotation:      true,
+			wantNewMachineSetReplicas: 1,
+			wantOldMachineSetReplicas: 4,
+		},
+		{
+			name:                      "should move to the next step once the duration of the pause has elapsed",
+			machineDeployment:         machineDeployment(&clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 2, PausedAt: pausedAt(2 * time.Hour)}, nil),
+			newMachineSet:             machineSet("new", 1, 1),
+			oldMachineSet:             machineSet("old", 4, 4),
+			wantStepIndex:             3,
+			wantNewMachineSetReplicas: 1,
+			wantOldMachineSetReplicas: 4,
+		},
+		{
+			name:                      "should abort the rollout if machines of the new MachineSet are unhealthy",
+			machineDeployment:         machineDeployment(&clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 3}, nil),
+			newMachineSet:             machineSet("new", 3, 2),
+			oldMachineSet:             machineSet("old", 2, 2),
+			machines:                  []client.Object{machine("m1", "new", true), machine("m2", "new", false)},
+			wantStepIndex:             3,
+			wantAborted:               true,
+			wantNewMachineSetReplicas: 2,
+			wantOldMachineSetReplicas: 5,
+		},
+		{
+			name:                      "should scale down the new MachineSet of an aborted rollout only as machines of the old MachineSet become available",
+			machineDeployment:         machineDeployment(&clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 3, Aborted: true}, nil),
+			newMachineSet:             machineSet("new", 2, 2),
+			oldMachineSet:             machineSet("old", 5, 3),
+			wantStepIndex:             3,
+			wantAborted:               true,
+			wantNewMachineSetReplicas: 1,
+			wantOldMachineSetReplicas: 5,
+		},
+		{
+			name:                      "should scale down the new MachineSet of an aborted rollout to zero once all the machines of the old MachineSet are available",
+			machineDeployment:         machineDeployment(&clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 3, Aborted: true}, nil),
+			newMachineSet:             machineSet("new", 2, 2),
+			oldMachineSet:             machineSet("old", 5, 5),
+			wantStepIndex:             3,
+			wantAborted:               true,
+			wantNewMachineSetReplicas: 0,
+			wantOldMachineSetReplicas: 5,
+		},
+		{
+			name: "should restart an aborted rollout if the resume annotation matches the current step",
+			machineDeployment: machineDeployment(&clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 3, Aborted: true},
+				map[string]string{clusterv1.RolloutResumeAnnotation: "3"}),
+			newMachineSet:             machineSet("new", 0, 0),
+			oldMachineSet:             machineSet("old", 5, 5),
+			wantStepIndex:             0,
+			wantNewMachineSetReplicas: 0,
+			wantOldMachineSetReplicas: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := append([]client.Object{tt.machineDeployment, tt.newMachineSet, tt.oldMachineSet}, tt.machines...)
+			r := &Reconciler{
+				Client:   fake.NewClientBuilder().WithObjects(objs...).Build(),
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			res, err := r.reconcileRolloutSteps(ctx, tt.machineDeployment, tt.newMachineSet, []*clusterv1.MachineSet{tt.oldMachineSet})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.RequeueAfter > 0).To(Equal(tt.wantRequeue))
+
+			rollout := tt.machineDeployment.Status.Rollout
+			g.Expect(rollout).ToNot(BeNil())
+			g.Expect(rollout.MachineSetName).To(Equal("new"))
+			g.Expect(rollout.CurrentStepIndex).To(Equal(tt.wantStepIndex))
+			g.Expect(rollout.PausedAt != nil).To(Equal(tt.wantPaused))
+			g.Expect(rollout.Aborted).To(Equal(tt.wantAborted))
+			if tt.wantResumeAnnotation {
+				g.Expect(tt.machineDeployment.Annotations).To(HaveKey(clusterv1.RolloutResumeAnnotation))
+			} else {
+				g.Expect(tt.machineDeployment.Annotations).ToNot(HaveKey(clusterv1.RolloutResumeAnnotation))
+			}
+
+			freshNewMachineSet := &clusterv1.MachineSet{}
+			g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(tt.newMachineSet), freshNewMachineSet)).To(Succeed())
+			g.Expect(*freshNewMachineSet.Spec.Replicas).To(Equal(tt.wantNewMachineSetReplicas))
+			freshOldMachineSet := &clusterv1.MachineSet{}
+			g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(tt.oldMachineSet), freshOldMachineSet)).To(Succeed())
+			g.Expect(*freshOldMachineSet.Spec.Replicas).To(Equal(tt.wantOldMachineSetReplicas))
+		})
+	}
+}
@@ -253,6 +253,10 @@ func (r *Reconciler) computeDesiredMachineSet(deployment *clusterv1.MachineDeplo
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to compute desired MachineSet")
 		}
+		if maxReplicas, ok := mdutil.RolloutStepMaxReplicas(deployment, name); ok && mdutil.GetReplicaCountForMachineSets(oldMSs) > 0 && replicas > maxReplicas {
+			// Do not exceed the replicas allowed by the first step of the rollout.
+			replicas = maxReplicas
+		}
 
 		machineTemplateSpec = *deployment.Spec.Template.Spec.DeepCopy()
 	} else {
@@ -343,6 +347,7 @@ func (r *Reconciler) computeDesiredMachineSet(deployment *clusterv1.MachineDeplo
 	desiredMS.Spec.Template.Spec.NodeDrainTimeout = deployment.Spec.Template.Spec.NodeDrainTimeout
 	desiredMS.Spec.Template.Spec.NodeDeletionTimeout = deployment.Spec.Template.Spec.NodeDeletionTimeout
 	desiredMS.Spec.Template.Spec.NodeVolumeDetachTimeout = deployment.Spec.Template.Spec.NodeVolumeDetachTimeout
+	desiredMS.Spec.Template.Spec.NodeDrainPolicy = deployment.Spec.Template.Spec.NodeDrainPolicy
 
 	return desiredMS, nil
 }
@@ -527,6 +532,7 @@ func calculateStatus(allMSs []*clusterv1.MachineSet, newMS *clusterv1.MachineSet
 		ReadyReplicas:       mdutil.GetReadyReplicaCountForMachineSets(allMSs),
 		AvailableReplicas:   availableReplicas,
 		UnavailableReplicas: unavailableReplicas,
+		Rollout:             deployment.Status.Rollout,
 		Conditions:          deployment.Status.Conditions,
 	}
 
@@ -390,6 +390,9 @@ func MachineTemplateDeepCopyRolloutFields(template *clusterv1.MachineTemplateSpe
 	templateCopy.Spec.NodeDeletionTimeout = nil
 	templateCopy.Spec.NodeVolumeDetachTimeout = nil
 
+	// Drop node drain policy
+	templateCopy.Spec.NodeDrainPolicy = nil
+
 	// Remove the version part from the references APIVersion field,
 	// for more details see issue #2183 and #2140.
 	templateCopy.Spec.InfrastructureRef.APIVersion = templateCopy.Spec.InfrastructureRef.GroupVersionKind().Group
@@ -563,11 +566,49 @@ func NewMSNewReplicas(deployment *clusterv1.MachineDeployment, allMSs []*cluster
 		// the desired number of replicas in the MachineDeployment
 		scaleUpCount := *(deployment.Spec.Replicas) - currentMachineCount
 		return newMSReplicas + scaleUpCount, nil
+	case clusterv1.BlueGreenMachineDeploymentStrategyType:
+		// The new MachineSet is always scaled up to the desired number of replicas in one step,
+		// without taking into account the replicas of the old MachineSets.
+		return *(deployment.Spec.Replicas), nil
 	default:
 		return 0, fmt.Errorf("failed to compute replicas: deployment strategy %v isn't supported", deployment.Spec.Strategy.Type)
 	}
 }
 
+// RolloutStepMaxReplicas returns the maximum number of replicas of the new MachineSet allowed by the current step
+// of a rollout using steps, i.e. the replicas corresponding to the last weight set up to the current step.
+// It returns false if the rollout is not limited by a step, i.e. the deployment has no steps or all the steps
+// have been completed. If the status of the deployment does not refer to the new MachineSet yet, the rollout
+// is considered at its first step.
+func RolloutStepMaxReplicas(deployment *clusterv1.MachineDeployment, newMSName string) (int32, bool) {
+	if deployment.Spec.Strategy == nil || deployment.Spec.Strategy.Type != clusterv1.RollingUpdateMachineDeploymentStrategyType ||
+		len(deployment.Spec.Strategy.Steps) == 0 || deployment.Spec.Replicas == nil {
+		return 0, false
+	}
+
+	currentStepIndex := 0
+	if rollout := deployment.Status.Rollout; rollout != nil && rollout.MachineSetName == newMSName {
+		if rollout.Aborted {
+			return 0, true
+		}
+		currentStepIndex = int(rollout.CurrentStepIndex)
+	}
+
+	steps := deployment.Spec.Strategy.Steps
+	if currentStepIndex >= len(steps) {
+		return 0, false
+	}
+
+	weight := int32(0)
+	for _, step := range steps[:currentStepIndex+1] {
+		if step.SetWeight != nil {
+			weight = *step.SetWeight
+		}
+	}
+	// Round up, so that each step with a weight replaces at least one machine.
+	return (*(deployment.Spec.Replicas)*weight + 99) / 100, true
+}
+
 // IsSaturated checks if the new machine set is saturated by comparing its size with its deployment size.
 // Both the deployment and the machine set have to believe this machine set can own all of the desired
 // replicas in the deployment and the annotation helps in achieving that. All machines of the MachineSet
@@ -210,6 +210,7 @@ func TestEqualMachineTemplate(t *testing.T) {
 	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.NodeDrainTimeout = &metav1.Duration{Duration: 20 * time.Second}
 	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.NodeDeletionTimeout = &metav1.Duration{Duration: 20 * time.Second}
 	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.NodeVolumeDetachTimeout = &metav1.Duration{Duration: 20 * time.Second}
+	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.NodeDrainPolicy = &clusterv1.NodeDrainPolicy{Mode: clusterv1.NodeDrainModeDelete}
 
 	machineTemplateWithDifferentInfraRef := machineTemplate.DeepCopy()
 	machineTemplateWithDifferentInfraRef.Spec.InfrastructureRef.Name = "infra2"
@@ -618,6 +619,11 @@ func TestNewMSNewReplicas(t *testing.T) {
 			clusterv1.RollingUpdateMachineDeploymentStrategyType,
 			6, 2, 10, 6,
 		},
+		{
+			"blue/green scale up - to depReplicas ignoring old MachineSets",
+			clusterv1.BlueGreenMachineDeploymentStrategyType,
+			3, 0, 0, 3,
+		},
 	}
 	newDeployment := generateDeployment("nginx")
 	newRC := generateMS(newDeployment)
@@ -648,6 +654,82 @@ func TestNewMSNewReplicas(t *testing.T) {
 	}
 }
 
+func TestRolloutStepMaxReplicas(t *testing.T) {
+	steps := []clusterv1.MachineDeploymentRolloutStep{
+		{SetWeight: pointer.Int32(10)},
+		{Pause: &clusterv1.MachineDeploymentRolloutPause{}},
+		{SetWeight: pointer.Int32(50)},
+	}
+	deployment := func(strategyType clusterv1.MachineDeploymentStrategyType, steps []clusterv1.MachineDeploymentRolloutStep, rollout *clusterv1.MachineDeploymentRolloutStatus) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			Spec: clusterv1.MachineDeploymentSpec{
+				Replicas: pointer.Int32(5),
+				Strategy: &clusterv1.MachineDeploymentStrategy{
+					Type:  strategyType,
+					Steps: steps,
+				},
+			},
+			Status: clusterv1.MachineDeploymentStatus{
+				Rollout: rollout,
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		deployment   *clusterv1.MachineDeployment
+		wantReplicas int32
+		wantOk       bool
+	}{
+		{
+			name:       "not limited without steps",
+			deployment: deployment(clusterv1.RollingUpdateMachineDeploymentStrategyType, nil, nil),
+		},
+		{
+			name:       "not limited with a strategy different than RollingUpdate",
+			deployment: deployment(clusterv1.OnDeleteMachineDeploymentStrategyType, steps, nil),
+		},
+		{
+			name:         "limited by the first step if the rollout of the new MachineSet has not been tracked yet, rounding up",
+			deployment:   deployment(clusterv1.RollingUpdateMachineDeploymentStrategyType, steps, &clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "old", CurrentStepIndex: 3}),
+			wantReplicas: 1,
+			wantOk:       true,
+		},
+		{
+			name:         "limited by the last weight before a pause",
+			deployment:   deployment(clusterv1.RollingUpdateMachineDeploymentStrategyType, steps, &clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 1}),
+			wantReplicas: 1,
+			wantOk:       true,
+		},
+		{
+			name:         "limited by the weight of the current step",
+			deployment:   deployment(clusterv1.RollingUpdateMachineDeploymentStrategyType, steps, &clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 2}),
+			wantReplicas: 3,
+			wantOk:       true,
+		},
+		{
+			name:       "not limited once all the steps have been completed",
+			deployment: deployment(clusterv1.RollingUpdateMachineDeploymentStrategyType, steps, &clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 3}),
+		},
+		{
+			name:         "limited to zero if the rollout has been aborted",
+			deployment:   deployment(clusterv1.RollingUpdateMachineDeploymentStrategyType, steps, &clusterv1.MachineDeploymentRolloutStatus{MachineSetName: "new", CurrentStepIndex: 2, Aborted: true}),
+			wantReplicas: 0,
+			wantOk:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			replicas, ok := RolloutStepMaxReplicas(tt.deployment, "new")
+			g.Expect(ok).To(Equal(tt.wantOk))
+			g.Expect(replicas).To(Equal(tt.wantReplicas))
+		})
+	}
+}
+
 func TestDeploymentComplete(t *testing.T) {
 	deployment := func(desired, current, updated, available, maxUnavailable, maxSurge int32) *clusterv1.MachineDeployment {
 		return &clusterv1.MachineDeployment{
@@ -47,6 +47,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine"
+	"sigs.k8s.io/cluster-api/internal/util/remediation"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -61,6 +62,10 @@ const (
 	// is restricted by remediation circuit shorting logic.
 	EventRemediationRestricted string = "RemediationRestricted"
 
+	// EventSoftRemediationRequested is emitted in case the soft remediation
+	// action is requested for an unhealthy machine.
+	EventSoftRemediationRequested string = "SoftRemediationRequested"
+
 	maxUnhealthyKeyLog     = "max unhealthy"
 	unhealthyTargetsKeyLog = "unhealthy targets"
 	unhealthyRangeKeyLog   = "unhealthy range"
@@ -228,6 +233,13 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 	// do sort to avoid keep changing m.Status as the returned machines are not in order
 	sort.Strings(m.Status.Targets)
 
+	// Surface the Machines created as a replacement of remediated Machines in the remediation history.
+	for _, t := range targets {
+		if replacementFor, ok := t.Machine.Annotations[clusterv1.ReplacementForAnnotation]; ok {
+			remediation.SetReplacedBy(m.Status.RemediationHistory, replacementFor, t.Machine.Name)
+		}
+	}
+
 	nodeStartupTimeout := m.Spec.NodeStartupTimeout
 	if nodeStartupTimeout == nil {
 		nodeStartupTimeout = &clusterv1.DefaultNodeStartupTimeout
@@ -319,8 +331,48 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 	m.Status.RemediationsAllowed = remediationCount
 	conditions.MarkTrue(m, clusterv1.RemediationAllowedCondition)
 
-	errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
+	// Remediation might be further restricted by the remediation budget of the Cluster, which is shared by all the
+	// MachineHealthChecks targeting the Cluster.
+	unhealthy, deferred, budgetRequeueAfter, err := r.reconcileRemediationBudget(ctx, cluster, m, unhealthy, time.Now())
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "error checking the remediation budget")
+	}
+
+	errList := []error{}
+	if len(deferred) > 0 {
+		logger.V(3).Info(
+			"Deferring remediation because the remediation budget of the Cluster is exhausted",
+			unhealthyTargetsKeyLog, len(unhealthy)+len(deferred),
+			"deferred targets", len(deferred),
+		)
+		message := fmt.Sprintf("Remediation is restricted, the remediation budget of the Cluster is exhausted (in flight: %v, deferred: %v)",
+			m.Status.RemediationBudget.InFlight,
+			len(deferred))
+		conditions.Set(m, &clusterv1.Condition{
+			Type:     clusterv1.RemediationAllowedCondition,
+			Status:   corev1.ConditionFalse,
+			Severity: clusterv1.ConditionSeverityWarning,
+			Reason:   clusterv1.RemediationBudgetExhaustedReason,
+			Message:  message,
+		})
+		r.recorder.Event(
+			m,
+			corev1.EventTypeWarning,
+			EventRemediationRestricted,
+			message,
+		)
+		for _, t := range deferred {
+			if err := t.patchHelper.Patch(ctx, t.Machine); err != nil {
+				errList = append(errList, errors.Wrapf(err, "failed to patch machine status for machine: %s/%s", t.Machine.Namespace, t.Machine.Name))
+			}
+		}
+		nextCheckTimes = append(nextCheckTimes, budgetRequeueAfter)
+	}
+
+	softRemediationTimeouts, unhealthyErrList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
+	errList = append(errList, unhealthyErrList...)
 	errList = append(errList, r.patchHealthyTargets(ctx, logger, healthy, m)...)
+	nextCheckTimes = append(nextCheckTimes, softRemediationTimeouts...)
 
 	// handle update errors
 	if len(errList) > 0 {
@@ -342,6 +394,8 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger, healthy []healthCheckTarget, m *clusterv1.MachineHealthCheck) []error {
 	errList := []error{}
 	for _, t := range healthy {
+		clearSoftRemediation(t.Machine)
+
 		if m.Spec.RemediationTemplate != nil {
 			// Get remediation request object
 			obj, err := r.getExternalRemediationRequest(ctx, m, t.Machine.Name)
@@ -371,11 +425,16 @@ func (r *Reconciler) patchHealthyTargets(ctx context.Context, logger logr.Logger
 }
 
 // patchUnhealthyTargets patches machines with MachineOwnerRemediatedCondition for remediation.
-func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logger, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) []error {
+// If soft remediation is configured, machines are annotated to request the soft remediation action first, and
+// the time left before escalating to the replacement of each machine is returned.
+func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logger, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) ([]time.Duration, []error) {
 	// mark for remediation
 	errList := []error{}
+	softRemediationTimeouts := []time.Duration{}
+	now := time.Now()
 	for _, t := range unhealthy {
 		condition := conditions.Get(t.Machine, clusterv1.MachineHealthCheckSucceededCondition)
+		markedForRemediation := false
 
 		if annotations.IsPaused(cluster, t.Machine) {
 			logger.Info("Machine has failed health check, but machine is paused so skipping remediation", "target", t.string(), "reason", condition.Reason, "message", condition.Message)
@@ -384,7 +443,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 				// If external remediation request already exists,
 				// return early
 				if r.externalRemediationRequestExists(ctx, m, t.Machine.Name) {
-					return errList
+					return softRemediationTimeouts, errList
 				}
 
 				cloneOwnerRef := &metav1.OwnerReference{
@@ -398,7 +457,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 				if err != nil {
 					conditions.MarkFalse(m, clusterv1.ExternalRemediationTemplateAvailableCondition, clusterv1.ExternalRemediationTemplateNotFoundReason, clusterv1.ConditionSeverityError, err.Error())
 					errList = append(errList, errors.Wrapf(err, "error retrieving remediation template %v %q for machine %q in namespace %q within cluster %q", m.Spec.RemediationTemplate.GroupVersionKind(), m.Spec.RemediationTemplate.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName))
-					return errList
+					return softRemediationTimeouts, errList
 				}
 
 				generateTemplateInput := &external.GenerateTemplateInput{
@@ -411,7 +470,7 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 				to, err := external.GenerateTemplate(generateTemplateInput)
 				if err != nil {
 					errList = append(errList, errors.Wrapf(err, "failed to create template for remediation request %v %q for machine %q in namespace %q within cluster %q", m.Spec.RemediationTemplate.GroupVersionKind(), m.Spec.RemediationTemplate.Name, t.Machine.Name, t.Machine.Namespace, m.Spec.ClusterName))
-					return errList
+					return softRemediationTimeouts, errList
 				}
 
 				// Set the Remediation Request to match the Machine name, the name is used to
@@ -427,14 +486,44 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 				if err := r.Client.Create(ctx, to); err != nil {
 					conditions.MarkFalse(m, clusterv1.ExternalRemediationRequestAvailableCondition, clusterv1.ExternalRemediationRequestCreationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 					errList = append(errList, errors.Wrapf(err, "error creating remediation request for machine %q in namespace %q within cluster %q", t.Machine.Name, t.Machine.Namespace, t.Machine.Spec.ClusterName))
-					return errList
+					return softRemediationTimeouts, errList
 				}
+				recordRemediation(m, t, now)
 			} else {
+				// If soft remediation is configured, request the soft remediation action first, and mark the machine
+				// for remediation only if it is still unhealthy once the soft remediation timeout expires.
+				_, alreadyRequested := softRemediationRequestTime(t.Machine)
+				if softRemediating, timeLeft := softRemediate(m.Spec.SoftRemediation, t.Machine, now); softRemediating {
+					softRemediationTimeouts = append(softRemediationTimeouts, timeLeft)
+					if !alreadyRequested {
+						logger.Info("Target has failed health check, requesting soft remediation", "target", t.string(), "action", m.Spec.SoftRemediation.Action, "reason", condition.Reason, "message", condition.Message)
+					}
+					// NOTE: The Machine is patched even if the action has been already requested, so the changes to
+					// the MachineHealthCheckSucceeded condition are persisted while waiting for the soft remediation timeout.
+					if err := t.patchHelper.Patch(ctx, t.Machine); err != nil {
+						errList = append(errList, errors.Wrapf(err, "failed to patch unhealthy machine: %s/%s", t.Machine.Namespace, t.Machine.Name))
+						continue
+					}
+					if alreadyRequested {
+						continue
+					}
+					r.recorder.Eventf(
+						t.Machine,
+						corev1.EventTypeNormal,
+						EventSoftRemediationRequested,
+						"Soft remediation action %s has been requested for Machine %v",
+						m.Spec.SoftRemediation.Action,
+						t.string(),
+					)
+					continue
+				}
+
 				logger.Info("Target has failed health check, marking for remediation", "target", t.string(), "reason", condition.Reason, "message", condition.Message)
 				// NOTE: MHC is responsible for creating MachineOwnerRemediatedCondition if missing or to trigger another remediation if the previous one is completed;
 				// instead, if a remediation is in already progress, the remediation owner is responsible for completing the process and MHC should not overwrite the condition.
 				if !conditions.Has(t.Machine, clusterv1.MachineOwnerRemediatedCondition) || conditions.IsTrue(t.Machine, clusterv1.MachineOwnerRemediatedCondition) {
 					conditions.MarkFalse(t.Machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+					markedForRemediation = true
 				}
 			}
 		}
@@ -443,6 +532,9 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 			errList = append(errList, errors.Wrapf(err, "failed to patch unhealthy machine status for machine: %s/%s", t.Machine.Namespace, t.Machine.Name))
 			continue
 		}
+		if markedForRemediation {
+			recordRemediation(m, t, now)
+		}
 		r.recorder.Eventf(
 			t.Machine,
 			corev1.EventTypeNormal,
@@ -451,7 +543,15 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 			t.string(),
 		)
 	}
-	return errList
+	return softRemediationTimeouts, errList
+}
+
+// recordRemediation records the remediation of a target in the remediation history of the MachineHealthCheck
+// and in the remediation metrics.
+func recordRemediation(m *clusterv1.MachineHealthCheck, t healthCheckTarget, now time.Time) {
+	entry := remediation.NewHistoryEntry(t.Machine, now)
+	m.Status.RemediationHistory = remediation.AddHistoryEntry(m.Status.RemediationHistory, entry)
+	remediationsTotal.WithLabelValues(m.Namespace, m.Spec.ClusterName, entry.Reason).Inc()
 }
 
 // clusterToMachineHealthCheck maps events from Cluster objects to
@@ -2623,10 +2623,75 @@ func TestPatchTargets(t *testing.T) {
 	}
 
 	// Target with wrong patch helper will fail but the other one will be patched.
-	g.Expect(r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target1, target3}, defaultCluster, mhc)).ToNot(BeEmpty())
+	_, errList := r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target1, target3}, defaultCluster, mhc)
+	g.Expect(errList).ToNot(BeEmpty())
 	g.Expect(cl.Get(ctx, client.ObjectKey{Name: machine2.Name, Namespace: machine2.Namespace}, machine2)).ToNot(HaveOccurred())
 	g.Expect(conditions.Get(machine2, clusterv1.MachineOwnerRemediatedCondition).Status).To(Equal(corev1.ConditionFalse))
 
+	// Only the remediation of the target which has been patched is recorded.
+	g.Expect(mhc.Status.RemediationHistory).To(HaveLen(1))
+	g.Expect(mhc.Status.RemediationHistory[0].Machine).To(Equal(machine2.Name))
+
 	// Target with wrong patch helper will fail but the other one will be patched.
 	g.Expect(r.patchHealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target1, target3}, mhc)).ToNot(BeEmpty())
 }
+
+func TestPatchUnhealthyTargetsWithSoftRemediationAlreadyRequested(t *testing.T) {
+	g := NewWithT(t)
+
+	namespace := metav1.NamespaceDefault
+	clusterName := testClusterName
+	defaultCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+		},
+	}
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+
+	mhc := newMachineHealthCheckWithLabels("mhc", namespace, clusterName, labels)
+	mhc.Spec.SoftRemediation = &clusterv1.SoftRemediation{
+		Action:  clusterv1.RebootSoftRemediationAction,
+		Timeout: metav1.Duration{Duration: 10 * time.Minute},
+	}
+	machine := newTestMachine("machine1", namespace, clusterName, "nodeName", labels)
+	machine.Annotations = map[string]string{
+		clusterv1.RemediationActionAnnotation:          string(clusterv1.RebootSoftRemediationAction),
+		clusterv1.RemediationActionTimestampAnnotation: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339),
+	}
+	conditions.MarkTrue(machine, clusterv1.MachineHealthCheckSucceededCondition)
+
+	cl := fake.NewClientBuilder().WithObjects(
+		machine,
+		mhc,
+	).WithStatusSubresource(&clusterv1.MachineHealthCheck{}, &clusterv1.Machine{}).Build()
+	recorder := record.NewFakeRecorder(32)
+	r := &Reconciler{
+		Client:   cl,
+		recorder: recorder,
+	}
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(machine), machine)).To(Succeed())
+	patchHelper, err := patch.NewHelper(machine, cl)
+	g.Expect(err).ToNot(HaveOccurred())
+	target := healthCheckTarget{
+		MHC:         mhc,
+		Machine:     machine,
+		patchHelper: patchHelper,
+		Node:        &corev1.Node{},
+	}
+	// The health check changes the condition of the Machine while soft remediation is already requested.
+	conditions.MarkFalse(machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityWarning, "")
+
+	softRemediationTimeouts, errList := r.patchUnhealthyTargets(context.TODO(), logr.New(log.NullLogSink{}), []healthCheckTarget{target}, defaultCluster, mhc)
+	g.Expect(errList).To(BeEmpty())
+	g.Expect(softRemediationTimeouts).To(HaveLen(1))
+
+	// Check the condition is patched, while the Machine is neither marked for remediation nor soft remediated again.
+	got := &clusterv1.Machine{}
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(machine), got)).To(Succeed())
+	g.Expect(conditions.IsFalse(got, clusterv1.MachineHealthCheckSucceededCondition)).To(BeTrue())
+	g.Expect(conditions.Has(got, clusterv1.MachineOwnerRemediatedCondition)).To(BeFalse())
+	g.Expect(got.Annotations).To(Equal(machine.Annotations))
+	g.Expect(recorder.Events).To(BeEmpty())
+}
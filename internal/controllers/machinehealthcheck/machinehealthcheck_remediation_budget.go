@@ -0,0 +1,237 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// remediationBudgetRequeueAfter is how long to wait before checking again if the remediation budget of a Cluster
+// allows to remediate unhealthy machines; machines being remediated by other MachineHealthChecks do not
+// trigger a reconcile of the MachineHealthCheck, so the MachineHealthCheck must be requeued.
+const remediationBudgetRequeueAfter = 30 * time.Second
+
+// remediationBudget is the remediation budget of a Cluster, shared by all the MachineHealthChecks targeting the Cluster.
+type remediationBudget struct {
+	// inFlight is the number of Machines of the Cluster being remediated.
+	inFlight int32
+
+	// startedInWindow is the number of remediations started within the window by all the MachineHealthChecks targeting the Cluster.
+	startedInWindow int32
+
+	// remaining is the number of further remediations which can be started.
+	remaining int32
+
+	// nextWindowSlot is the duration after which the oldest remediation started within the window exits the window.
+	nextWindowSlot time.Duration
+}
+
+// reconcileRemediationBudget enforces the remediation budget of the Cluster, if any, on the unhealthy targets
+// of a MachineHealthCheck. It returns the targets which can be remediated, i.e. the targets already being remediated
+// and as many new targets as allowed by the budget, and the targets whose remediation has to be deferred.
+func (r *Reconciler) reconcileRemediationBudget(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck, unhealthy []healthCheckTarget, now time.Time) ([]healthCheckTarget, []healthCheckTarget, time.Duration, error) {
+	if cluster.Spec.RemediationBudget == nil {
+		m.Status.RemediationBudget = nil
+		return unhealthy, nil, 0, nil
+	}
+
+	mhcs, err := r.getClusterMachineHealthChecks(ctx, cluster, m)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	budget, err := r.getRemediationBudget(ctx, cluster, mhcs, now)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var previousStartTimes []metav1.Time
+	if m.Status.RemediationBudget != nil {
+		previousStartTimes = m.Status.RemediationBudget.StartTimes
+	}
+
+	// Targets already being remediated, or paused, do not consume the budget.
+	allowed := []healthCheckTarget{}
+	newRemediations := []healthCheckTarget{}
+	for _, t := range unhealthy {
+		if annotations.IsPaused(cluster, t.Machine) || r.isRemediationInFlight(ctx, t.Machine, mhcs) {
+			allowed = append(allowed, t)
+			continue
+		}
+		newRemediations = append(newRemediations, t)
+	}
+
+	// Pick the new remediations to start in a deterministic order.
+	sort.Slice(newRemediations, func(i, j int) bool {
+		return newRemediations[i].Machine.Name < newRemediations[j].Machine.Name
+	})
+	started := int32(len(newRemediations))
+	if started > budget.remaining {
+		started = budget.remaining
+	}
+	allowed = append(allowed, newRemediations[:started]...)
+	deferred := newRemediations[started:]
+
+	m.Status.RemediationBudget = &clusterv1.RemediationBudgetStatus{
+		InFlight:  budget.inFlight + started,
+		Remaining: budget.remaining - started,
+	}
+
+	// Keep track of the remediations started by this MachineHealthCheck within the window.
+	if window := remediationBudgetWindow(cluster.Spec.RemediationBudget); window > 0 {
+		var startTimes []metav1.Time
+		for _, startTime := range previousStartTimes {
+			if startTime.Add(window).After(now) {
+				startTimes = append(startTimes, startTime)
+			}
+		}
+		for i := int32(0); i < started; i++ {
+			startTimes = append(startTimes, metav1.NewTime(now))
+		}
+		m.Status.RemediationBudget.StartedInWindow = budget.startedInWindow + started
+		m.Status.RemediationBudget.StartTimes = startTimes
+	}
+
+	if len(deferred) == 0 {
+		return allowed, nil, 0, nil
+	}
+
+	requeueAfter := remediationBudgetRequeueAfter
+	if budget.nextWindowSlot > 0 && budget.nextWindowSlot < requeueAfter {
+		requeueAfter = budget.nextWindowSlot
+	}
+	return allowed, deferred, requeueAfter, nil
+}
+
+// getRemediationBudget computes the remediation budget of a Cluster.
+func (r *Reconciler) getRemediationBudget(ctx context.Context, cluster *clusterv1.Cluster, mhcs []*clusterv1.MachineHealthCheck, now time.Time) (*remediationBudget, error) {
+	machines := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machines, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list machines for cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	budget := &remediationBudget{}
+	for i := range machines.Items {
+		if r.isRemediationInFlight(ctx, &machines.Items[i], mhcs) {
+			budget.inFlight++
+		}
+	}
+
+	remaining := int32(math.MaxInt32)
+	if maxInFlight := cluster.Spec.RemediationBudget.MaxInFlight; maxInFlight != nil {
+		remaining = *maxInFlight - budget.inFlight
+	}
+
+	if window := remediationBudgetWindow(cluster.Spec.RemediationBudget); window > 0 {
+		var oldest *metav1.Time
+		for _, mhc := range mhcs {
+			if mhc.Status.RemediationBudget == nil {
+				continue
+			}
+			for i, startTime := range mhc.Status.RemediationBudget.StartTimes {
+				if !startTime.Add(window).After(now) {
+					continue
+				}
+				budget.startedInWindow++
+				if oldest == nil || startTime.Before(oldest) {
+					oldest = &mhc.Status.RemediationBudget.StartTimes[i]
+				}
+			}
+		}
+		if oldest != nil {
+			budget.nextWindowSlot = oldest.Add(window).Sub(now)
+		}
+
+		if remainingInWindow := *cluster.Spec.RemediationBudget.MaxPerWindow - budget.startedInWindow; remainingInWindow < remaining {
+			remaining = remainingInWindow
+		}
+	}
+
+	if remaining < 0 {
+		remaining = 0
+	}
+	budget.remaining = remaining
+	return budget, nil
+}
+
+// getClusterMachineHealthChecks returns all the MachineHealthChecks targeting a Cluster;
+// the given MachineHealthCheck is returned as is, given that its status is being reconciled.
+func (r *Reconciler) getClusterMachineHealthChecks(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.MachineHealthCheck) ([]*clusterv1.MachineHealthCheck, error) {
+	mhcList := &clusterv1.MachineHealthCheckList{}
+	if err := r.Client.List(ctx, mhcList, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineHealthChecks for cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	mhcs := []*clusterv1.MachineHealthCheck{m}
+	for i := range mhcList.Items {
+		if mhcList.Items[i].Name == m.Name {
+			continue
+		}
+		mhcs = append(mhcs, &mhcList.Items[i])
+	}
+	return mhcs, nil
+}
+
+// isRemediationInFlight returns true if a Machine is being remediated, i.e. it has been marked for remediation
+// by its owner, a soft remediation action has been requested or an external remediation request exists.
+func (r *Reconciler) isRemediationInFlight(ctx context.Context, machine *clusterv1.Machine, mhcs []*clusterv1.MachineHealthCheck) bool {
+	if conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition) {
+		return true
+	}
+	if _, ok := softRemediationRequestTime(machine); ok {
+		return true
+	}
+
+	// External remediation requests can exist only for unhealthy machines; checking only those
+	// avoids looking up remediation requests for all the Machines of the Cluster.
+	if !conditions.IsFalse(machine, clusterv1.MachineHealthCheckSucceededCondition) {
+		return false
+	}
+	for _, mhc := range mhcs {
+		if mhc.Spec.RemediationTemplate == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&mhc.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(machine.Labels)) {
+			continue
+		}
+		if r.externalRemediationRequestExists(ctx, mhc, machine.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// remediationBudgetWindow returns the window used to enforce the maximum number of remediations per window, if any.
+func remediationBudgetWindow(budget *clusterv1.RemediationBudget) time.Duration {
+	if budget.MaxPerWindow == nil || budget.Window == nil {
+		return 0
+	}
+	return budget.Window.Duration
+}
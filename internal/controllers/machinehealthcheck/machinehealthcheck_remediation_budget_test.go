@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileRemediationBudget(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newMachine := func(name string, remediating bool) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+			},
+		}
+		if remediating {
+			conditions.MarkFalse(m, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+		}
+		return m
+	}
+	newMHC := func(name string, startTimes ...time.Time) *clusterv1.MachineHealthCheck {
+		mhc := &clusterv1.MachineHealthCheck{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+			},
+		}
+		if len(startTimes) > 0 {
+			mhc.Status.RemediationBudget = &clusterv1.RemediationBudgetStatus{}
+			for _, startTime := range startTimes {
+				mhc.Status.RemediationBudget.StartTimes = append(mhc.Status.RemediationBudget.StartTimes, metav1.NewTime(startTime))
+			}
+		}
+		return mhc
+	}
+	targets := func(machines ...*clusterv1.Machine) []healthCheckTarget {
+		ret := []healthCheckTarget{}
+		for _, m := range machines {
+			ret = append(ret, healthCheckTarget{Machine: m})
+		}
+		return ret
+	}
+	names := func(targets []healthCheckTarget) []string {
+		ret := []string{}
+		for _, t := range targets {
+			ret = append(ret, t.Machine.Name)
+		}
+		return ret
+	}
+
+	m1 := newMachine("m1", false)
+	m2 := newMachine("m2", false)
+	m3 := newMachine("m3", false)
+	remediating := newMachine("remediating", true)
+
+	tests := []struct {
+		name             string
+		budget           *clusterv1.RemediationBudget
+		mhc              *clusterv1.MachineHealthCheck
+		objs             []client.Object
+		unhealthy        []healthCheckTarget
+		wantAllowed      []string
+		wantDeferred     []string
+		wantRequeueAfter time.Duration
+		wantStatus       *clusterv1.RemediationBudgetStatus
+	}{
+		{
+			name:        "allows all the unhealthy targets if the cluster has no remediation budget",
+			mhc:         newMHC("mhc", now),
+			objs:        []client.Object{m1, m2},
+			unhealthy:   targets(m1, m2),
+			wantAllowed: []string{"m1", "m2"},
+		},
+		{
+			name:             "limits new remediations to maxInFlight",
+			budget:           &clusterv1.RemediationBudget{MaxInFlight: pointer.Int32(2)},
+			mhc:              newMHC("mhc"),
+			objs:             []client.Object{m1, m2, m3},
+			unhealthy:        targets(m3, m2, m1),
+			wantAllowed:      []string{"m1", "m2"},
+			wantDeferred:     []string{"m3"},
+			wantRequeueAfter: remediationBudgetRequeueAfter,
+			wantStatus:       &clusterv1.RemediationBudgetStatus{InFlight: 2, Remaining: 0},
+		},
+		{
+			name:             "machines being remediated count against maxInFlight but are always allowed",
+			budget:           &clusterv1.RemediationBudget{MaxInFlight: pointer.Int32(1)},
+			mhc:              newMHC("mhc"),
+			objs:             []client.Object{m1, remediating},
+			unhealthy:        targets(m1, remediating),
+			wantAllowed:      []string{"remediating"},
+			wantDeferred:     []string{"m1"},
+			wantRequeueAfter: remediationBudgetRequeueAfter,
+			wantStatus:       &clusterv1.RemediationBudgetStatus{InFlight: 1, Remaining: 0},
+		},
+		{
+			name: "limits new remediations to maxPerWindow, counting remediations started by all the MachineHealthChecks",
+			budget: &clusterv1.RemediationBudget{
+				MaxPerWindow: pointer.Int32(2),
+				Window:       &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			mhc:              newMHC("mhc", now.Add(-20*time.Minute)),
+			objs:             []client.Object{m1, m2, newMHC("other", now.Add(-10*time.Minute+10*time.Second))},
+			unhealthy:        targets(m1, m2),
+			wantAllowed:      []string{"m1"},
+			wantDeferred:     []string{"m2"},
+			wantRequeueAfter: 10 * time.Second,
+			wantStatus: &clusterv1.RemediationBudgetStatus{
+				InFlight:        1,
+				StartedInWindow: 2,
+				Remaining:       0,
+				StartTimes:      []metav1.Time{metav1.NewTime(now)},
+			},
+		},
+		{
+			name: "requeues after the default interval if no slot frees up in the window before",
+			budget: &clusterv1.RemediationBudget{
+				MaxPerWindow: pointer.Int32(1),
+				Window:       &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			mhc:              newMHC("mhc", now.Add(-1*time.Minute)),
+			objs:             []client.Object{m1},
+			unhealthy:        targets(m1),
+			wantAllowed:      []string{},
+			wantDeferred:     []string{"m1"},
+			wantRequeueAfter: remediationBudgetRequeueAfter,
+			wantStatus: &clusterv1.RemediationBudgetStatus{
+				InFlight:        0,
+				StartedInWindow: 1,
+				Remaining:       0,
+				StartTimes:      []metav1.Time{metav1.NewTime(now.Add(-1 * time.Minute))},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "cluster",
+				},
+				Spec: clusterv1.ClusterSpec{
+					RemediationBudget: tt.budget,
+				},
+			}
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithObjects(append(tt.objs, tt.mhc)...).Build(),
+			}
+
+			allowed, deferred, requeueAfter, err := r.reconcileRemediationBudget(context.Background(), cluster, tt.mhc, tt.unhealthy, now)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(names(allowed)).To(ConsistOf(tt.wantAllowed))
+			g.Expect(names(deferred)).To(ConsistOf(tt.wantDeferred))
+			g.Expect(requeueAfter).To(Equal(tt.wantRequeueAfter))
+			g.Expect(tt.mhc.Status.RemediationBudget).To(Equal(tt.wantStatus))
+		})
+	}
+}
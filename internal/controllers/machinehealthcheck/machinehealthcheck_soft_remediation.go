@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"time"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// softRemediationMinHealthyPeriod is the minimum period a Machine must stay healthy after a soft remediation action
+// has been requested, before the action can be requested again; Machines failing again within this period are
+// replaced, so Machines flapping between healthy and unhealthy are not soft remediated over and over.
+const softRemediationMinHealthyPeriod = 1 * time.Hour
+
+// softRemediate requests the soft remediation action for an unhealthy Machine, if not already requested.
+// It returns true if the Machine is being soft remediated, together with the time left before escalating
+// to the replacement of the Machine; false if the Machine should be replaced.
+func softRemediate(softRemediation *clusterv1.SoftRemediation, machine *clusterv1.Machine, now time.Time) (bool, time.Duration) {
+	if softRemediation == nil {
+		return false, 0
+	}
+
+	// If the replacement of the Machine has been already triggered, do not request the action, e.g. because
+	// soft remediation has been configured after the Machine has been marked for remediation.
+	if conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition) {
+		return false, 0
+	}
+
+	requestedAt, ok := softRemediationRequestTime(machine)
+	if !ok {
+		// If the action has been requested recently and the Machine failed again, replace the Machine.
+		if lastRequestedAt, ok := lastSoftRemediationRequestTime(machine); ok && now.Sub(lastRequestedAt) < softRemediationMinHealthyPeriod {
+			return false, 0
+		}
+
+		annotations := machine.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[clusterv1.RemediationActionAnnotation] = string(softRemediation.Action)
+		annotations[clusterv1.RemediationActionTimestampAnnotation] = now.UTC().Format(time.RFC3339)
+		machine.SetAnnotations(annotations)
+		return true, softRemediation.Timeout.Duration
+	}
+
+	if timeLeft := requestedAt.Add(softRemediation.Timeout.Duration).Sub(now); timeLeft > 0 {
+		return true, timeLeft
+	}
+	return false, 0
+}
+
+// softRemediationRequestTime returns the time when the soft remediation action has been requested for a Machine, if any.
+func softRemediationRequestTime(machine *clusterv1.Machine) (time.Time, bool) {
+	if _, ok := machine.GetAnnotations()[clusterv1.RemediationActionAnnotation]; !ok {
+		return time.Time{}, false
+	}
+	return lastSoftRemediationRequestTime(machine)
+}
+
+// lastSoftRemediationRequestTime returns the time when the soft remediation action has been last requested for a Machine,
+// including requests which have been already cleared because the Machine became healthy again.
+func lastSoftRemediationRequestTime(machine *clusterv1.Machine) (time.Time, bool) {
+	requestedAt, err := time.Parse(time.RFC3339, machine.GetAnnotations()[clusterv1.RemediationActionTimestampAnnotation])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return requestedAt, true
+}
+
+// clearSoftRemediation removes the soft remediation request from a Machine which is healthy again.
+// NOTE: The time of the request is kept, so the action is not requested again if the Machine fails
+// within softRemediationMinHealthyPeriod.
+func clearSoftRemediation(machine *clusterv1.Machine) {
+	annotations := machine.GetAnnotations()
+	delete(annotations, clusterv1.RemediationActionAnnotation)
+	machine.SetAnnotations(annotations)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestSoftRemediate(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	softRemediation := &clusterv1.SoftRemediation{
+		Action:  clusterv1.RebootSoftRemediationAction,
+		Timeout: metav1.Duration{Duration: 10 * time.Minute},
+	}
+	requestedAt := func(t time.Time) map[string]string {
+		return map[string]string{
+			clusterv1.RemediationActionAnnotation:          string(clusterv1.RebootSoftRemediationAction),
+			clusterv1.RemediationActionTimestampAnnotation: t.Format(time.RFC3339),
+		}
+	}
+
+	tests := []struct {
+		name                string
+		softRemediation     *clusterv1.SoftRemediation
+		annotations         map[string]string
+		ownerRemediated     bool
+		wantSoftRemediating bool
+		wantTimeLeft        time.Duration
+		wantAnnotations     map[string]string
+	}{
+		{
+			name:                "does not soft remediate if soft remediation is not configured",
+			wantSoftRemediating: false,
+		},
+		{
+			name:                "requests the soft remediation action",
+			softRemediation:     softRemediation,
+			wantSoftRemediating: true,
+			wantTimeLeft:        10 * time.Minute,
+			wantAnnotations:     requestedAt(now),
+		},
+		{
+			name:                "waits for the soft remediation timeout to expire",
+			softRemediation:     softRemediation,
+			annotations:         requestedAt(now.Add(-4 * time.Minute)),
+			wantSoftRemediating: true,
+			wantTimeLeft:        6 * time.Minute,
+			wantAnnotations:     requestedAt(now.Add(-4 * time.Minute)),
+		},
+		{
+			name:                "does not soft remediate once the soft remediation timeout has expired",
+			softRemediation:     softRemediation,
+			annotations:         requestedAt(now.Add(-11 * time.Minute)),
+			wantSoftRemediating: false,
+			wantAnnotations:     requestedAt(now.Add(-11 * time.Minute)),
+		},
+		{
+			name:                "requests the soft remediation action again if the timestamp is invalid",
+			softRemediation:     softRemediation,
+			annotations:         map[string]string{clusterv1.RemediationActionAnnotation: string(clusterv1.RebootSoftRemediationAction)},
+			wantSoftRemediating: true,
+			wantTimeLeft:        10 * time.Minute,
+			wantAnnotations:     requestedAt(now),
+		},
+		{
+			name:                "does not soft remediate if the action has been requested recently for a machine which became healthy in the meantime",
+			softRemediation:     softRemediation,
+			annotations:         map[string]string{clusterv1.RemediationActionTimestampAnnotation: now.Add(-30 * time.Minute).Format(time.RFC3339)},
+			wantSoftRemediating: false,
+			wantAnnotations:     map[string]string{clusterv1.RemediationActionTimestampAnnotation: now.Add(-30 * time.Minute).Format(time.RFC3339)},
+		},
+		{
+			name:                "requests the soft remediation action again once the machine has been healthy for the min healthy period",
+			softRemediation:     softRemediation,
+			annotations:         map[string]string{clusterv1.RemediationActionTimestampAnnotation: now.Add(-2 * time.Hour).Format(time.RFC3339)},
+			wantSoftRemediating: true,
+			wantTimeLeft:        10 * time.Minute,
+			wantAnnotations:     requestedAt(now),
+		},
+		{
+			name:                "does not soft remediate if the machine has been already marked for remediation",
+			softRemediation:     softRemediation,
+			ownerRemediated:     true,
+			wantSoftRemediating: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+			if tt.ownerRemediated {
+				conditions.MarkFalse(machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+			}
+
+			softRemediating, timeLeft := softRemediate(tt.softRemediation, machine, now)
+			g.Expect(softRemediating).To(Equal(tt.wantSoftRemediating))
+			g.Expect(timeLeft).To(Equal(tt.wantTimeLeft))
+			g.Expect(machine.GetAnnotations()).To(Equal(tt.wantAnnotations))
+		})
+	}
+}
+
+func TestClearSoftRemediation(t *testing.T) {
+	g := NewWithT(t)
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				"foo":                                 "bar",
+				clusterv1.RemediationActionAnnotation: string(clusterv1.RebootSoftRemediationAction),
+				clusterv1.RemediationActionTimestampAnnotation: time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	}
+
+	clearSoftRemediation(machine)
+	g.Expect(machine.GetAnnotations()).To(HaveKeyWithValue("foo", "bar"))
+	g.Expect(machine.GetAnnotations()).ToNot(HaveKey(clusterv1.RemediationActionAnnotation))
+
+	_, requested := softRemediationRequestTime(machine)
+	g.Expect(requested).To(BeFalse())
+
+	// Check the time of the last request is kept.
+	_, requested = lastSoftRemediationRequestTime(machine)
+	g.Expect(requested).To(BeTrue())
+}
@@ -19,6 +19,7 @@ package machinehealthcheck
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -85,7 +86,8 @@ func (t *healthCheckTarget) nodeName() string {
 // - The Machine has failed for some reason
 // - The Machine did not get a node before `timeoutForMachineToHaveNode` elapses
 // - The Node has gone away
-// - Any condition on the node is matched for the given timeout
+// - Any condition on the node, condition on the machine or taint on the node is matched for the given timeout
+// (at least one check of each kind must be matched instead if the MachineHealthCheck uses the And operator)
 // If the target doesn't currently need rememdiation, provide a duration after
 // which the target should next be checked.
 // The target should be requeued after this duration.
@@ -163,31 +165,170 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 		return false, nextCheck
 	}
 
-	// check conditions
+	// check conditions, machine conditions and taints
+	nodeConditionChecks := t.nodeConditionChecks(now)
+	machineConditionChecks := t.machineConditionChecks(now)
+	nodeTaintChecks := t.nodeTaintChecks(now)
+
+	if t.MHC.Spec.UnhealthyChecksOperator == clusterv1.AndUnhealthyChecksOperator {
+		return t.needsRemediationWithAllChecks(logger, nodeConditionChecks, machineConditionChecks, nodeTaintChecks)
+	}
+
+	checks := append(nodeConditionChecks, machineConditionChecks...)
+	checks = append(checks, nodeTaintChecks...)
+	for _, c := range checks {
+		// If a check has been in the unhealthy state for longer than the
+		// timeout, return true with no requeue time.
+		if c.unhealthy {
+			conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, c.reason, clusterv1.ConditionSeverityWarning, c.message)
+			logger.V(3).Info("Target is unhealthy: check is in state longer than allowed timeout", "reason", c.reason, "message", c.message)
+			return true, time.Duration(0)
+		}
+
+		if c.nextCheck > 0 {
+			nextCheckTimes = append(nextCheckTimes, c.nextCheck)
+		}
+	}
+	return false, minDuration(nextCheckTimes)
+}
+
+// needsRemediationWithAllChecks determines whether a target needs remediation when the MachineHealthCheck uses
+// the And operator, i.e. when every kind of check (unhealthy conditions, unhealthy machine conditions and unhealthy
+// node taints) is met; the checks of the same kind are combined in a logical OR, given that e.g. a Node cannot match
+// both a Ready=False and a Ready=Unknown unhealthy condition at the same time.
+// Kinds of checks not configured in the MachineHealthCheck are ignored.
+func (t *healthCheckTarget) needsRemediationWithAllChecks(logger logr.Logger, checksByKind ...[]healthCheckResult) (bool, time.Duration) {
+	var nextCheckTimes []time.Duration
+	var unhealthyChecks []healthCheckResult
+	for _, checks := range checksByKind {
+		if len(checks) == 0 {
+			continue
+		}
+
+		unhealthy, nextCheck := combineHealthCheckResults(checks)
+		switch {
+		case unhealthy != nil:
+			unhealthyChecks = append(unhealthyChecks, *unhealthy)
+		case nextCheck > 0:
+			nextCheckTimes = append(nextCheckTimes, nextCheck)
+		default:
+			// If any kind of check is not in the unhealthy state, the target is healthy.
+			return false, 0
+		}
+	}
+	if len(nextCheckTimes) > 0 {
+		// The target becomes unhealthy only when the last kind of check is met.
+		return false, maxDuration(nextCheckTimes)
+	}
+	if len(unhealthyChecks) == 0 {
+		return false, 0
+	}
+
+	messages := make([]string, 0, len(unhealthyChecks))
+	for _, c := range unhealthyChecks {
+		messages = append(messages, c.message)
+	}
+	conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, unhealthyChecks[0].reason, clusterv1.ConditionSeverityWarning, strings.Join(messages, "; "))
+	logger.V(3).Info("Target is unhealthy: all the kinds of checks are in state longer than allowed timeout", "checks", strings.Join(messages, "; "))
+	return true, time.Duration(0)
+}
+
+// combineHealthCheckResults combines the results of checks of the same kind in a logical OR; it returns the first
+// check in the unhealthy state, if any, otherwise the duration after which the first check will be unhealthy.
+func combineHealthCheckResults(checks []healthCheckResult) (*healthCheckResult, time.Duration) {
+	var nextCheckTimes []time.Duration
+	for i := range checks {
+		if checks[i].unhealthy {
+			return &checks[i], 0
+		}
+		if checks[i].nextCheck > 0 {
+			nextCheckTimes = append(nextCheckTimes, checks[i].nextCheck)
+		}
+	}
+	return nil, minDuration(nextCheckTimes)
+}
+
+// healthCheckResult is the result of a single check on a target.
+type healthCheckResult struct {
+	// unhealthy is true when the target has been in the unhealthy state for longer than the timeout.
+	unhealthy bool
+	// nextCheck is the duration after which the target will be unhealthy, if the target
+	// is in the unhealthy state but the timeout has not elapsed yet.
+	nextCheck time.Duration
+	reason    string
+	message   string
+}
+
+// newHealthCheckResult returns the result of a check on a target which is in the unhealthy state since the given time.
+func newHealthCheckResult(now, since time.Time, timeout time.Duration, reason, message string) healthCheckResult {
+	if since.Add(timeout).Before(now) {
+		return healthCheckResult{unhealthy: true, reason: reason, message: message}
+	}
+
+	durationUnhealthy := now.Sub(since)
+	return healthCheckResult{nextCheck: timeout - durationUnhealthy + time.Second, reason: reason, message: message}
+}
+
+// nodeConditionChecks checks the target's node against the MachineHealthCheck's unhealthy conditions.
+func (t *healthCheckTarget) nodeConditionChecks(now time.Time) []healthCheckResult {
+	results := make([]healthCheckResult, 0, len(t.MHC.Spec.UnhealthyConditions))
 	for _, c := range t.MHC.Spec.UnhealthyConditions {
 		nodeCondition := getNodeCondition(t.Node, c.Type)
 
 		// Skip when current node condition is different from the one reported
 		// in the MachineHealthCheck.
 		if nodeCondition == nil || nodeCondition.Status != c.Status {
+			results = append(results, healthCheckResult{})
 			continue
 		}
 
-		// If the condition has been in the unhealthy state for longer than the
-		// timeout, return true with no requeue time.
-		if nodeCondition.LastTransitionTime.Add(c.Timeout.Duration).Before(now) {
-			conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.UnhealthyNodeConditionReason, clusterv1.ConditionSeverityWarning, "Condition %s on node is reporting status %s for more than %s", c.Type, c.Status, c.Timeout.Duration.String())
-			logger.V(3).Info("Target is unhealthy: condition is in state longer than allowed timeout", "condition", c.Type, "state", c.Status, "timeout", c.Timeout.Duration.String())
-			return true, time.Duration(0)
+		results = append(results, newHealthCheckResult(now, nodeCondition.LastTransitionTime.Time, c.Timeout.Duration,
+			clusterv1.UnhealthyNodeConditionReason, fmt.Sprintf("Condition %s on node is reporting status %s for more than %s", c.Type, c.Status, c.Timeout.Duration.String())))
+	}
+	return results
+}
+
+// machineConditionChecks checks the target's machine against the MachineHealthCheck's unhealthy machine conditions.
+func (t *healthCheckTarget) machineConditionChecks(now time.Time) []healthCheckResult {
+	results := make([]healthCheckResult, 0, len(t.MHC.Spec.UnhealthyMachineConditions))
+	for _, c := range t.MHC.Spec.UnhealthyMachineConditions {
+		machineCondition := conditions.Get(t.Machine, c.Type)
+
+		// Skip when current machine condition is different from the one reported
+		// in the MachineHealthCheck.
+		if machineCondition == nil || machineCondition.Status != c.Status {
+			results = append(results, healthCheckResult{})
+			continue
 		}
 
-		durationUnhealthy := now.Sub(nodeCondition.LastTransitionTime.Time)
-		nextCheck := c.Timeout.Duration - durationUnhealthy + time.Second
-		if nextCheck > 0 {
-			nextCheckTimes = append(nextCheckTimes, nextCheck)
+		results = append(results, newHealthCheckResult(now, machineCondition.LastTransitionTime.Time, c.Timeout.Duration,
+			clusterv1.UnhealthyMachineConditionReason, fmt.Sprintf("Condition %s on machine is reporting status %s for more than %s", c.Type, c.Status, c.Timeout.Duration.String())))
+	}
+	return results
+}
+
+// nodeTaintChecks checks the target's node against the MachineHealthCheck's unhealthy node taints.
+func (t *healthCheckTarget) nodeTaintChecks(now time.Time) []healthCheckResult {
+	results := make([]healthCheckResult, 0, len(t.MHC.Spec.UnhealthyNodeTaints))
+	for _, c := range t.MHC.Spec.UnhealthyNodeTaints {
+		taint := getNodeTaint(t.Node, c.Key, c.Effect)
+
+		// Skip when the node does not have the taint reported in the MachineHealthCheck.
+		if taint == nil {
+			results = append(results, healthCheckResult{})
+			continue
+		}
+
+		message := fmt.Sprintf("Taint %s:%s on node is applied for more than %s", taint.Key, taint.Effect, c.Timeout.Duration.String())
+
+		// TimeAdded is set only for NoExecute taints; if not set, the node is unhealthy as soon as the taint is applied.
+		if taint.TimeAdded == nil {
+			results = append(results, healthCheckResult{unhealthy: true, reason: clusterv1.UnhealthyNodeTaintReason, message: message})
+			continue
 		}
+		results = append(results, newHealthCheckResult(now, taint.TimeAdded.Time, c.Timeout.Duration, clusterv1.UnhealthyNodeTaintReason, message))
 	}
-	return false, minDuration(nextCheckTimes)
+	return results
 }
 
 // getTargetsFromMHC uses the MachineHealthCheck's selector to fetch machines
@@ -317,6 +458,16 @@ func (r *Reconciler) healthCheckTargets(targets []healthCheckTarget, logger logr
 	return healthy, unhealthy, nextCheckTimes
 }
 
+// getNodeTaint returns the node taint matching the given key and, if set, the given effect.
+func getNodeTaint(node *corev1.Node, key string, effect corev1.TaintEffect) *corev1.Taint {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key && (effect == "" || taint.Effect == effect) {
+			return &taint
+		}
+	}
+	return nil
+}
+
 // getNodeCondition returns node condition by type.
 func getNodeCondition(node *corev1.Node, conditionType corev1.NodeConditionType) *corev1.NodeCondition {
 	for _, cond := range node.Status.Conditions {
@@ -342,6 +493,21 @@ func minDuration(durations []time.Duration) time.Duration {
 	return minDuration
 }
 
+func maxDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return time.Duration(0)
+	}
+
+	maxDuration := durations[0]
+	// Ignore first element as that is already maxDuration
+	for _, nc := range durations[1:] {
+		if nc > maxDuration {
+			maxDuration = nc
+		}
+	}
+	return maxDuration
+}
+
 // shouldSkipRemediation checks if the machine should be skipped for remediation.
 // Returns true if it should be skipped along with the reason for skipping.
 func shouldSkipRemediation(m *clusterv1.Machine) (bool, string) {
@@ -477,6 +477,174 @@ func TestHealthCheckTargets(t *testing.T) {
 	}
 }
 
+func TestNeedsRemediationWithMachineConditionsAndNodeTaints(t *testing.T) {
+	cluster := &clusterv1.Cluster{}
+	conditions.MarkTrue(cluster, clusterv1.InfrastructureReadyCondition)
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+
+	timeout := metav1.Duration{Duration: 5 * time.Minute}
+	nodeReadyUnknown := []clusterv1.UnhealthyCondition{
+		{
+			Type:    corev1.NodeReady,
+			Status:  corev1.ConditionUnknown,
+			Timeout: timeout,
+		},
+	}
+	nodeNotReady := []clusterv1.UnhealthyCondition{
+		{
+			Type:    corev1.NodeReady,
+			Status:  corev1.ConditionUnknown,
+			Timeout: timeout,
+		},
+		{
+			Type:    corev1.NodeReady,
+			Status:  corev1.ConditionFalse,
+			Timeout: timeout,
+		},
+	}
+	infrastructureNotReady := []clusterv1.UnhealthyMachineCondition{
+		{
+			Type:    clusterv1.InfrastructureReadyCondition,
+			Status:  corev1.ConditionFalse,
+			Timeout: timeout,
+		},
+	}
+	unreachableTaint := []clusterv1.UnhealthyNodeTaint{
+		{
+			Key:     corev1.TaintNodeUnreachable,
+			Effect:  corev1.TaintEffectNoExecute,
+			Timeout: timeout,
+		},
+	}
+	hardwareFaultTaint := []clusterv1.UnhealthyNodeTaint{
+		{
+			Key: "example.com/hardware-fault",
+		},
+	}
+
+	machineWithInfrastructureNotReady := func(unhealthyDuration time.Duration) *clusterv1.Machine {
+		machine := newTestMachine("machine1", metav1.NamespaceDefault, "cluster", "node1", nil)
+		machine.SetConditions(clusterv1.Conditions{
+			{
+				Type:               clusterv1.InfrastructureReadyCondition,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-unhealthyDuration)),
+			},
+		})
+		return machine
+	}
+	nodeWithTaint := func(node *corev1.Node, key string, effect corev1.TaintEffect, taintedDuration time.Duration) *corev1.Node {
+		taint := corev1.Taint{Key: key, Effect: effect}
+		if effect == corev1.TaintEffectNoExecute {
+			taint.TimeAdded = &metav1.Time{Time: time.Now().Add(-taintedDuration)}
+		}
+		node.Spec.Taints = append(node.Spec.Taints, taint)
+		return node
+	}
+
+	testCases := []struct {
+		desc                    string
+		spec                    clusterv1.MachineHealthCheckSpec
+		machine                 *clusterv1.Machine
+		node                    *corev1.Node
+		expectNeedsRemediation  bool
+		expectNextCheck         time.Duration
+		expectHealthCheckReason string
+	}{
+		{
+			desc:                    "with a machine condition matched for longer than the timeout",
+			spec:                    clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyMachineConditions: infrastructureNotReady},
+			machine:                 machineWithInfrastructureNotReady(400 * time.Second),
+			node:                    newTestNode("node1"),
+			expectNeedsRemediation:  true,
+			expectHealthCheckReason: clusterv1.UnhealthyMachineConditionReason,
+		},
+		{
+			desc:            "with a machine condition matched for shorter than the timeout",
+			spec:            clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyMachineConditions: infrastructureNotReady},
+			machine:         machineWithInfrastructureNotReady(200 * time.Second),
+			node:            newTestNode("node1"),
+			expectNextCheck: 100 * time.Second,
+		},
+		{
+			desc:                    "with a node taint without time added",
+			spec:                    clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyNodeTaints: hardwareFaultTaint},
+			machine:                 newTestMachine("machine1", metav1.NamespaceDefault, "cluster", "node1", nil),
+			node:                    nodeWithTaint(newTestNode("node1"), "example.com/hardware-fault", corev1.TaintEffectNoSchedule, 0),
+			expectNeedsRemediation:  true,
+			expectHealthCheckReason: clusterv1.UnhealthyNodeTaintReason,
+		},
+		{
+			desc:            "with a node taint applied for shorter than the timeout",
+			spec:            clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyNodeTaints: unreachableTaint},
+			machine:         newTestMachine("machine1", metav1.NamespaceDefault, "cluster", "node1", nil),
+			node:            nodeWithTaint(newTestNode("node1"), corev1.TaintNodeUnreachable, corev1.TaintEffectNoExecute, 100*time.Second),
+			expectNextCheck: 200 * time.Second,
+		},
+		{
+			desc:    "with a node taint with a different effect",
+			spec:    clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyNodeTaints: unreachableTaint},
+			machine: newTestMachine("machine1", metav1.NamespaceDefault, "cluster", "node1", nil),
+			node:    nodeWithTaint(newTestNode("node1"), corev1.TaintNodeUnreachable, corev1.TaintEffectNoSchedule, 0),
+		},
+		{
+			desc:                    "with the And operator and all the checks matched for longer than the timeout",
+			spec:                    clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyMachineConditions: infrastructureNotReady, UnhealthyChecksOperator: clusterv1.AndUnhealthyChecksOperator},
+			machine:                 machineWithInfrastructureNotReady(400 * time.Second),
+			node:                    newTestUnhealthyNode("node1", corev1.NodeReady, corev1.ConditionUnknown, 400*time.Second),
+			expectNeedsRemediation:  true,
+			expectHealthCheckReason: clusterv1.UnhealthyNodeConditionReason,
+		},
+		{
+			desc:    "with the And operator and only some of the checks matched",
+			spec:    clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyMachineConditions: infrastructureNotReady, UnhealthyChecksOperator: clusterv1.AndUnhealthyChecksOperator},
+			machine: newTestMachine("machine1", metav1.NamespaceDefault, "cluster", "node1", nil),
+			node:    newTestUnhealthyNode("node1", corev1.NodeReady, corev1.ConditionUnknown, 400*time.Second),
+		},
+		{
+			desc:            "with the And operator and all the checks matched, some for shorter than the timeout",
+			spec:            clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeReadyUnknown, UnhealthyMachineConditions: infrastructureNotReady, UnhealthyChecksOperator: clusterv1.AndUnhealthyChecksOperator},
+			machine:         machineWithInfrastructureNotReady(100 * time.Second),
+			node:            newTestUnhealthyNode("node1", corev1.NodeReady, corev1.ConditionUnknown, 200*time.Second),
+			expectNextCheck: 200 * time.Second,
+		},
+		{
+			desc:                    "with the And operator and one of the unhealthy conditions and all the other kinds of checks matched",
+			spec:                    clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeNotReady, UnhealthyMachineConditions: infrastructureNotReady, UnhealthyNodeTaints: unreachableTaint, UnhealthyChecksOperator: clusterv1.AndUnhealthyChecksOperator},
+			machine:                 machineWithInfrastructureNotReady(400 * time.Second),
+			node:                    nodeWithTaint(newTestUnhealthyNode("node1", corev1.NodeReady, corev1.ConditionFalse, 400*time.Second), corev1.TaintNodeUnreachable, corev1.TaintEffectNoExecute, 400*time.Second),
+			expectNeedsRemediation:  true,
+			expectHealthCheckReason: clusterv1.UnhealthyNodeConditionReason,
+		},
+		{
+			desc:    "with the And operator and one of the kinds of checks not matched",
+			spec:    clusterv1.MachineHealthCheckSpec{UnhealthyConditions: nodeNotReady, UnhealthyMachineConditions: infrastructureNotReady, UnhealthyNodeTaints: unreachableTaint, UnhealthyChecksOperator: clusterv1.AndUnhealthyChecksOperator},
+			machine: machineWithInfrastructureNotReady(400 * time.Second),
+			node:    newTestUnhealthyNode("node1", corev1.NodeReady, corev1.ConditionFalse, 400*time.Second),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			target := healthCheckTarget{
+				Cluster: cluster,
+				MHC:     &clusterv1.MachineHealthCheck{Spec: tc.spec},
+				Machine: tc.machine,
+				Node:    tc.node,
+			}
+
+			needsRemediation, nextCheck := target.needsRemediation(ctrl.LoggerFrom(ctx), metav1.Duration{Duration: 10 * time.Minute})
+			g.Expect(needsRemediation).To(Equal(tc.expectNeedsRemediation))
+			g.Expect(nextCheck.Truncate(time.Second)).To(Equal(tc.expectNextCheck))
+			if tc.expectNeedsRemediation {
+				g.Expect(conditions.GetReason(tc.machine, clusterv1.MachineHealthCheckSucceededCondition)).To(Equal(tc.expectHealthCheckReason))
+			}
+		})
+	}
+}
+
 func newTestMachine(name, namespace, clusterName, nodeName string, labels map[string]string) *clusterv1.Machine {
 	// Copy the labels so that the map is unique to each test Machine
 	l := make(map[string]string)
@@ -45,6 +45,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine"
+	"sigs.k8s.io/cluster-api/internal/util/remediation"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -519,6 +520,13 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 			log = log.WithValues(infraRef.Kind, klog.KRef(infraRef.Namespace, infraRef.Name))
 			machine.Spec.InfrastructureRef = *infraRef
 
+			// If there are remediated Machines which have not been replaced yet, the new Machine replaces the oldest one.
+			// NOTE: The annotation is set after creating the InfraMachine and the BootstrapConfig, so it is not propagated to them.
+			replacementFor, isReplacement := remediation.NextToReplace(ms.Status.RemediationHistory)
+			if isReplacement {
+				machine.Annotations[clusterv1.ReplacementForAnnotation] = replacementFor
+			}
+
 			// Create the Machine.
 			if err := ssa.Patch(ctx, r.Client, machineSetManagerName, machine); err != nil {
 				log.Error(err, "Error while creating a machine")
@@ -539,6 +547,9 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 				continue
 			}
 
+			if isReplacement {
+				remediation.SetReplacedBy(ms.Status.RemediationHistory, replacementFor, machine.Name)
+			}
 			log.Info(fmt.Sprintf("Created machine %d of %d", i+1, diff), "Machine", klog.KObj(machine))
 			r.recorder.Eventf(ms, corev1.EventTypeNormal, "SuccessfulCreate", "Created machine %q", machine.Name)
 			machineList = append(machineList, machine)
@@ -641,11 +652,18 @@ func (r *Reconciler) computeDesiredMachine(machineSet *clusterv1.MachineSet, exi
 
 	// Set Annotations
 	desiredMachine.Annotations = machineAnnotationsFromMachineSet(machineSet)
+	// Preserve the remediated Machine replaced by an existing Machine.
+	if existingMachine != nil {
+		if replacementFor, ok := existingMachine.Annotations[clusterv1.ReplacementForAnnotation]; ok {
+			desiredMachine.Annotations[clusterv1.ReplacementForAnnotation] = replacementFor
+		}
+	}
 
 	// Set all other in-place mutable fields.
 	desiredMachine.Spec.NodeDrainTimeout = machineSet.Spec.Template.Spec.NodeDrainTimeout
 	desiredMachine.Spec.NodeDeletionTimeout = machineSet.Spec.Template.Spec.NodeDeletionTimeout
 	desiredMachine.Spec.NodeVolumeDetachTimeout = machineSet.Spec.Template.Spec.NodeVolumeDetachTimeout
+	desiredMachine.Spec.NodeDrainPolicy = machineSet.Spec.Template.Spec.NodeDrainPolicy
 
 	return desiredMachine
 }
@@ -1018,6 +1036,7 @@ func (r *Reconciler) reconcileUnhealthyMachines(ctx context.Context, cluster *cl
 			errs = append(errs, errors.Wrapf(err, "failed to delete Machine %s", klog.KObj(m)))
 			continue
 		}
+		ms.Status.RemediationHistory = remediation.AddHistoryEntry(ms.Status.RemediationHistory, remediation.NewHistoryEntry(m, time.Now()))
 		conditions.MarkTrue(m, clusterv1.MachineOwnerRemediatedCondition)
 		if err := r.Client.Status().Patch(ctx, m, patch); err != nil && !apierrors.IsNotFound(err) {
 			errs = append(errs, errors.Wrapf(err, "failed to update status of Machine %s", klog.KObj(m)))
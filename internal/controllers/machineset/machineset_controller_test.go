@@ -1407,6 +1407,9 @@ func TestMachineSetReconciler_reconcileUnhealthyMachines(t *testing.T) {
 		// Verify the healthy machine is not deleted.
 		m = &clusterv1.Machine{}
 		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(healthyMachine), m)).Should(Succeed())
+		// Verify the remediation of the unhealthy machine is recorded.
+		g.Expect(machineSet.Status.RemediationHistory).To(HaveLen(1))
+		g.Expect(machineSet.Status.RemediationHistory[0].Machine).To(Equal(unhealthyMachine.Name))
 	})
 
 	t.Run("should update the unhealthy machine MachineOwnerRemediated condition if preflight checks did not pass", func(t *testing.T) {
@@ -1617,7 +1620,7 @@ func TestComputeDesiredMachine(t *testing.T) {
 	existingMachine.Name = "exiting-machine-1"
 	existingMachine.UID = "abc-123-existing-machine-1"
 	existingMachine.Labels = nil
-	existingMachine.Annotations = nil
+	existingMachine.Annotations = map[string]string{clusterv1.ReplacementForAnnotation: "remediated-machine"}
 	existingMachine.Spec.InfrastructureRef = corev1.ObjectReference{
 		Kind:       "GenericInfrastructureMachine",
 		Name:       "infra-machine-1",
@@ -1637,6 +1640,8 @@ func TestComputeDesiredMachine(t *testing.T) {
 	expectedUpdatedMachine.UID = existingMachine.UID
 	expectedUpdatedMachine.Spec.InfrastructureRef = *existingMachine.Spec.InfrastructureRef.DeepCopy()
 	expectedUpdatedMachine.Spec.Bootstrap.ConfigRef = existingMachine.Spec.Bootstrap.ConfigRef.DeepCopy()
+	// The remediated Machine replaced by the existing Machine is preserved.
+	expectedUpdatedMachine.Annotations[clusterv1.ReplacementForAnnotation] = "remediated-machine"
 
 	tests := []struct {
 		name            string
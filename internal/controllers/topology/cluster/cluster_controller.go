@@ -47,6 +47,7 @@ import (
 	"sigs.k8s.io/cluster-api/internal/hooks"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 	"sigs.k8s.io/cluster-api/util"
@@ -62,6 +63,7 @@ import (
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinehealthchecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;delete
 
 // Reconciler reconciles a managed topology for a Cluster object.
@@ -134,8 +136,9 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 }
 
 // SetupForDryRun prepares the Reconciler for a dry run execution.
-func (r *Reconciler) SetupForDryRun(recorder record.EventRecorder) {
-	r.patchEngine = patches.NewEngine(r.RuntimeClient)
+// The given patchEngineOptions are used to configure the patch engine, e.g. to record the generated patches.
+func (r *Reconciler) SetupForDryRun(recorder record.EventRecorder, patchEngineOptions ...patches.EngineOption) {
+	r.patchEngine = patches.NewEngine(r.RuntimeClient, patchEngineOptions...)
 	r.recorder = recorder
 	r.patchHelperFactory = dryRunPatchHelperFactory(r.Client)
 }
@@ -235,10 +238,20 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 		return ctrl.Result{}, nil
 	}
 
+	// If the Cluster is pinned to a revision of the ClusterClass, use the ClusterClass as recorded in the revision
+	// until the Cluster is rebased.
+	if classRevision := s.Current.Cluster.Spec.Topology.ClassRevision; classRevision != "" {
+		clusterClass, err = revisions.ClusterClass(ctx, r.Client, clusterClass, classRevision)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		s.Blueprint.ClusterClass = clusterClass
+	}
+
 	// Default and Validate the Cluster variables based on information from the ClusterClass.
 	// This step is needed as if the ClusterClass does not exist at Cluster creation some fields may not be defaulted or
 	// validated in the webhook.
-	if errs := webhooks.DefaultAndValidateVariables(s.Current.Cluster, clusterClass); len(errs) > 0 {
+	if errs := webhooks.DefaultAndValidateVariables(ctx, s.Current.Cluster, clusterClass); len(errs) > 0 {
 		return ctrl.Result{}, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), s.Current.Cluster.Name, errs)
 	}
 
@@ -348,6 +348,17 @@ func (r *Reconciler) computeControlPlane(ctx context.Context, s *scope.Scope, in
 		}
 	}
 
+	// If it is required to manage the NodeDrainPolicy for the control plane, set the corresponding field.
+	nodeDrainPolicy := s.Blueprint.ClusterClass.Spec.ControlPlane.NodeDrainPolicy
+	if s.Blueprint.Topology.ControlPlane.NodeDrainPolicy != nil {
+		nodeDrainPolicy = s.Blueprint.Topology.ControlPlane.NodeDrainPolicy
+	}
+	if nodeDrainPolicy != nil {
+		if err := contract.ControlPlane().MachineTemplate().NodeDrainPolicy().Set(controlPlane, nodeDrainPolicy); err != nil {
+			return nil, errors.Wrap(err, "failed to set spec.machineTemplate.nodeDrainPolicy in the ControlPlane object")
+		}
+	}
+
 	// Sets the desired Kubernetes version for the control plane.
 	version, err := r.computeControlPlaneVersion(ctx, s)
 	if err != nil {
@@ -697,6 +708,11 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 		nodeDeletionTimeout = machineDeploymentTopology.NodeDeletionTimeout
 	}
 
+	nodeDrainPolicy := machineDeploymentClass.NodeDrainPolicy
+	if machineDeploymentTopology.NodeDrainPolicy != nil {
+		nodeDrainPolicy = machineDeploymentTopology.NodeDrainPolicy
+	}
+
 	// Compute the MachineDeployment object.
 	desiredBootstrapTemplateRef, err := calculateRefDesiredAPIVersion(currentBootstrapTemplateRef, desiredMachineDeployment.BootstrapTemplate)
 	if err != nil {
@@ -740,6 +756,7 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 					NodeDrainTimeout:        nodeDrainTimeout,
 					NodeVolumeDetachTimeout: nodeVolumeDetachTimeout,
 					NodeDeletionTimeout:     nodeDeletionTimeout,
+					NodeDrainPolicy:         nodeDrainPolicy,
 				},
 			},
 		},
@@ -1046,6 +1063,11 @@ func computeMachinePool(_ context.Context, s *scope.Scope, machinePoolTopology c
 		nodeDeletionTimeout = machinePoolTopology.NodeDeletionTimeout
 	}
 
+	nodeDrainPolicy := machinePoolClass.NodeDrainPolicy
+	if machinePoolTopology.NodeDrainPolicy != nil {
+		nodeDrainPolicy = machinePoolTopology.NodeDrainPolicy
+	}
+
 	// Compute the MachinePool object.
 	desiredBootstrapConfigRef, err := calculateRefDesiredAPIVersion(currentBootstrapConfigRef, desiredMachinePool.BootstrapObject)
 	if err != nil {
@@ -1088,6 +1110,7 @@ func computeMachinePool(_ context.Context, s *scope.Scope, machinePoolTopology c
 					NodeDrainTimeout:        nodeDrainTimeout,
 					NodeVolumeDetachTimeout: nodeVolumeDetachTimeout,
 					NodeDeletionTimeout:     nodeDeletionTimeout,
+					NodeDrainPolicy:         nodeDrainPolicy,
 				},
 			},
 		},
@@ -1389,6 +1389,9 @@ func TestComputeMachineDeployment(t *testing.T) {
 	topologyStrategy := clusterv1.MachineDeploymentStrategy{
 		Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
 	}
+	topologyNodeDrainPolicy := clusterv1.NodeDrainPolicy{
+		Mode: clusterv1.NodeDrainModeDelete,
+	}
 	mdTopology := clusterv1.MachineDeploymentTopology{
 		Metadata: clusterv1.ObjectMeta{
 			Labels: map[string]string{
@@ -1412,6 +1415,7 @@ func TestComputeMachineDeployment(t *testing.T) {
 		NodeDeletionTimeout:     &topologyDuration,
 		MinReadySeconds:         &topologyMinReadySeconds,
 		Strategy:                &topologyStrategy,
+		NodeDrainPolicy:         &topologyNodeDrainPolicy,
 	}
 
 	t.Run("Generates the machine deployment and the referenced templates", func(t *testing.T) {
@@ -1444,6 +1448,7 @@ func TestComputeMachineDeployment(t *testing.T) {
 		g.Expect(*actualMd.Spec.Template.Spec.NodeDrainTimeout).To(Equal(topologyDuration))
 		g.Expect(*actualMd.Spec.Template.Spec.NodeVolumeDetachTimeout).To(Equal(topologyDuration))
 		g.Expect(*actualMd.Spec.Template.Spec.NodeDeletionTimeout).To(Equal(topologyDuration))
+		g.Expect(actualMd.Spec.Template.Spec.NodeDrainPolicy).To(Equal(&topologyNodeDrainPolicy))
 		g.Expect(actualMd.Spec.ClusterName).To(Equal("cluster1"))
 		g.Expect(actualMd.Name).To(ContainSubstring("cluster1"))
 		g.Expect(actualMd.Name).To(ContainSubstring("big-pool-of-machines"))
@@ -2910,6 +2915,8 @@ func Test_computeMachineHealthCheck(t *testing.T) {
 			},
 			NodeStartupTimeout: &metav1.Duration{
 				Duration: time.Duration(1)},
+			// UnhealthyChecksOperator is added by defaulting values using MachineHealthCheck.Default()
+			UnhealthyChecksOperator: clusterv1.OrUnhealthyChecksOperator,
 		},
 	}
 
@@ -25,6 +25,8 @@ import (
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 
@@ -47,16 +49,52 @@ type Engine interface {
 	Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, desired *scope.ClusterState) error
 }
 
+// GeneratedPatch is a patch generated by a ClusterClass patch for a template.
+type GeneratedPatch struct {
+	// PatchName is the name of the ClusterClass patch which generated the patch.
+	PatchName string
+
+	// HolderReference is the reference to the object holding the template.
+	HolderReference runtimehooksv1.HolderReference
+
+	// TemplateRef is the reference to the template the patch is applied to.
+	TemplateRef corev1.ObjectReference
+
+	// PatchType is the type of the patch.
+	PatchType runtimehooksv1.PatchType
+
+	// Patch is the generated patch.
+	Patch []byte
+}
+
+// EngineOption is a configuration option for the patch engine.
+type EngineOption func(*engine)
+
+// WithGeneratedPatchRecorder configures a function which is called for each patch generated for a template.
+// NOTE: This can be used to inspect the generated patches, e.g. when dry running the topology controller.
+func WithGeneratedPatchRecorder(recorder func(GeneratedPatch)) EngineOption {
+	return func(e *engine) {
+		e.generatedPatchRecorder = recorder
+	}
+}
+
 // NewEngine creates a new patch engine.
-func NewEngine(runtimeClient runtimeclient.Client) Engine {
-	return &engine{
+func NewEngine(runtimeClient runtimeclient.Client, opts ...EngineOption) Engine {
+	e := &engine{
 		runtimeClient: runtimeClient,
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // engine implements the Engine interface.
 type engine struct {
 	runtimeClient runtimeclient.Client
+
+	// generatedPatchRecorder is called for each patch generated for a template, if set.
+	generatedPatchRecorder func(GeneratedPatch)
 }
 
 // Apply applies patches to the desired state according to the patches from the ClusterClass, variables from the Cluster
@@ -110,6 +148,11 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 			return errors.Wrapf(err, "failed to generate patches for patch %q", clusterClassPatch.Name)
 		}
 
+		// Record the generated patches, if a recorder is configured.
+		if e.generatedPatchRecorder != nil {
+			recordGeneratedPatches(e.generatedPatchRecorder, clusterClassPatch.Name, req, resp)
+		}
+
 		// Apply patches to the request.
 		if err := applyPatchesToRequest(ctx, req, resp); err != nil {
 			return errors.Wrapf(err, "failed to apply patches for patch %q", clusterClassPatch.Name)
@@ -150,6 +193,8 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 }
 
 // addVariablesForPatch adds variables for a given ClusterClassPatch to the items in the PatchRequest.
+// NOTE: For inline patches, derived variables are calculated for each item from its global and template-specific
+// variables, and added to the template-specific variables of the item.
 func addVariablesForPatch(blueprint *scope.ClusterBlueprint, desired *scope.ClusterState, req *runtimehooksv1.GeneratePatchesRequest, definitionFrom string) error {
 	// If there is no definitionFrom return an error.
 	if definitionFrom == "" {
@@ -179,6 +224,9 @@ func addVariablesForPatch(blueprint *scope.ClusterBlueprint, desired *scope.Clus
 		mpStateIndex[mp.Object.Name] = mp
 	}
 	for i, item := range req.Items {
+		// Reset the variables of the item, as they depend on the specific patch.
+		item.Variables = nil
+
 		// If the item is a Control Plane add the Control Plane variables.
 		if item.HolderReference.FieldPath == "spec.controlPlaneRef" {
 			item.Variables = controlPlaneVariables
@@ -223,6 +271,16 @@ func addVariablesForPatch(blueprint *scope.ClusterBlueprint, desired *scope.Clus
 			}
 			item.Variables = mpVariables
 		}
+
+		// Calculate the derived variables with the same variables the inline patches get for the item.
+		if definitionFrom == clusterv1.VariableDefinitionFromInline && len(blueprint.ClusterClass.Spec.DerivedVariables) > 0 {
+			derivedVariables, err := variables.Derived(blueprint.ClusterClass.Spec.DerivedVariables, globalVariables, item.Variables)
+			if err != nil {
+				return errors.Wrapf(err, "failed to calculate derived variables for %s %s", item.HolderReference.Kind, item.HolderReference.Name)
+			}
+			item.Variables = append(append([]runtimehooksv1.Variable{}, item.Variables...), derivedVariables...)
+		}
+
 		req.Items[i] = item
 	}
 	return nil
@@ -419,6 +477,29 @@ func createPatchGenerator(runtimeClient runtimeclient.Client, patch *clusterv1.C
 	return nil, errors.Errorf("failed to create patch generator for patch %q", patch.Name)
 }
 
+// recordGeneratedPatches calls the recorder for each patch of the GeneratePatchesResponse.
+func recordGeneratedPatches(recorder func(GeneratedPatch), patchName string, req *runtimehooksv1.GeneratePatchesRequest, resp *runtimehooksv1.GeneratePatchesResponse) {
+	for _, patch := range resp.Items {
+		generatedPatch := GeneratedPatch{
+			PatchName: patchName,
+			PatchType: patch.PatchType,
+			Patch:     patch.Patch,
+		}
+		if requestItem := getRequestItemByUID(req, patch.UID); requestItem != nil {
+			generatedPatch.HolderReference = requestItem.HolderReference
+			if template, ok := requestItem.Object.Object.(*unstructured.Unstructured); ok {
+				generatedPatch.TemplateRef = corev1.ObjectReference{
+					APIVersion: template.GetAPIVersion(),
+					Kind:       template.GetKind(),
+					Namespace:  template.GetNamespace(),
+					Name:       template.GetName(),
+				}
+			}
+		}
+		recorder(generatedPatch)
+	}
+}
+
 // applyPatchesToRequest updates the templates of a GeneratePatchesRequest by applying the patches
 // of a GeneratePatchesResponse.
 func applyPatchesToRequest(ctx context.Context, req *runtimehooksv1.GeneratePatchesRequest, resp *runtimehooksv1.GeneratePatchesResponse) error {
@@ -529,6 +610,7 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 		contract.ControlPlane().MachineTemplate().NodeDrainTimeout().Path(),
 		contract.ControlPlane().MachineTemplate().NodeVolumeDetachTimeout().Path(),
 		contract.ControlPlane().MachineTemplate().NodeDeletionTimeout().Path(),
+		contract.ControlPlane().MachineTemplate().NodeDrainPolicy().Path(),
 		contract.ControlPlane().Replicas().Path(),
 		contract.ControlPlane().Version().Path(),
 	}); err != nil {
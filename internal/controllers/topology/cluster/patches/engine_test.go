@@ -57,6 +57,7 @@ func TestApply(t *testing.T) {
 		name                   string
 		patches                []clusterv1.ClusterClassPatch
 		varDefinitions         []clusterv1.ClusterClassStatusVariable
+		derivedVariables       []clusterv1.ClusterClassDerivedVariable
 		externalPatchResponses map[string]runtimehooksv1.ResponseObject
 		expectedFields         expectedFields
 		wantErr                bool
@@ -856,6 +857,208 @@ func TestApply(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should correctly apply patches with derived variables",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{
+					Name:       "clusterPrefix",
+					Expression: pointer.String(`builtin.cluster.name + "-" + builtin.cluster.namespace`),
+				},
+				{
+					Name:     "mdPrefix",
+					Template: pointer.String(`{{ .clusterPrefix }}-md`),
+				},
+			},
+			patches: []clusterv1.ClusterClassPatch{
+				{
+					Name: "fake-patch1",
+					Definitions: []clusterv1.PatchDefinition{
+						{
+							Selector: clusterv1.PatchSelector{
+								APIVersion: builder.InfrastructureGroupVersion.String(),
+								Kind:       builder.GenericInfrastructureClusterTemplateKind,
+								MatchResources: clusterv1.PatchSelectorMatch{
+									InfrastructureCluster: true,
+								},
+							},
+							JSONPatches: []clusterv1.JSONPatch{
+								{
+									Op:   "add",
+									Path: "/spec/template/spec/resource",
+									ValueFrom: &clusterv1.JSONPatchValue{
+										Variable: pointer.String("clusterPrefix"),
+									},
+								},
+							},
+						},
+						{
+							Selector: clusterv1.PatchSelector{
+								APIVersion: builder.InfrastructureGroupVersion.String(),
+								Kind:       builder.GenericInfrastructureMachineTemplateKind,
+								MatchResources: clusterv1.PatchSelectorMatch{
+									MachineDeploymentClass: &clusterv1.PatchSelectorMatchMachineDeploymentClass{
+										Names: []string{"default-worker"},
+									},
+								},
+							},
+							JSONPatches: []clusterv1.JSONPatch{
+								{
+									Op:   "add",
+									Path: "/spec/template/spec/resource",
+									ValueFrom: &clusterv1.JSONPatchValue{
+										// Derived variables can be combined with template-specific variables in patches.
+										Template: pointer.String(`{{ .mdPrefix }}-{{ .builtin.machineDeployment.topologyName }}`),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedFields: expectedFields{
+				infrastructureCluster: map[string]interface{}{
+					"spec.resource": "cluster1-default",
+				},
+				machineDeploymentInfrastructureMachineTemplate: map[string]map[string]interface{}{
+					"default-worker-topo1": {"spec.template.spec.resource": "cluster1-default-md-default-worker-topo1"},
+					"default-worker-topo2": {"spec.template.spec.resource": "cluster1-default-md-default-worker-topo2"},
+				},
+			},
+		},
+		{
+			name: "Should calculate derived variables with the builtin and template-specific variables of each template",
+			varDefinitions: []clusterv1.ClusterClassStatusVariable{
+				{
+					Name: "default-worker-infra",
+					Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+						{
+							From: "inline",
+						},
+					},
+				},
+				{
+					Name: "default-mp-worker-infra",
+					Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+						{
+							From: "inline",
+						},
+					},
+				},
+			},
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{
+					Name:       "controlPlaneReplicas",
+					Expression: pointer.String(`has(builtin.controlPlane) ? "replicas-" + string(builtin.controlPlane.replicas) : "none"`),
+				},
+				{
+					Name:     "mdInfra",
+					Template: pointer.String(`{{ index . "default-worker-infra" }}-derived`),
+				},
+				{
+					Name:     "mpInfra",
+					Template: pointer.String(`{{ index . "default-mp-worker-infra" }}-derived`),
+				},
+			},
+			patches: []clusterv1.ClusterClassPatch{
+				{
+					Name: "fake-patch1",
+					Definitions: []clusterv1.PatchDefinition{
+						{
+							Selector: clusterv1.PatchSelector{
+								APIVersion: builder.InfrastructureGroupVersion.String(),
+								Kind:       builder.GenericInfrastructureClusterTemplateKind,
+								MatchResources: clusterv1.PatchSelectorMatch{
+									InfrastructureCluster: true,
+								},
+							},
+							JSONPatches: []clusterv1.JSONPatch{
+								{
+									Op:   "add",
+									Path: "/spec/template/spec/resource",
+									ValueFrom: &clusterv1.JSONPatchValue{
+										Variable: pointer.String("controlPlaneReplicas"),
+									},
+								},
+							},
+						},
+						{
+							Selector: clusterv1.PatchSelector{
+								APIVersion: builder.ControlPlaneGroupVersion.String(),
+								Kind:       builder.GenericControlPlaneTemplateKind,
+								MatchResources: clusterv1.PatchSelectorMatch{
+									ControlPlane: true,
+								},
+							},
+							JSONPatches: []clusterv1.JSONPatch{
+								{
+									Op:   "add",
+									Path: "/spec/template/spec/resource",
+									ValueFrom: &clusterv1.JSONPatchValue{
+										Variable: pointer.String("controlPlaneReplicas"),
+									},
+								},
+							},
+						},
+						{
+							Selector: clusterv1.PatchSelector{
+								APIVersion: builder.InfrastructureGroupVersion.String(),
+								Kind:       builder.GenericInfrastructureMachineTemplateKind,
+								MatchResources: clusterv1.PatchSelectorMatch{
+									MachineDeploymentClass: &clusterv1.PatchSelectorMatchMachineDeploymentClass{
+										Names: []string{"default-worker"},
+									},
+								},
+							},
+							JSONPatches: []clusterv1.JSONPatch{
+								{
+									Op:   "add",
+									Path: "/spec/template/spec/resource",
+									ValueFrom: &clusterv1.JSONPatchValue{
+										Variable: pointer.String("mdInfra"),
+									},
+								},
+							},
+						},
+						{
+							Selector: clusterv1.PatchSelector{
+								APIVersion: builder.InfrastructureGroupVersion.String(),
+								Kind:       builder.GenericInfrastructureMachinePoolTemplateKind,
+								MatchResources: clusterv1.PatchSelectorMatch{
+									MachinePoolClass: &clusterv1.PatchSelectorMatchMachinePoolClass{
+										Names: []string{"default-mp-worker"},
+									},
+								},
+							},
+							JSONPatches: []clusterv1.JSONPatch{
+								{
+									Op:   "add",
+									Path: "/spec/template/spec/resource",
+									ValueFrom: &clusterv1.JSONPatchValue{
+										Variable: pointer.String("mpInfra"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedFields: expectedFields{
+				infrastructureCluster: map[string]interface{}{
+					"spec.resource": "none",
+				},
+				controlPlane: map[string]interface{}{
+					"spec.resource": "replicas-3",
+				},
+				machineDeploymentInfrastructureMachineTemplate: map[string]map[string]interface{}{
+					"default-worker-topo1": {"spec.template.spec.resource": "value1-derived"},
+					"default-worker-topo2": {"spec.template.spec.resource": "default-worker-topo2-derived"},
+				},
+				machinePoolInfrastructureMachinePool: map[string]map[string]interface{}{
+					"default-mp-worker-topo1": {"spec.resource": "value2-derived"},
+					"default-mp-worker-topo2": {"spec.resource": "default-mp-worker-topo2-derived"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -905,6 +1108,10 @@ func TestApply(t *testing.T) {
 				// If there are variable definitions in the test add them to the ClusterClass.
 				blueprint.ClusterClass.Status.Variables = tt.varDefinitions
 			}
+			if len(tt.derivedVariables) > 0 {
+				// If there are derived variables in the test add them to the ClusterClass.
+				blueprint.ClusterClass.Spec.DerivedVariables = tt.derivedVariables
+			}
 
 			// Copy the desired objects before applying patches.
 			expectedCluster := desired.Cluster.DeepCopy()
@@ -976,6 +1183,55 @@ func TestApply(t *testing.T) {
 	}
 }
 
+func TestApplyWithGeneratedPatchRecorder(t *testing.T) {
+	g := NewWithT(t)
+
+	blueprint, desired := setupTestObjects()
+	blueprint.ClusterClass.Spec.Patches = []clusterv1.ClusterClassPatch{
+		{
+			Name: "fake-patch1",
+			Definitions: []clusterv1.PatchDefinition{
+				{
+					Selector: clusterv1.PatchSelector{
+						APIVersion: builder.InfrastructureGroupVersion.String(),
+						Kind:       builder.GenericInfrastructureClusterTemplateKind,
+						MatchResources: clusterv1.PatchSelectorMatch{
+							InfrastructureCluster: true,
+						},
+					},
+					JSONPatches: []clusterv1.JSONPatch{
+						{
+							Op:    "add",
+							Path:  "/spec/template/spec/resource",
+							Value: &apiextensionsv1.JSON{Raw: []byte(`"infraCluster"`)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	generatedPatches := []GeneratedPatch{}
+	patchEngine := NewEngine(nil, WithGeneratedPatchRecorder(func(patch GeneratedPatch) {
+		generatedPatches = append(generatedPatches, patch)
+	}))
+	g.Expect(patchEngine.Apply(context.Background(), blueprint, desired)).To(Succeed())
+
+	g.Expect(generatedPatches).To(HaveLen(1))
+	g.Expect(generatedPatches[0].PatchName).To(Equal("fake-patch1"))
+	g.Expect(generatedPatches[0].HolderReference).To(Equal(runtimehooksv1.HolderReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Namespace:  metav1.NamespaceDefault,
+		Name:       "cluster1",
+		FieldPath:  "spec.infrastructureRef",
+	}))
+	g.Expect(generatedPatches[0].TemplateRef.Kind).To(Equal(builder.GenericInfrastructureClusterTemplateKind))
+	g.Expect(generatedPatches[0].TemplateRef.Name).To(Equal("infraClusterTemplate1"))
+	g.Expect(generatedPatches[0].PatchType).To(Equal(runtimehooksv1.JSONPatchType))
+	g.Expect(generatedPatches[0].Patch).To(Equal([]byte(`[{"op":"add","path":"/spec/template/spec/resource","value":"infraCluster"}]`)))
+}
+
 func setupTestObjects() (*scope.ClusterBlueprint, *scope.ClusterState) {
 	infrastructureClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infraClusterTemplate1").
 		Build()
@@ -21,22 +21,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"reflect"
 	"strconv"
 	"strings"
-	"text/template"
 
-	"github.com/Masterminds/sprig/v3"
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/pkg/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/api"
 	patchvariables "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/variables"
+	topologyvariables "sigs.k8s.io/cluster-api/internal/topology/variables"
 )
 
 // jsonPatchGenerator generates JSON patches for a GeneratePatchesRequest based on a ClusterClassPatch.
@@ -97,7 +97,31 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 		}
 
 		// Loop over all PatchDefinitions.
+		template := &patchedTemplate{item: item}
 		for _, patch := range matchingPatches {
+			// Generate a JSON merge patch, if a merge patch template is set.
+			if patch.MergePatchTemplate != nil {
+				currentTemplate, err := template.get()
+				if err != nil {
+					errs = append(errs, errors.Wrapf(err, "failed to generate JSON merge patch for %q", objectKind))
+					continue
+				}
+				mergePatch, err := generateMergePatch(*patch.MergePatchTemplate, variables, currentTemplate)
+				if err != nil {
+					errs = append(errs, errors.Wrapf(err, "failed to generate JSON merge patch for %q", objectKind))
+					continue
+				}
+
+				// Add mergePatch to the response.
+				resp.Items = append(resp.Items, runtimehooksv1.GeneratePatchesResponseItem{
+					UID:       item.UID,
+					Patch:     mergePatch,
+					PatchType: runtimehooksv1.JSONMergePatchType,
+				})
+				template.add(resp.Items[len(resp.Items)-1])
+				continue
+			}
+
 			// Generate JSON patches.
 			jsonPatches, err := generateJSONPatches(patch.JSONPatches, variables)
 			if err != nil {
@@ -111,6 +135,7 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 				Patch:     jsonPatches,
 				PatchType: runtimehooksv1.JSONPatchType,
 			})
+			template.add(resp.Items[len(resp.Items)-1])
 		}
 	}
 
@@ -121,6 +146,53 @@ func (j *jsonPatchGenerator) Generate(_ context.Context, _ client.Object, req *r
 	return resp, nil
 }
 
+// patchedTemplate is the template of a GeneratePatchesRequestItem with the patches generated so far applied,
+// which is required to merge the lists of a merge patch with the lists of the template.
+// NOTE: Patches are applied only when the patched template is required, i.e. when generating a merge patch.
+type patchedTemplate struct {
+	item    *runtimehooksv1.GeneratePatchesRequestItem
+	raw     []byte
+	patches []runtimehooksv1.GeneratePatchesResponseItem
+}
+
+// add adds a patch generated for the template.
+func (t *patchedTemplate) add(patch runtimehooksv1.GeneratePatchesResponseItem) {
+	t.patches = append(t.patches, patch)
+}
+
+// get returns the template with the patches generated so far applied.
+func (t *patchedTemplate) get() ([]byte, error) {
+	if t.raw == nil {
+		t.raw = t.item.Object.Raw
+		if t.raw == nil {
+			raw, err := json.Marshal(t.item.Object.Object)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to marshal template")
+			}
+			t.raw = raw
+		}
+	}
+
+	for _, patch := range t.patches {
+		var err error
+		switch patch.PatchType {
+		case runtimehooksv1.JSONPatchType:
+			var jsonPatch jsonpatch.Patch
+			jsonPatch, err = jsonpatch.DecodePatch(patch.Patch)
+			if err == nil {
+				t.raw, err = jsonPatch.Apply(t.raw)
+			}
+		case runtimehooksv1.JSONMergePatchType:
+			t.raw, err = jsonpatch.MergePatch(t.raw, patch.Patch)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to apply previous patches to template")
+		}
+	}
+	t.patches = nil
+	return t.raw, nil
+}
+
 // matchesSelector returns true if the GeneratePatchesRequestItem matches the selector.
 func matchesSelector(req *runtimehooksv1.GeneratePatchesRequestItem, templateVariables map[string]apiextensionsv1.JSON, selector clusterv1.PatchSelector) bool {
 	gvk := req.Object.Object.GetObjectKind().GroupVersionKind()
@@ -225,7 +297,7 @@ func patchIsEnabled(enabledIf *string, variables map[string]apiextensionsv1.JSON
 	}
 
 	// Rendered template.
-	value, err := renderValueTemplate(*enabledIf, variables)
+	value, err := topologyvariables.RenderTemplate(*enabledIf, variables)
 	if err != nil {
 		return false, errors.Wrapf(err, "failed to calculate value for enabledIf")
 	}
@@ -271,6 +343,133 @@ func generateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables map[string
 	return resJSON, nil
 }
 
+// mergePatchListKeys are the keys used to identify the items of a list of objects when merging lists in merge patches,
+// in order of precedence; e.g. containers, volumes and users are identified by name, files by path.
+var mergePatchListKeys = []string{"name", "path"}
+
+// generateMergePatch generates a JSON merge patch by rendering the given template with the variables.
+// Lists in the rendered template are merged with the corresponding lists in the given template (the object
+// to be patched), so the generated JSON merge patch, which replaces lists as a whole, preserves existing list items:
+//   - items which are objects with the same value for the first of mergePatchListKeys they have are merged.
+//   - items equal to an existing item are ignored.
+//   - all other items are appended.
+func generateMergePatch(mergePatchTemplate string, variables map[string]apiextensionsv1.JSON, template []byte) ([]byte, error) {
+	value, err := topologyvariables.RenderTemplate(mergePatchTemplate, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure the rendered template is an object which only modifies the spec of a template.
+	// NOTE: Only the spec of a template can be patched, same as for JSON patches.
+	var mergePatch map[string]interface{}
+	if err := json.Unmarshal(value.Raw, &mergePatch); err != nil || mergePatch == nil {
+		return nil, errors.Errorf("rendered merge patch template must be an object: %s", string(value.Raw))
+	}
+	for key := range mergePatch {
+		if key != "spec" {
+			return nil, errors.Errorf("rendered merge patch template can only modify spec, got %q", key)
+		}
+	}
+
+	var templateObject map[string]interface{}
+	if err := json.Unmarshal(template, &templateObject); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal template")
+	}
+
+	res, err := json.Marshal(mergePatchLists(templateObject, mergePatch))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal merge patch")
+	}
+	return res, nil
+}
+
+// mergePatchLists returns the given merge patch with the lists replaced by the result of merging
+// them with the corresponding lists in the given object.
+func mergePatchLists(object, mergePatch map[string]interface{}) map[string]interface{} {
+	for key, patchValue := range mergePatch {
+		switch patchValue := patchValue.(type) {
+		case map[string]interface{}:
+			if objectValue, ok := object[key].(map[string]interface{}); ok {
+				mergePatch[key] = mergePatchLists(objectValue, patchValue)
+			}
+		case []interface{}:
+			if objectValue, ok := object[key].([]interface{}); ok {
+				mergePatch[key] = mergeLists(objectValue, patchValue)
+			}
+		}
+	}
+	return mergePatch
+}
+
+// mergeLists merges the items of a list in a merge patch into the corresponding list in the object.
+func mergeLists(objectList, patchList []interface{}) []interface{} {
+	res := append([]interface{}{}, objectList...)
+	for _, patchItem := range patchList {
+		if i := findListItem(res, patchItem); i >= 0 {
+			res[i] = mergeValues(res[i], patchItem)
+			continue
+		}
+		res = append(res, patchItem)
+	}
+	return res
+}
+
+// findListItem returns the index of the item in the list matching the given item, if any, otherwise -1.
+func findListItem(list []interface{}, item interface{}) int {
+	if item, ok := item.(map[string]interface{}); ok {
+		for _, key := range mergePatchListKeys {
+			value, ok := item[key]
+			if !ok {
+				continue
+			}
+			for i := range list {
+				if listItem, ok := list[i].(map[string]interface{}); ok && reflect.DeepEqual(listItem[key], value) {
+					return i
+				}
+			}
+			return -1
+		}
+	}
+
+	for i := range list {
+		if reflect.DeepEqual(list[i], item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeValues merges a value from a merge patch into the corresponding value in the object, with the same
+// semantics of JSON merge patches, except for lists which are merged.
+func mergeValues(objectValue, patchValue interface{}) interface{} {
+	patchMap, ok := patchValue.(map[string]interface{})
+	if !ok {
+		if patchList, ok := patchValue.([]interface{}); ok {
+			if objectList, ok := objectValue.([]interface{}); ok {
+				return mergeLists(objectList, patchList)
+			}
+		}
+		return patchValue
+	}
+	objectMap, ok := objectValue.(map[string]interface{})
+	if !ok {
+		objectMap = map[string]interface{}{}
+	}
+
+	res := make(map[string]interface{}, len(objectMap))
+	for key, value := range objectMap {
+		res[key] = value
+	}
+	for key, value := range patchMap {
+		if value == nil {
+			delete(res, key)
+			continue
+		}
+		res[key] = mergeValues(res[key], value)
+	}
+	return res
+}
+
 // calculateValue calculates a value for a JSON patch.
 func calculateValue(patch clusterv1.JSONPatch, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
 	// Return if values are set incorrectly.
@@ -302,82 +501,9 @@ func calculateValue(patch clusterv1.JSONPatch, variables map[string]apiextension
 	}
 
 	// Return rendered value template.
-	value, err := renderValueTemplate(*patch.ValueFrom.Template, variables)
+	value, err := topologyvariables.RenderTemplate(*patch.ValueFrom.Template, variables)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to calculate value for template")
 	}
 	return value, nil
 }
-
-// renderValueTemplate renders a template with the given variables as data.
-func renderValueTemplate(valueTemplate string, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
-	// Parse the template.
-	tpl, err := template.New("tpl").Funcs(sprig.HermeticTxtFuncMap()).Parse(valueTemplate)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse template: %q", valueTemplate)
-	}
-
-	// Convert the flat variables map in a nested map, so that variables can be
-	// consumed in templates like this: `{{ .builtin.cluster.name }}`
-	// NOTE: Variable values are also converted to their Go types as
-	// they cannot be directly consumed as byte arrays.
-	data, err := calculateTemplateData(variables)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to calculate template data")
-	}
-
-	// Render the template.
-	var buf bytes.Buffer
-	if err := tpl.Execute(&buf, data); err != nil {
-		return nil, errors.Wrapf(err, "failed to render template: %q", valueTemplate)
-	}
-
-	// Unmarshal the rendered template.
-	// NOTE: The YAML library is used for unmarshalling, to be able to handle YAML and JSON.
-	value := apiextensionsv1.JSON{}
-	if err := yaml.Unmarshal(buf.Bytes(), &value); err != nil {
-		return nil, errors.Wrapf(err, "failed to unmarshal rendered template: %q", buf.String())
-	}
-
-	return &value, nil
-}
-
-// calculateTemplateData calculates data for the template, by converting
-// the variables to their Go types.
-// Example:
-//   - Input:
-//     map[string]apiextensionsv1.JSON{
-//     "builtin": {Raw: []byte(`{"cluster":{"name":"cluster-name"}}`},
-//     "integerVariable": {Raw: []byte("4")},
-//     "numberVariable": {Raw: []byte("2.5")},
-//     "booleanVariable": {Raw: []byte("true")},
-//     }
-//   - Output:
-//     map[string]interface{}{
-//     "builtin": map[string]interface{}{
-//     "cluster": map[string]interface{}{
-//     "name": <string>"cluster-name"
-//     }
-//     },
-//     "integerVariable": <float64>4,
-//     "numberVariable": <float64>2.5,
-//     "booleanVariable": <bool>true,
-//     }
-func calculateTemplateData(variables map[string]apiextensionsv1.JSON) (map[string]interface{}, error) {
-	res := make(map[string]interface{}, len(variables))
-
-	// Marshal the variables into a byte array.
-	tmp, err := json.Marshal(variables)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to convert variables: failed to marshal variables")
-	}
-
-	// Unmarshal the byte array back.
-	// NOTE: This converts the "leaf nodes" of the nested map
-	// from apiextensionsv1.JSON to their Go types.
-	if err := json.Unmarshal(tmp, &res); err != nil {
-		return nil, errors.Wrapf(err, "failed to convert variables: failed to unmarshal variables")
-	}
-
-	return res, nil
-}
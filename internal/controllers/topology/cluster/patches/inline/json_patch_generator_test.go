@@ -410,6 +410,177 @@ func TestGenerate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should generate JSON merge patch Results from mergePatchTemplate",
+			patch: &clusterv1.ClusterClassPatch{
+				Name: "files",
+				Definitions: []clusterv1.PatchDefinition{
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+							Kind:       "BootstrapTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{
+								MachineDeploymentClass: &clusterv1.PatchSelectorMatchMachineDeploymentClass{
+									Names: []string{"default-worker"},
+								},
+							},
+						},
+						MergePatchTemplate: pointer.String(`
+spec:
+  template:
+    spec:
+      files:
+      - contentFrom:
+          secret:
+            key: worker-node-azure.json
+            name: "{{ .builtin.cluster.name }}-md-0-azure-json"
+        owner: root:root
+      preKubeadmCommands:
+      {{- range .preKubeadmCommands }}
+      - {{ . | quote }}
+      {{- end }}
+`),
+					},
+				},
+			},
+			req: &runtimehooksv1.GeneratePatchesRequest{
+				Variables: []runtimehooksv1.Variable{
+					{
+						Name:  "builtin",
+						Value: apiextensionsv1.JSON{Raw: []byte(`{"cluster":{"name":"cluster-name","namespace":"default","topology":{"class":"clusterClass1","version":"v1.21.1"}}}`)},
+					},
+					{
+						Name:  "preKubeadmCommands",
+						Value: apiextensionsv1.JSON{Raw: []byte(`["echo hello","echo world"]`)},
+					},
+				},
+				Items: []runtimehooksv1.GeneratePatchesRequestItem{
+					{
+						UID: "1",
+						HolderReference: runtimehooksv1.HolderReference{
+							APIVersion: clusterv1.GroupVersion.String(),
+							Kind:       "MachineDeployment",
+							Name:       "my-md-0",
+							Namespace:  "default",
+							FieldPath:  "spec.template.spec.bootstrap.configRef",
+						},
+						Variables: []runtimehooksv1.Variable{
+							{
+								Name:  "builtin",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"machineDeployment":{"class":"default-worker"}}`)},
+							},
+						},
+						Object: runtime.RawExtension{
+							Object: &unstructured.Unstructured{
+								Object: map[string]interface{}{
+									"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+									"kind":       "BootstrapTemplate",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &runtimehooksv1.GeneratePatchesResponse{
+				Items: []runtimehooksv1.GeneratePatchesResponseItem{
+					{
+						UID: "1",
+						Patch: toJSONCompact(`{"spec":{"template":{"spec":{
+"files":[{"contentFrom":{"secret":{"key":"worker-node-azure.json","name":"cluster-name-md-0-azure-json"}},"owner":"root:root"}],
+"preKubeadmCommands":["echo hello","echo world"]
+}}}}`),
+						PatchType: runtimehooksv1.JSONMergePatchType,
+					},
+				},
+			},
+		},
+		{
+			name: "Should generate JSON merge patch Results merging lists with the template and the previous patches",
+			patch: &clusterv1.ClusterClassPatch{
+				Name: "files",
+				Definitions: []clusterv1.PatchDefinition{
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+							Kind:       "BootstrapTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{
+								MachineDeploymentClass: &clusterv1.PatchSelectorMatchMachineDeploymentClass{
+									Names: []string{"default-worker"},
+								},
+							},
+						},
+						JSONPatches: []clusterv1.JSONPatch{
+							{
+								Op:    "add",
+								Path:  "/spec/template/spec/files/-",
+								Value: &apiextensionsv1.JSON{Raw: []byte(`{"path":"/etc/json-patch"}`)},
+							},
+						},
+					},
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+							Kind:       "BootstrapTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{
+								MachineDeploymentClass: &clusterv1.PatchSelectorMatchMachineDeploymentClass{
+									Names: []string{"default-worker"},
+								},
+							},
+						},
+						MergePatchTemplate: pointer.String(`
+spec:
+  template:
+    spec:
+      files:
+      - path: /etc/merge-patch
+`),
+					},
+				},
+			},
+			req: &runtimehooksv1.GeneratePatchesRequest{
+				Items: []runtimehooksv1.GeneratePatchesRequestItem{
+					{
+						UID: "1",
+						HolderReference: runtimehooksv1.HolderReference{
+							APIVersion: clusterv1.GroupVersion.String(),
+							Kind:       "MachineDeployment",
+							Name:       "my-md-0",
+							Namespace:  "default",
+							FieldPath:  "spec.template.spec.bootstrap.configRef",
+						},
+						Variables: []runtimehooksv1.Variable{
+							{
+								Name:  "builtin",
+								Value: apiextensionsv1.JSON{Raw: []byte(`{"machineDeployment":{"class":"default-worker"}}`)},
+							},
+						},
+						Object: runtime.RawExtension{
+							Raw: []byte(`{"apiVersion":"bootstrap.cluster.x-k8s.io/v1beta1","kind":"BootstrapTemplate","spec":{"template":{"spec":{"files":[{"path":"/etc/template"}]}}}}`),
+							Object: &unstructured.Unstructured{
+								Object: map[string]interface{}{
+									"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+									"kind":       "BootstrapTemplate",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &runtimehooksv1.GeneratePatchesResponse{
+				Items: []runtimehooksv1.GeneratePatchesResponseItem{
+					{
+						UID:       "1",
+						Patch:     toJSONCompact(`[{"op":"add","path":"/spec/template/spec/files/-","value":{"path":"/etc/json-patch"}}]`),
+						PatchType: runtimehooksv1.JSONPatchType,
+					},
+					{
+						UID:       "1",
+						Patch:     toJSONCompact(`{"spec":{"template":{"spec":{"files":[{"path":"/etc/template"},{"path":"/etc/json-patch"},{"path":"/etc/merge-patch"}]}}}}`),
+						PatchType: runtimehooksv1.JSONMergePatchType,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1446,6 +1617,126 @@ true
 	}
 }
 
+func TestGenerateMergePatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		mergePatchTemplate string
+		variables          map[string]apiextensionsv1.JSON
+		template           string
+		want               []byte
+		wantErr            bool
+	}{
+		{
+			name:               "Should return rendered merge patch",
+			mergePatchTemplate: `{"spec":{"template":{"spec":{"name":"{{ .variableA }}"}}}}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableA": {Raw: []byte(`"value"`)},
+			},
+			want: toJSONCompact(`{"spec":{"template":{"spec":{"name":"value"}}}}`),
+		},
+		{
+			name: "Should return rendered merge patch from YAML",
+			mergePatchTemplate: `
+spec:
+  template:
+    spec:
+      replicas: {{ .variableA }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableA": {Raw: []byte(`3`)},
+			},
+			want: toJSONCompact(`{"spec":{"template":{"spec":{"replicas":3}}}}`),
+		},
+		{
+			name: "Should merge lists with the lists in the template",
+			mergePatchTemplate: `
+spec:
+  template:
+    spec:
+      files:
+      - path: /etc/a
+        content: "{{ .variableA }}"
+      - path: /etc/c
+        content: c
+      preKubeadmCommands:
+      - echo a
+      - echo c
+      users:
+      - name: admin
+        sshAuthorizedKeys:
+        - key-b
+      - name: other`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableA": {Raw: []byte(`"a"`)},
+			},
+			template: `{"spec":{"template":{"spec":{
+"files":[{"path":"/etc/a","owner":"root:root"},{"path":"/etc/b","content":"b"}],
+"preKubeadmCommands":["echo a","echo b"],
+"users":[{"name":"admin","sshAuthorizedKeys":["key-a"]}]
+}}}}`,
+			want: toJSONCompact(`{"spec":{"template":{"spec":{
+"files":[{"content":"a","owner":"root:root","path":"/etc/a"},{"content":"b","path":"/etc/b"},{"content":"c","path":"/etc/c"}],
+"preKubeadmCommands":["echo a","echo b","echo c"],
+"users":[{"name":"admin","sshAuthorizedKeys":["key-a","key-b"]},{"name":"other"}]
+}}}}`),
+		},
+		{
+			name:               "Should keep lists not in the template",
+			mergePatchTemplate: `{"spec":{"template":{"spec":{"preKubeadmCommands":["{{ .variableA }}"]}}}}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableA": {Raw: []byte(`"echo a"`)},
+			},
+			template: `{"spec":{"template":{"spec":{"files":[{"path":"/etc/a"}]}}}}`,
+			want:     toJSONCompact(`{"spec":{"template":{"spec":{"preKubeadmCommands":["echo a"]}}}}`),
+		},
+		{
+			name:               "Fails if the template cannot be rendered",
+			mergePatchTemplate: `{"spec":{"name":"{{ .variableA.nested }}"}}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableA": {Raw: []byte(`"value"`)},
+			},
+			wantErr: true,
+		},
+		{
+			name:               "Fails if the rendered template is not an object",
+			mergePatchTemplate: `- "{{ .variableA }}"`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableA": {Raw: []byte(`"value"`)},
+			},
+			wantErr: true,
+		},
+		{
+			name:               "Fails if the rendered template is null",
+			mergePatchTemplate: `null`,
+			wantErr:            true,
+		},
+		{
+			name:               "Fails if the rendered template modifies fields outside of spec",
+			mergePatchTemplate: `{"metadata":{"name":"{{ .variableA }}"}}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableA": {Raw: []byte(`"value"`)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			template := tt.template
+			if template == "" {
+				template = "{}"
+			}
+			got, err := generateMergePatch(tt.mergePatchTemplate, tt.variables, []byte(template))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestCalculateValue(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -1887,462 +2178,6 @@ func TestCalculateValue(t *testing.T) {
 	}
 }
 
-func TestRenderValueTemplate(t *testing.T) {
-	tests := []struct {
-		name      string
-		template  string
-		variables map[string]apiextensionsv1.JSON
-		want      *apiextensionsv1.JSON
-		wantErr   bool
-	}{
-		// Basic types
-		{
-			name:     "Should render a string variable",
-			template: `{{ .stringVariable }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"stringVariable": {Raw: []byte(`"bar"`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"bar"`)},
-		},
-		{
-			name:     "Should render an integer variable",
-			template: `{{ .integerVariable }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"integerVariable": {Raw: []byte("3")},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`3`)},
-		},
-		{
-			name:     "Should render a number variable",
-			template: `{{ .numberVariable }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"numberVariable": {Raw: []byte("2.5")},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`2.5`)},
-		},
-		{
-			name:     "Should render a boolean variable",
-			template: `{{ .booleanVariable }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"booleanVariable": {Raw: []byte("true")},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`true`)},
-		},
-		{
-			name:     "Fails if the template is invalid",
-			template: `{{ booleanVariable }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"booleanVariable": {Raw: []byte("true")},
-			},
-			wantErr: true,
-		},
-		// Default variables via template
-		{
-			name:     "Should render depending on variable existence: variable is set",
-			template: `{{ if .vnetName }}{{.vnetName}}{{else}}{{.builtin.cluster.name}}-vnet{{end}}`,
-			variables: map[string]apiextensionsv1.JSON{
-				patchvariables.BuiltinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
-				"vnetName":                  {Raw: []byte(`"custom-network"`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"custom-network"`)},
-		},
-		{
-			name:     "Should render depending on variable existence: variable is not set",
-			template: `{{ if .vnetName }}{{.vnetName}}{{else}}{{.builtin.cluster.name}}-vnet{{end}}`,
-			variables: map[string]apiextensionsv1.JSON{
-				patchvariables.BuiltinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"cluster1-vnet"`)},
-		},
-		// YAML
-		{
-			name: "Should render a YAML array",
-			template: `
-- contentFrom:
-    secret:
-      key: control-plane-azure.json
-      name: "{{ .builtin.cluster.name }}-control-plane-azure-json"
-  owner: root:root
-`,
-			variables: map[string]apiextensionsv1.JSON{
-				patchvariables.BuiltinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`
-[{
-	"contentFrom":{
-		"secret":{
-			"key":"control-plane-azure.json",
-			"name":"cluster1-control-plane-azure-json"
-		}
-	},
-	"owner":"root:root"
-}]`),
-			},
-		},
-		{
-			name: "Should render a YAML object",
-			template: `
-contentFrom:
-  secret:
-    key: control-plane-azure.json
-    name: "{{ .builtin.cluster.name }}-control-plane-azure-json"
-owner: root:root
-`,
-			variables: map[string]apiextensionsv1.JSON{
-				patchvariables.BuiltinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`
-{
-	"contentFrom":{
-		"secret":{
-			"key":"control-plane-azure.json",
-			"name":"cluster1-control-plane-azure-json"
-		}
-	},
-	"owner":"root:root"
-}`),
-			},
-		},
-		// JSON
-		{
-			name: "Should render a JSON array",
-			template: `
-[{
-	"contentFrom":{
-		"secret":{
-			"key":"control-plane-azure.json",
-			"name":"{{ .builtin.cluster.name }}-control-plane-azure-json"
-		}
-	},
-	"owner":"root:root"
-}]`,
-			variables: map[string]apiextensionsv1.JSON{
-				patchvariables.BuiltinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`
-[{
-	"contentFrom":{
-		"secret":{
-			"key":"control-plane-azure.json",
-			"name":"cluster1-control-plane-azure-json"
-		}
-	},
-	"owner":"root:root"
-}]`),
-			},
-		},
-		{
-			name: "Should render a JSON object",
-			template: `
-{
-	"contentFrom":{
-		"secret":{
-			"key":"control-plane-azure.json",
-			"name":"{{ .builtin.cluster.name }}-control-plane-azure-json"
-		}
-	},
-	"owner":"root:root"
-}`,
-			variables: map[string]apiextensionsv1.JSON{
-				patchvariables.BuiltinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`
-{
-	"contentFrom":{
-		"secret":{
-			"key":"control-plane-azure.json",
-			"name":"cluster1-control-plane-azure-json"
-		}
-	},
-	"owner":"root:root"
-}`),
-			},
-		},
-		// Object types
-		{
-			name:     "Should render a object property top-level",
-			template: `{{ .variableObject }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"map[firstLevel:map[secondLevel:map[leaf:value]]]"`)}, // Not ideal but that's go templating.
-		},
-		{
-			name:     "Should render a object property firstLevel",
-			template: `{{ .variableObject.firstLevel }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"map[secondLevel:map[leaf:value]]"`)}, // Not ideal but that's go templating.
-		},
-		{
-			name:     "Should render a object property secondLevel",
-			template: `{{ .variableObject.firstLevel.secondLevel }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"map[leaf:value]"`)}, // Not ideal but that's go templating.
-		},
-		{
-			name:     "Should render a object property leaf",
-			template: `{{ .variableObject.firstLevel.secondLevel.leaf }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"value"`)},
-		},
-		{
-			name:     "Should render even if object property leaf does not exist",
-			template: `{{ .variableObject.firstLevel.secondLevel.anotherLeaf }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"\u003cno value\u003e"`)},
-		},
-		{
-			name: "Should render a object with range",
-			template: `
-{
-{{ range $key, $value := .variableObject }}
- "{{$key}}-modified": "{{$value}}",
-{{end}}
-}
-`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableObject": {Raw: []byte(`{"key1":"value1","key2":"value2"}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`{"key1-modified":"value1","key2-modified":"value2"}`)},
-		},
-		// Arrays
-		{
-			name:     "Should render an array property",
-			template: `{{ .variableArray }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableArray": {Raw: []byte(`["string1","string2","string3"]`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`["string1 string2 string3"]`)}, // // Not ideal but that's go templating.
-		},
-		{
-			name: "Should render an array property with range",
-			template: `
-{
-{{ range .variableArray }}
- "{{.}}-modified": "value",
-{{end}}
-}
-`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableArray": {Raw: []byte(`["string1","string2","string3"]`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`{"string1-modified":"value","string2-modified":"value","string3-modified":"value"}`)},
-		},
-		{
-			name:     "Should render an array property: array element",
-			template: `{{ index .variableArray 1 }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableArray": {Raw: []byte(`["string1","string2","string3"]`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"string2"`)},
-		},
-		{
-			name:     "Should render an array property: array object element field",
-			template: `{{ (index .variableArray 1).propertyA }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"variableArray": {Raw: []byte(`[{"propertyA":"A0","propertyB":"B0"},{"propertyA":"A1","propertyB":"B1"}]`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"A1"`)},
-		},
-		// Pick up config for a specific MD Class
-		{
-			name:     "Should render a object property with a lookup based on a builtin variable (class)",
-			template: `{{ (index .mdConfig .builtin.machineDeployment.class).config }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"mdConfig": {Raw: []byte(`{
-"mdClass1":{
-	"config":"configValue1"
-},
-"mdClass2":{
-	"config":"configValue2"
-}
-}`)},
-				// Schema must either support complex objects with predefined keys/mdClasses or maps with additionalProperties.
-				patchvariables.BuiltinsName: {Raw: []byte(`{
-"machineDeployment":{
-	"version":"v1.21.1",
-	"class":"mdClass2",
-	"name":"md1",
-	"topologyName":"md-topology",
-	"replicas":3
-}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
-		},
-		// Pick up config for a specific MP Class
-		{
-			name:     "Should render a object property with a lookup based on a builtin variable (class)",
-			template: `{{ (index .mpConfig .builtin.machinePool.class).config }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"mpConfig": {Raw: []byte(`{
-"mpClass1":{
-	"config":"configValue1"
-},
-"mpClass2":{
-	"config":"configValue2"
-}
-}`)},
-				// Schema must either support complex objects with predefined keys/mdClasses or maps with additionalProperties.
-				patchvariables.BuiltinsName: {Raw: []byte(`{
-"machinePool":{
-	"version":"v1.21.1",
-	"class":"mpClass2",
-	"name":"mp1",
-	"topologyName":"mp-topology",
-	"replicas":3
-}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
-		},
-		// Pick up config for a specific version
-		{
-			name:     "Should render a object property with a lookup based on a builtin variable (version)",
-			template: `{{ (index .mdConfig .builtin.machineDeployment.version).config }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"mdConfig": {Raw: []byte(`{
-"v1.21.0":{
-	"config":"configValue1"
-},
-"v1.21.1":{
-	"config":"configValue2"
-}
-}`)},
-				// Schema must either support complex objects with predefined keys/mdClasses or maps with additionalProperties.
-				patchvariables.BuiltinsName: {Raw: []byte(`{"machineDeployment":{"version":"v1.21.1","class":"mdClass2","name":"md1","topologyName":"md-topology","replicas":3}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
-		},
-		{
-			name:     "Should render a object property with a lookup based on a builtin variable (version)",
-			template: `{{ (index .mpConfig .builtin.machinePool.version).config }}`,
-			variables: map[string]apiextensionsv1.JSON{
-				"mpConfig": {Raw: []byte(`{
-"v1.21.0":{
-	"config":"configValue1"
-},
-"v1.21.1":{
-	"config":"configValue2"
-}
-}`)},
-				// Schema must either support complex objects with predefined keys/mpClasses or maps with additionalProperties.
-				patchvariables.BuiltinsName: {Raw: []byte(`{"machinePool":{"version":"v1.21.1","class":"mpClass2","name":"mp1","topologyName":"mp-topology","replicas":3}}`)},
-			},
-			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			g := NewWithT(t)
-
-			got, err := renderValueTemplate(tt.template, tt.variables)
-			if tt.wantErr {
-				g.Expect(err).To(HaveOccurred())
-				return
-			}
-			g.Expect(err).ToNot(HaveOccurred())
-
-			// Compact tt.want so we can use easily readable multi-line
-			// strings in the test definition.
-			var compactWant bytes.Buffer
-			g.Expect(json.Compact(&compactWant, tt.want.Raw)).To(Succeed())
-
-			g.Expect(string(got.Raw)).To(Equal(compactWant.String()))
-		})
-	}
-}
-
-func TestCalculateTemplateData(t *testing.T) {
-	tests := []struct {
-		name      string
-		variables map[string]apiextensionsv1.JSON
-		want      map[string]interface{}
-		wantErr   bool
-	}{
-		{
-			name: "Fails for invalid JSON value (missing closing quote)",
-			variables: map[string]apiextensionsv1.JSON{
-				"stringVariable": {Raw: []byte(`"cluster-name`)},
-			},
-			wantErr: true,
-		},
-		{
-			name: "Fails for invalid JSON value (string without quotes)",
-			variables: map[string]apiextensionsv1.JSON{
-				"stringVariable": {Raw: []byte(`cluster-name`)},
-			},
-			wantErr: true,
-		},
-		{
-			name: "Should convert basic types",
-			variables: map[string]apiextensionsv1.JSON{
-				"stringVariable":  {Raw: []byte(`"cluster-name"`)},
-				"integerVariable": {Raw: []byte("4")},
-				"numberVariable":  {Raw: []byte("2.5")},
-				"booleanVariable": {Raw: []byte("true")},
-			},
-			want: map[string]interface{}{
-				"stringVariable":  "cluster-name",
-				"integerVariable": float64(4),
-				"numberVariable":  float64(2.5),
-				"booleanVariable": true,
-			},
-		},
-		{
-			name: "Should handle nested variables correctly",
-			variables: map[string]apiextensionsv1.JSON{
-				"builtin":      {Raw: []byte(`{"cluster":{"name":"cluster-name","namespace":"default","topology":{"class":"clusterClass1","version":"v1.22.0"}},"controlPlane":{"replicas":3},"machineDeployment":{"version":"v1.21.2"},"machinePool":{"version":"v1.21.2"}}`)},
-				"userVariable": {Raw: []byte(`"value"`)},
-			},
-			want: map[string]interface{}{
-				"builtin": map[string]interface{}{
-					"cluster": map[string]interface{}{
-						"name":      "cluster-name",
-						"namespace": "default",
-						"topology": map[string]interface{}{
-							"class":   "clusterClass1",
-							"version": "v1.22.0",
-						},
-					},
-					"controlPlane": map[string]interface{}{
-						"replicas": float64(3),
-					},
-					"machineDeployment": map[string]interface{}{
-						"version": "v1.21.2",
-					},
-					"machinePool": map[string]interface{}{
-						"version": "v1.21.2",
-					},
-				},
-				"userVariable": "value",
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			g := NewWithT(t)
-
-			got, err := calculateTemplateData(tt.variables)
-			if tt.wantErr {
-				g.Expect(err).To(HaveOccurred())
-				return
-			}
-			g.Expect(err).ToNot(HaveOccurred())
-
-			g.Expect(got).To(BeComparableTo(tt.want))
-		})
-	}
-}
-
 // toJSONCompact is used to be able to write JSON values in a readable manner.
 func toJSONCompact(value string) []byte {
 	var compactValue bytes.Buffer
@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	topologyvariables "sigs.k8s.io/cluster-api/internal/topology/variables"
+)
+
+// Derived calculates the derived variables of a ClusterClass for a template using the global and the
+// template-specific variables of the template, which include the builtin variables.
+// NOTE: Derived variables are calculated in the order they are defined, so a derived variable can use the
+// derived variables defined before it.
+func Derived(derivedVariables []clusterv1.ClusterClassDerivedVariable, globalVariables, templateVariables []runtimehooksv1.Variable) ([]runtimehooksv1.Variable, error) {
+	if len(derivedVariables) == 0 {
+		return nil, nil
+	}
+
+	variables, err := MergeVariableMaps(ToMap(globalVariables), ToMap(templateVariables))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to merge global and template-specific variables")
+	}
+
+	res := []runtimehooksv1.Variable{}
+	for _, derivedVariable := range derivedVariables {
+		value, err := calculateDerivedValue(derivedVariable, variables)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to calculate derived variable %q", derivedVariable.Name)
+		}
+		variables[derivedVariable.Name] = *value
+		res = append(res, runtimehooksv1.Variable{Name: derivedVariable.Name, Value: *value})
+	}
+	return res, nil
+}
+
+// calculateDerivedValue calculates the value of a derived variable.
+func calculateDerivedValue(derivedVariable clusterv1.ClusterClassDerivedVariable, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	switch {
+	case derivedVariable.Template != nil:
+		return topologyvariables.RenderTemplate(*derivedVariable.Template, variables)
+	case derivedVariable.Expression != nil:
+		return evaluateExpression(*derivedVariable.Expression, variables)
+	default:
+		return nil, errors.New("one of template or expression must be set")
+	}
+}
+
+// evaluateExpression evaluates a CEL expression with the given variables.
+func evaluateExpression(expression string, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	variableNames := make([]string, 0, len(variables))
+	data := make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		// NOTE: Integers are unmarshalled into int64, as expected by CEL.
+		var v interface{}
+		if err := json.Unmarshal(value.Raw, &v); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal variable %q", name)
+		}
+		variableNames = append(variableNames, name)
+		data[name] = v
+	}
+
+	program, err := topologyvariables.CompileExpression(expression, variableNames)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _, err := program.Eval(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to evaluate expression: %q", expression)
+	}
+
+	// Convert the result to JSON.
+	value, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert the result of expression %q", expression)
+	}
+	raw, err := json.Marshal(value.(*structpb.Value).AsInterface())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal the result of expression %q", expression)
+	}
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
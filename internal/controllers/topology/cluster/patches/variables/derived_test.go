@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+)
+
+func TestDerived(t *testing.T) {
+	globalVariables := []runtimehooksv1.Variable{
+		{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"eu-west-1"`)}},
+		{Name: "controlPlane", Value: apiextensionsv1.JSON{Raw: []byte(`{"ha":true,"replicas":3}`)}},
+		{Name: BuiltinsName, Value: apiextensionsv1.JSON{Raw: []byte(`{"cluster":{"name":"cluster1","namespace":"default"}}`)}},
+	}
+
+	tests := []struct {
+		name              string
+		derivedVariables  []clusterv1.ClusterClassDerivedVariable
+		templateVariables []runtimehooksv1.Variable
+		want              []runtimehooksv1.Variable
+		wantErr           bool
+	}{
+		{
+			name: "no derived variables",
+			want: nil,
+		},
+		{
+			name: "template using global variables and builtins",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Template: pointer.String(`{{ .builtin.cluster.name }}-{{ .region }}`)},
+			},
+			want: []runtimehooksv1.Variable{
+				{Name: "prefix", Value: apiextensionsv1.JSON{Raw: []byte(`"cluster1-eu-west-1"`)}},
+			},
+		},
+		{
+			name: "expressions returning scalars, lists and objects",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Expression: pointer.String(`builtin.cluster.name + "-" + region`)},
+				{Name: "etcdMembers", Expression: pointer.String(`controlPlane.ha && controlPlane.replicas >= 3 ? controlPlane.replicas : 1`)},
+				{Name: "zones", Expression: pointer.String(`[region + "a", region + "b"]`)},
+				{Name: "labels", Expression: pointer.String(`{"cluster": builtin.cluster.name, "ha": controlPlane.ha}`)},
+			},
+			want: []runtimehooksv1.Variable{
+				{Name: "prefix", Value: apiextensionsv1.JSON{Raw: []byte(`"cluster1-eu-west-1"`)}},
+				{Name: "etcdMembers", Value: apiextensionsv1.JSON{Raw: []byte(`3`)}},
+				{Name: "zones", Value: apiextensionsv1.JSON{Raw: []byte(`["eu-west-1a","eu-west-1b"]`)}},
+				{Name: "labels", Value: apiextensionsv1.JSON{Raw: []byte(`{"cluster":"cluster1","ha":true}`)}},
+			},
+		},
+		{
+			name: "derived variables can use the derived variables defined before them",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Expression: pointer.String(`builtin.cluster.name + "-" + region`)},
+				{Name: "bucket", Template: pointer.String(`{{ .prefix }}-backups`)},
+			},
+			want: []runtimehooksv1.Variable{
+				{Name: "prefix", Value: apiextensionsv1.JSON{Raw: []byte(`"cluster1-eu-west-1"`)}},
+				{Name: "bucket", Value: apiextensionsv1.JSON{Raw: []byte(`"cluster1-eu-west-1-backups"`)}},
+			},
+		},
+		{
+			name: "fails if an expression references an unknown variable",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Expression: pointer.String(`unknown + "-" + region`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "template-specific variables and builtins are merged with the global ones",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Expression: pointer.String(`builtin.cluster.name + "-" + builtin.machineDeployment.topologyName + "-" + region`)},
+				{Name: "etcdMembers", Expression: pointer.String(`has(builtin.controlPlane) ? builtin.controlPlane.replicas : 0`)},
+			},
+			templateVariables: []runtimehooksv1.Variable{
+				{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east-1"`)}},
+				{Name: BuiltinsName, Value: apiextensionsv1.JSON{Raw: []byte(`{"machineDeployment":{"topologyName":"md1"}}`)}},
+			},
+			want: []runtimehooksv1.Variable{
+				{Name: "prefix", Value: apiextensionsv1.JSON{Raw: []byte(`"cluster1-md1-us-east-1"`)}},
+				{Name: "etcdMembers", Value: apiextensionsv1.JSON{Raw: []byte(`0`)}},
+			},
+		},
+		{
+			name: "fails if an expression uses template-specific builtin variables not available for the template",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Expression: pointer.String(`builtin.machineDeployment.class`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if a template can't be parsed",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Template: pointer.String(`{{ .region `)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if neither template nor expression are set",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := Derived(tt.derivedVariables, globalVariables, tt.templateVariables)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(BeComparableTo(tt.want))
+		})
+	}
+}
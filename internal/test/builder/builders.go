@@ -116,6 +116,7 @@ func (c *ClusterBuilder) Build() *clusterv1.Cluster {
 // ClusterTopologyBuilder contains the fields needed to build a testable ClusterTopology.
 type ClusterTopologyBuilder struct {
 	class                string
+	classRevision        string
 	workers              *clusterv1.WorkersTopology
 	version              string
 	controlPlaneReplicas int32
@@ -136,6 +137,12 @@ func (c *ClusterTopologyBuilder) WithClass(class string) *ClusterTopologyBuilder
 	return c
 }
 
+// WithClassRevision adds the passed ClusterClass revision name to the ClusterTopologyBuilder.
+func (c *ClusterTopologyBuilder) WithClassRevision(classRevision string) *ClusterTopologyBuilder {
+	c.classRevision = classRevision
+	return c
+}
+
 // WithVersion adds the passed version to the ClusterTopologyBuilder.
 func (c *ClusterTopologyBuilder) WithVersion(version string) *ClusterTopologyBuilder {
 	c.version = version
@@ -175,9 +182,10 @@ func (c *ClusterTopologyBuilder) WithVariables(vars ...clusterv1.ClusterVariable
 // Build returns a testable cluster Topology object with any values passed to the builder.
 func (c *ClusterTopologyBuilder) Build() *clusterv1.Topology {
 	return &clusterv1.Topology{
-		Class:   c.class,
-		Workers: c.workers,
-		Version: c.version,
+		Class:         c.class,
+		ClassRevision: c.classRevision,
+		Workers:       c.workers,
+		Version:       c.version,
 		ControlPlane: clusterv1.ControlPlaneTopology{
 			Replicas:           &c.controlPlaneReplicas,
 			MachineHealthCheck: c.controlPlaneMHC,
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package revisions implements utils for ClusterClass revisions.
+package revisions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/labels/format"
+)
+
+// revisionData is the subset of a ClusterClass recorded in a revision, i.e. the spec and the variable
+// definitions in the status; it has the same JSON representation as the corresponding ClusterClass fields.
+type revisionData struct {
+	Spec   clusterv1.ClusterClassSpec `json:"spec"`
+	Status revisionStatus             `json:"status"`
+}
+
+type revisionStatus struct {
+	Variables []clusterv1.ClusterClassStatusVariable `json:"variables,omitempty"`
+}
+
+// Data returns the data recorded in a revision of the given ClusterClass.
+func Data(clusterClass *clusterv1.ClusterClass) ([]byte, error) {
+	data, err := json.Marshal(revisionData{
+		Spec:   clusterClass.Spec,
+		Status: revisionStatus{Variables: clusterClass.Status.Variables},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revision data")
+	}
+	return data, nil
+}
+
+// Name returns the name of the revision of the given ClusterClass recording data.
+func Name(clusterClass *clusterv1.ClusterClass, data []byte) string {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(data)
+	return fmt.Sprintf("%s-%d", clusterClass.Name, hasher.Sum32())
+}
+
+// LabelValue returns the value of the ClusterClassNameLabel for the revisions of the given ClusterClass.
+func LabelValue(clusterClass *clusterv1.ClusterClass) string {
+	return format.MustFormatValue(clusterClass.Name)
+}
+
+// ClusterClass returns the ClusterClass as recorded in the revision with the given name.
+func ClusterClass(ctx context.Context, c client.Reader, clusterClass *clusterv1.ClusterClass, revisionName string) (*clusterv1.ClusterClass, error) {
+	revision := &appsv1.ControllerRevision{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: clusterClass.Namespace, Name: revisionName}, revision); err != nil {
+		return nil, errors.Wrapf(err, "failed to get revision %s of ClusterClass %s", revisionName, clusterClass.Name)
+	}
+	return FromRevision(clusterClass, revision)
+}
+
+// FromRevision returns the ClusterClass as recorded in the given revision.
+// NOTE: The returned ClusterClass is a copy of the given ClusterClass, with the spec and the variable definitions
+// in the status replaced by the ones recorded in the revision.
+func FromRevision(clusterClass *clusterv1.ClusterClass, revision *appsv1.ControllerRevision) (*clusterv1.ClusterClass, error) {
+	if revision.Labels[clusterv1.ClusterClassNameLabel] != LabelValue(clusterClass) {
+		return nil, errors.Errorf("ControllerRevision %s is not a revision of ClusterClass %s", revision.Name, clusterClass.Name)
+	}
+
+	data := revisionData{}
+	if err := json.Unmarshal(revision.Data.Raw, &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal revision %s of ClusterClass %s", revision.Name, clusterClass.Name)
+	}
+
+	res := clusterClass.DeepCopy()
+	res.Spec = data.Spec
+	res.Status.Variables = data.Status.Variables
+	return res, nil
+}
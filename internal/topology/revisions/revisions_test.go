@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revisions
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestClusterClass(t *testing.T) {
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "class",
+		},
+		Spec: clusterv1.ClusterClassSpec{
+			ControlPlane: clusterv1.ControlPlaneClass{
+				Metadata: clusterv1.ObjectMeta{Labels: map[string]string{"foo": "current"}},
+			},
+		},
+		Status: clusterv1.ClusterClassStatus{
+			Variables:          []clusterv1.ClusterClassStatusVariable{{Name: "current"}},
+			ObservedGeneration: 2,
+		},
+	}
+
+	revisionClusterClass := clusterClass.DeepCopy()
+	revisionClusterClass.Spec.ControlPlane.Metadata.Labels["foo"] = "revision"
+	revisionClusterClass.Status.Variables = []clusterv1.ClusterClassStatusVariable{{Name: "revision"}}
+	data, err := Data(revisionClusterClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revision := func(name, className string) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{clusterv1.ClusterClassNameLabel: className},
+			},
+			Data: runtime.RawExtension{Raw: data},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		revisionName string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:         "Returns the ClusterClass as recorded in the revision",
+			revisionName: "class-1",
+		},
+		{
+			name:         "Fails if the revision does not exist",
+			revisionName: "class-2",
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:         "Fails if the ControllerRevision is not a revision of the ClusterClass",
+			revisionName: "other-class-1",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithObjects(
+				revision("class-1", "class"),
+				revision("other-class-1", "other-class"),
+			).Build()
+
+			got, err := ClusterClass(context.Background(), c, clusterClass, tt.revisionName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(apierrors.IsNotFound(err)).To(Equal(tt.wantNotFound))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Name).To(Equal(clusterClass.Name))
+			g.Expect(got.Spec).To(Equal(revisionClusterClass.Spec))
+			g.Expect(got.Status.Variables).To(Equal(revisionClusterClass.Status.Variables))
+			g.Expect(got.Status.ObservedGeneration).To(Equal(clusterClass.Status.ObservedGeneration))
+
+			// The given ClusterClass is not modified.
+			g.Expect(clusterClass.Spec.ControlPlane.Metadata.Labels).To(HaveKeyWithValue("foo", "current"))
+		})
+	}
+}
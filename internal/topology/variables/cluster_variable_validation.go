@@ -17,31 +17,34 @@ limitations under the License.
 package variables
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"strings"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
 	structuralpruning "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
 	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 // ValidateClusterVariables validates ClusterVariables based on the definitions in ClusterClass `.status.variables`.
-func ValidateClusterVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) field.ErrorList {
-	return validateClusterVariables(values, definitions, true, fldPath)
+func ValidateClusterVariables(ctx context.Context, values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) field.ErrorList {
+	return validateClusterVariables(ctx, values, definitions, true, fldPath)
 }
 
 // ValidateMachineVariables validates MachineDeployment and MachinePool variables.
-func ValidateMachineVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) field.ErrorList {
-	return validateClusterVariables(values, definitions, false, fldPath)
+func ValidateMachineVariables(ctx context.Context, values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) field.ErrorList {
+	return validateClusterVariables(ctx, values, definitions, false, fldPath)
 }
 
 // validateClusterVariables validates variable values according to the corresponding definition.
-func validateClusterVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, validateRequired bool, fldPath *field.Path) field.ErrorList {
+func validateClusterVariables(ctx context.Context, values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, validateRequired bool, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
 	// Get a map of ClusterVariable values. This function validates that:
@@ -73,7 +76,7 @@ func validateClusterVariables(values []clusterv1.ClusterVariable, definitions []
 		}
 
 		// Values must be valid according to the schema in their definition.
-		allErrs = append(allErrs, ValidateClusterVariable(value.DeepCopy(), &clusterv1.ClusterClassVariable{
+		allErrs = append(allErrs, ValidateClusterVariable(ctx, value.DeepCopy(), &clusterv1.ClusterClassVariable{
 			Name:     value.Name,
 			Required: definition.Required,
 			Schema:   definition.Schema,
@@ -119,11 +122,12 @@ func validateRequiredVariables(values map[string]map[string]clusterv1.ClusterVar
 }
 
 // ValidateClusterVariable validates a clusterVariable.
-func ValidateClusterVariable(value *clusterv1.ClusterVariable, definition *clusterv1.ClusterClassVariable, fldPath *field.Path) field.ErrorList {
+func ValidateClusterVariable(ctx context.Context, value *clusterv1.ClusterVariable, definition *clusterv1.ClusterClassVariable, fldPath *field.Path) field.ErrorList {
 	// Parse JSON value.
 	var variableValue interface{}
 	// Only try to unmarshal the clusterVariable if it is not nil, otherwise the variableValue is nil.
 	// Note: A clusterVariable with a nil value is the result of setting the variable value to "null" via YAML.
+	// Note: Integers are unmarshalled into int64, as expected by CEL when evaluating x-kubernetes-validations rules.
 	if value.Value.Raw != nil {
 		if err := json.Unmarshal(value.Value.Raw, &variableValue); err != nil {
 			return field.ErrorList{field.Invalid(fldPath.Child("value"), string(value.Value.Raw),
@@ -151,7 +155,26 @@ func ValidateClusterVariable(value *clusterv1.ClusterVariable, definition *clust
 		return err
 	}
 
-	return validateUnknownFields(fldPath, value, variableValue, apiExtensionsSchema)
+	if err := validateUnknownFields(fldPath, value, variableValue, apiExtensionsSchema); err != nil {
+		return err
+	}
+
+	return validateCELRules(ctx, fldPath, value, variableValue, apiExtensionsSchema)
+}
+
+// validateCELRules validates the given variableValue against the x-kubernetes-validations rules
+// defined in variableSchema.
+func validateCELRules(ctx context.Context, fldPath *field.Path, clusterVariable *clusterv1.ClusterVariable, variableValue interface{}, variableSchema *apiextensions.JSONSchemaProps) field.ErrorList {
+	ss, err := structuralschema.NewStructural(variableSchema)
+	if err != nil {
+		return field.ErrorList{field.InternalError(fldPath,
+			fmt.Errorf("failed to create structural schema for variable %q; ClusterClass should be checked: %v", clusterVariable.Name, err))} // TODO: consider if to add ClusterClass name
+	}
+
+	// NewValidator returns nil if there are no x-kubernetes-validations rules in the schema; Validate is a no-op in this case.
+	celValidator := cel.NewValidator(ss, false, celconfig.PerCallLimit)
+	validationErrors, _ := celValidator.Validate(ctx, fldPath, ss, variableValue, nil, celconfig.RuntimeCELCostBudget)
+	return validationErrors
 }
 
 // validateUnknownFields validates the given variableValue for unknown fields.
@@ -17,6 +17,7 @@ limitations under the License.
 package variables
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -629,7 +630,7 @@ func Test_ValidateClusterVariables(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			errList := validateClusterVariables(tt.values, tt.definitions,
+			errList := validateClusterVariables(context.TODO(), tt.values, tt.definitions,
 				tt.validateRequired, field.NewPath("spec", "topology", "variables"))
 
 			if tt.wantErr {
@@ -1527,7 +1528,7 @@ func Test_ValidateClusterVariable(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			errList := ValidateClusterVariable(tt.clusterVariable, tt.clusterClassVariable,
+			errList := ValidateClusterVariable(context.TODO(), tt.clusterVariable, tt.clusterClassVariable,
 				field.NewPath("spec", "topology", "variables"))
 
 			if tt.wantErr {
@@ -1538,3 +1539,70 @@ func Test_ValidateClusterVariable(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateClusterVariableCELRules(t *testing.T) {
+	clusterClassVariable := &clusterv1.ClusterClassVariable{
+		Name: "controlPlane",
+		Schema: clusterv1.VariableSchema{
+			OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]clusterv1.JSONSchemaProps{
+					"ha": {
+						Type: "boolean",
+					},
+					"replicas": {
+						Type: "integer",
+						XValidations: []clusterv1.ValidationRule{{
+							Rule:              "self % 2 == 1",
+							MessageExpression: "'replicas must be odd, got ' + string(self)",
+						}},
+					},
+				},
+				XValidations: []clusterv1.ValidationRule{{
+					Rule:    "!self.ha || self.replicas >= 3",
+					Message: "replicas must be at least 3 if ha is enabled",
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		wantErrs []string
+	}{
+		{
+			name:  "pass if the value satisfies all the rules",
+			value: `{"ha": true, "replicas": 3}`,
+		},
+		{
+			name:  "pass if the rule does not apply to the value",
+			value: `{"ha": false, "replicas": 1}`,
+		},
+		{
+			name:     "fail with the rule message",
+			value:    `{"ha": true, "replicas": 1}`,
+			wantErrs: []string{"replicas must be at least 3 if ha is enabled"},
+		},
+		{
+			name:     "fail with the evaluated message expression of a nested rule",
+			value:    `{"ha": false, "replicas": 2}`,
+			wantErrs: []string{"replicas must be odd, got 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errList := ValidateClusterVariable(context.TODO(), &clusterv1.ClusterVariable{
+				Name:  clusterClassVariable.Name,
+				Value: apiextensionsv1.JSON{Raw: []byte(tt.value)},
+			}, clusterClassVariable, field.NewPath("spec", "topology", "variables"))
+
+			g.Expect(errList).To(HaveLen(len(tt.wantErrs)))
+			for i := range tt.wantErrs {
+				g.Expect(errList[i].Detail).To(Equal(tt.wantErrs[i]))
+			}
+		})
+	}
+}
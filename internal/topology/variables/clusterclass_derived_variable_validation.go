@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"fmt"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ValidateClusterClassDerivedVariables validates the derived variables of a ClusterClass.
+func ValidateClusterClassDerivedVariables(derivedVariables []clusterv1.ClusterClassDerivedVariable, clusterClassVariables []clusterv1.ClusterClassVariable, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Derived variables can use the builtin variable, the ClusterClass variables and the derived variables defined before them.
+	variableNames := sets.Set[string]{}.Insert(builtinsName)
+	for _, clusterClassVariable := range clusterClassVariables {
+		variableNames.Insert(clusterClassVariable.Name)
+	}
+
+	for i, derivedVariable := range derivedVariables {
+		allErrs = append(allErrs, validateClusterClassDerivedVariable(derivedVariable, variableNames, fldPath.Index(i))...)
+		variableNames.Insert(derivedVariable.Name)
+	}
+
+	return allErrs
+}
+
+// validateClusterClassDerivedVariable validates a ClusterClassDerivedVariable.
+func validateClusterClassDerivedVariable(derivedVariable clusterv1.ClusterClassDerivedVariable, variableNames sets.Set[string], fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Validate variable name.
+	allErrs = append(allErrs, validateClusterClassVariableName(derivedVariable.Name, fldPath.Child("name"))...)
+	if variableNames.Has(derivedVariable.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), derivedVariable.Name,
+			fmt.Sprintf("variable name must be unique. Variable with name %q is defined more than once", derivedVariable.Name)))
+	}
+
+	switch {
+	case derivedVariable.Template == nil && derivedVariable.Expression == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "one of template or expression must be set"))
+	case derivedVariable.Template != nil && derivedVariable.Expression != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "template and expression are mutually exclusive"))
+	case derivedVariable.Template != nil:
+		if _, err := template.New("template").Funcs(TemplateFuncs()).Parse(*derivedVariable.Template); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), *derivedVariable.Template,
+				fmt.Sprintf("template can not be parsed: %v", err)))
+		}
+	case derivedVariable.Expression != nil:
+		if _, err := CompileExpression(*derivedVariable.Expression, sets.List(variableNames)); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("expression"), *derivedVariable.Expression, err.Error()))
+		}
+	}
+
+	return allErrs
+}
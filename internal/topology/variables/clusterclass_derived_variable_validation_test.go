@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func Test_ValidateClusterClassDerivedVariables(t *testing.T) {
+	clusterClassVariables := []clusterv1.ClusterClassVariable{
+		{
+			Name: "region",
+			Schema: clusterv1.VariableSchema{
+				OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		derivedVariables []clusterv1.ClusterClassDerivedVariable
+		wantErr          bool
+	}{
+		{
+			name: "pass with valid templates and expressions",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Expression: pointer.String(`builtin.cluster.name + "-" + region`)},
+				{Name: "bucket", Template: pointer.String(`{{ .prefix }}-{{ .builtin.cluster.namespace | lower }}`)},
+				{Name: "bucketURL", Expression: pointer.String(`"s3://" + bucket`)},
+			},
+		},
+		{
+			name: "fail if the name is not set",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Template: pointer.String(`{{ .region }}`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the name is builtin",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "builtin", Template: pointer.String(`{{ .region }}`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the name is already used by a ClusterClass variable",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "region", Template: pointer.String(`{{ .region }}`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the name is used by more than one derived variable",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Template: pointer.String(`{{ .region }}`)},
+				{Name: "prefix", Template: pointer.String(`{{ .region }}`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if neither template nor expression are set",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if both template and expression are set",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Template: pointer.String(`{{ .region }}`), Expression: pointer.String(`region`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the template can't be parsed",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Template: pointer.String(`{{ .region `)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the expression does not compile",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "prefix", Expression: pointer.String(`region +`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the expression uses a derived variable defined after it",
+			derivedVariables: []clusterv1.ClusterClassDerivedVariable{
+				{Name: "bucketURL", Expression: pointer.String(`"s3://" + bucket`)},
+				{Name: "bucket", Expression: pointer.String(`region + "-backups"`)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errList := ValidateClusterClassDerivedVariables(tt.derivedVariables, clusterClassVariables,
+				field.NewPath("spec", "derivedVariables"))
+
+			if tt.wantErr {
+				g.Expect(errList).NotTo(BeEmpty())
+				return
+			}
+			g.Expect(errList).To(BeEmpty())
+		})
+	}
+}
@@ -22,11 +22,17 @@ import (
 	"strings"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsvalidation "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/validation"
 	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel/model"
 	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
 	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	apiservercel "k8s.io/apiserver/pkg/cel"
+	"k8s.io/apiserver/pkg/cel/environment"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -151,6 +157,103 @@ func validateRootSchema(ctx context.Context, clusterClassVariable *clusterv1.Clu
 	}
 
 	allErrs = append(allErrs, validateSchema(apiExtensionsSchema, fldPath)...)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	// Validate the CEL validation rules; this requires the structural schema of the variable itself,
+	// so the rules can be compiled against the type of the variable value.
+	variableStructural, err := structuralschema.NewStructural(apiExtensionsSchema)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, "", err.Error()))
+	}
+	allErrs = append(allErrs, validateCELValidations(variableStructural, model.SchemaDeclType(variableStructural, false), fldPath)...)
+	return allErrs
+}
+
+// validateCELValidations validates the x-kubernetes-validations rules of the given schema and of all its nested schemas
+// by compiling them against the type of the value they are going to be evaluated against.
+func validateCELValidations(schema *structuralschema.Structural, declType *apiservercel.DeclType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(schema.XValidations) > 0 {
+		allErrs = append(allErrs, compileCELRules(schema, declType, fldPath.Child("x-kubernetes-validations"))...)
+	}
+
+	// Types not supported by CEL are omitted from the declType; there are no rules to compile below them.
+	if declType == nil {
+		return allErrs
+	}
+
+	for propertyName, propertySchema := range schema.Properties {
+		p := propertySchema
+		var propertyType *apiservercel.DeclType
+		if escapedPropertyName, ok := apiservercel.Escape(propertyName); ok {
+			if f, ok := declType.Fields[escapedPropertyName]; ok {
+				propertyType = f.Type
+			}
+		}
+		allErrs = append(allErrs, validateCELValidations(&p, propertyType, fldPath.Child("properties").Key(propertyName))...)
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Structural != nil {
+		allErrs = append(allErrs, validateCELValidations(schema.AdditionalProperties.Structural, declType.ElemType, fldPath.Child("additionalProperties"))...)
+	}
+
+	if schema.Items != nil {
+		allErrs = append(allErrs, validateCELValidations(schema.Items, declType.ElemType, fldPath.Child("items"))...)
+	}
+
+	return allErrs
+}
+
+// compileCELRules validates the x-kubernetes-validations rules defined at the level of the given schema.
+func compileCELRules(schema *structuralschema.Structural, declType *apiservercel.DeclType, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, rule := range schema.XValidations {
+		if rule.Rule == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("rule"), "rule must be defined"))
+		}
+		if strings.Contains(rule.Message, "\n") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("message"), rule.Message, "message must not contain line breaks"))
+		}
+		if rule.Message == "" && strings.Contains(rule.Rule, "\n") {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("message"), "message must be specified if rule contains line breaks"))
+		}
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if declType == nil {
+		return append(allErrs, field.Forbidden(fldPath, "x-kubernetes-validations are not supported for this type"))
+	}
+
+	compilationResults, err := cel.Compile(schema, declType, celconfig.PerCallLimit, environment.MustBaseEnvSet(environment.DefaultCompatibilityVersion()), cel.NewExpressionsEnvLoader())
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("failed to compile x-kubernetes-validations rules: %v", err)))
+	}
+
+	for i, result := range compilationResults {
+		rule := schema.XValidations[i]
+		switch {
+		case result.Error != nil:
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("rule"), rule.Rule, result.Error.Detail))
+		case result.TransitionRule:
+			// Variable values are validated without their previous value, so rules comparing against it
+			// would never be evaluated.
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("rule"), "transition rules using oldSelf are not supported"))
+		case result.MaxCost > apiextensionsvalidation.StaticEstimatedCostLimit:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i).Child("rule"),
+				fmt.Sprintf("estimated rule cost exceeds budget by factor of %.1fx (try adding maxLength, maxItems, or maxProperties to the schema)",
+					float64(result.MaxCost)/float64(apiextensionsvalidation.StaticEstimatedCostLimit))))
+		}
+		if result.MessageExpressionError != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("messageExpression"), rule.MessageExpression, result.MessageExpressionError.Detail))
+		}
+	}
+
 	return allErrs
 }
 
@@ -967,6 +967,117 @@ func Test_ValidateClusterClassVariable(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "pass if variable has valid CEL validation rules",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "var",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]clusterv1.JSONSchemaProps{
+							"ha": {
+								Type: "boolean",
+							},
+							"replicas": {
+								Type: "integer",
+								XValidations: []clusterv1.ValidationRule{{
+									Rule: "self >= 1",
+								}},
+							},
+						},
+						XValidations: []clusterv1.ValidationRule{{
+							Rule:              "!self.ha || self.replicas >= 3",
+							MessageExpression: "'replicas must be at least 3 if ha is enabled, got ' + string(self.replicas)",
+						}},
+					},
+				},
+			},
+		},
+		{
+			name: "fail if variable has a CEL validation rule which does not compile",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "var",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]clusterv1.JSONSchemaProps{
+							"replicas": {
+								Type: "integer",
+							},
+						},
+						XValidations: []clusterv1.ValidationRule{{
+							Rule: "self.unknownField >= 3",
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if variable has a CEL validation rule with a nested rule which does not compile",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "var",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "array",
+						Items: &clusterv1.JSONSchemaProps{
+							Type: "string",
+							XValidations: []clusterv1.ValidationRule{{
+								Rule: "self > 3",
+							}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if variable has a CEL validation rule with a messageExpression which does not compile",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "var",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "integer",
+						XValidations: []clusterv1.ValidationRule{{
+							Rule:              "self >= 3",
+							MessageExpression: "self + 1",
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if variable has a CEL transition rule",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "var",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "integer",
+						XValidations: []clusterv1.ValidationRule{{
+							Rule: "self >= oldSelf",
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if variable has a CEL validation rule with a message containing line breaks",
+			clusterClassVariable: &clusterv1.ClusterClassVariable{
+				Name: "var",
+				Schema: clusterv1.VariableSchema{
+					OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+						Type: "integer",
+						XValidations: []clusterv1.ValidationRule{{
+							Rule:    "self >= 3",
+							Message: "must be\nat least 3",
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -14,5 +14,6 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package variables implements validation and defaulting for ClusterClass variables.
+// Package variables implements validation and defaulting for ClusterClass variables, as well as
+// rendering the Go templates and compiling the CEL expressions used in ClusterClasses.
 package variables
@@ -112,6 +112,14 @@ func convertToAPIExtensionsJSONSchemaProps(schema *clusterv1.JSONSchemaProps, fl
 			}
 		}
 	}
+	for _, validation := range schema.XValidations {
+		props.XValidations = append(props.XValidations, apiextensions.ValidationRule{
+			Rule:              validation.Rule,
+			Message:           validation.Message,
+			MessageExpression: validation.MessageExpression,
+		})
+	}
+
 	if schema.Maximum != nil {
 		f := float64(*schema.Maximum)
 		props.Maximum = &f
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// TemplateFuncs returns the functions which can be used in ClusterClass patch templates.
+// The functions are the hermetic functions of the Sprig library, i.e. the functions which always
+// evaluate to the same result for a given input (e.g. string, list, dict, semver, base64 and default
+// functions), plus toYaml.
+// NOTE: This is the only function library which should be used to parse and render templates
+// in ClusterClasses, so that validation and rendering are consistent.
+func TemplateFuncs() template.FuncMap {
+	funcs := sprig.HermeticTxtFuncMap()
+	funcs["toYaml"] = toYaml
+	return funcs
+}
+
+// toYaml marshals the given value to YAML; it returns an empty string if the value cannot be marshalled.
+// NOTE: The trailing newline is dropped, so the function can be combined with indent and nindent.
+func toYaml(v interface{}) string {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// RenderTemplate renders a template with the given variables as data.
+func RenderTemplate(valueTemplate string, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	// Parse the template.
+	tpl, err := template.New("tpl").Funcs(TemplateFuncs()).Parse(valueTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse template: %q", valueTemplate)
+	}
+
+	// Convert the flat variables map in a nested map, so that variables can be
+	// consumed in templates like this: `{{ .builtin.cluster.name }}`
+	// NOTE: Variable values are also converted to their Go types as
+	// they cannot be directly consumed as byte arrays.
+	data, err := calculateTemplateData(variables)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to calculate template data")
+	}
+
+	// Render the template.
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrapf(err, "failed to render template: %q", valueTemplate)
+	}
+
+	// Unmarshal the rendered template.
+	// NOTE: The YAML library is used for unmarshalling, to be able to handle YAML and JSON.
+	value := apiextensionsv1.JSON{}
+	if err := yaml.Unmarshal(buf.Bytes(), &value); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal rendered template: %q", buf.String())
+	}
+
+	return &value, nil
+}
+
+// calculateTemplateData calculates data for the template, by converting
+// the variables to their Go types.
+// Example:
+//   - Input:
+//     map[string]apiextensionsv1.JSON{
+//     "builtin": {Raw: []byte(`{"cluster":{"name":"cluster-name"}}`},
+//     "integerVariable": {Raw: []byte("4")},
+//     "numberVariable": {Raw: []byte("2.5")},
+//     "booleanVariable": {Raw: []byte("true")},
+//     }
+//   - Output:
+//     map[string]interface{}{
+//     "builtin": map[string]interface{}{
+//     "cluster": map[string]interface{}{
+//     "name": <string>"cluster-name"
+//     }
+//     },
+//     "integerVariable": <float64>4,
+//     "numberVariable": <float64>2.5,
+//     "booleanVariable": <bool>true,
+//     }
+func calculateTemplateData(variables map[string]apiextensionsv1.JSON) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(variables))
+
+	// Marshal the variables into a byte array.
+	tmp, err := json.Marshal(variables)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert variables: failed to marshal variables")
+	}
+
+	// Unmarshal the byte array back.
+	// NOTE: This converts the "leaf nodes" of the nested map
+	// from apiextensionsv1.JSON to their Go types.
+	if err := json.Unmarshal(tmp, &res); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert variables: failed to unmarshal variables")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,563 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		variables map[string]apiextensionsv1.JSON
+		want      *apiextensionsv1.JSON
+		wantErr   bool
+	}{
+		// Basic types
+		{
+			name:     "Should render a string variable",
+			template: `{{ .stringVariable }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"stringVariable": {Raw: []byte(`"bar"`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"bar"`)},
+		},
+		{
+			name:     "Should render an integer variable",
+			template: `{{ .integerVariable }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"integerVariable": {Raw: []byte("3")},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`3`)},
+		},
+		{
+			name:     "Should render a number variable",
+			template: `{{ .numberVariable }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"numberVariable": {Raw: []byte("2.5")},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`2.5`)},
+		},
+		{
+			name:     "Should render a boolean variable",
+			template: `{{ .booleanVariable }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"booleanVariable": {Raw: []byte("true")},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`true`)},
+		},
+		{
+			name:     "Fails if the template is invalid",
+			template: `{{ booleanVariable }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"booleanVariable": {Raw: []byte("true")},
+			},
+			wantErr: true,
+		},
+		// Default variables via template
+		{
+			name:     "Should render depending on variable existence: variable is set",
+			template: `{{ if .vnetName }}{{.vnetName}}{{else}}{{.builtin.cluster.name}}-vnet{{end}}`,
+			variables: map[string]apiextensionsv1.JSON{
+				builtinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
+				"vnetName":   {Raw: []byte(`"custom-network"`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"custom-network"`)},
+		},
+		{
+			name:     "Should render depending on variable existence: variable is not set",
+			template: `{{ if .vnetName }}{{.vnetName}}{{else}}{{.builtin.cluster.name}}-vnet{{end}}`,
+			variables: map[string]apiextensionsv1.JSON{
+				builtinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"cluster1-vnet"`)},
+		},
+		// YAML
+		{
+			name: "Should render a YAML array",
+			template: `
+- contentFrom:
+    secret:
+      key: control-plane-azure.json
+      name: "{{ .builtin.cluster.name }}-control-plane-azure-json"
+  owner: root:root
+`,
+			variables: map[string]apiextensionsv1.JSON{
+				builtinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`
+[{
+	"contentFrom":{
+		"secret":{
+			"key":"control-plane-azure.json",
+			"name":"cluster1-control-plane-azure-json"
+		}
+	},
+	"owner":"root:root"
+}]`),
+			},
+		},
+		{
+			name: "Should render a YAML object",
+			template: `
+contentFrom:
+  secret:
+    key: control-plane-azure.json
+    name: "{{ .builtin.cluster.name }}-control-plane-azure-json"
+owner: root:root
+`,
+			variables: map[string]apiextensionsv1.JSON{
+				builtinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`
+{
+	"contentFrom":{
+		"secret":{
+			"key":"control-plane-azure.json",
+			"name":"cluster1-control-plane-azure-json"
+		}
+	},
+	"owner":"root:root"
+}`),
+			},
+		},
+		// JSON
+		{
+			name: "Should render a JSON array",
+			template: `
+[{
+	"contentFrom":{
+		"secret":{
+			"key":"control-plane-azure.json",
+			"name":"{{ .builtin.cluster.name }}-control-plane-azure-json"
+		}
+	},
+	"owner":"root:root"
+}]`,
+			variables: map[string]apiextensionsv1.JSON{
+				builtinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`
+[{
+	"contentFrom":{
+		"secret":{
+			"key":"control-plane-azure.json",
+			"name":"cluster1-control-plane-azure-json"
+		}
+	},
+	"owner":"root:root"
+}]`),
+			},
+		},
+		{
+			name: "Should render a JSON object",
+			template: `
+{
+	"contentFrom":{
+		"secret":{
+			"key":"control-plane-azure.json",
+			"name":"{{ .builtin.cluster.name }}-control-plane-azure-json"
+		}
+	},
+	"owner":"root:root"
+}`,
+			variables: map[string]apiextensionsv1.JSON{
+				builtinsName: {Raw: []byte(`{"cluster":{"name":"cluster1"}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`
+{
+	"contentFrom":{
+		"secret":{
+			"key":"control-plane-azure.json",
+			"name":"cluster1-control-plane-azure-json"
+		}
+	},
+	"owner":"root:root"
+}`),
+			},
+		},
+		// Object types
+		{
+			name:     "Should render a object property top-level",
+			template: `{{ .variableObject }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"map[firstLevel:map[secondLevel:map[leaf:value]]]"`)}, // Not ideal but that's go templating.
+		},
+		{
+			name:     "Should render a object property firstLevel",
+			template: `{{ .variableObject.firstLevel }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"map[secondLevel:map[leaf:value]]"`)}, // Not ideal but that's go templating.
+		},
+		{
+			name:     "Should render a object property secondLevel",
+			template: `{{ .variableObject.firstLevel.secondLevel }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"map[leaf:value]"`)}, // Not ideal but that's go templating.
+		},
+		{
+			name:     "Should render a object property leaf",
+			template: `{{ .variableObject.firstLevel.secondLevel.leaf }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"value"`)},
+		},
+		{
+			name:     "Should render even if object property leaf does not exist",
+			template: `{{ .variableObject.firstLevel.secondLevel.anotherLeaf }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableObject": {Raw: []byte(`{"firstLevel":{"secondLevel":{"leaf":"value"}}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"\u003cno value\u003e"`)},
+		},
+		{
+			name: "Should render a object with range",
+			template: `
+{
+{{ range $key, $value := .variableObject }}
+ "{{$key}}-modified": "{{$value}}",
+{{end}}
+}
+`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableObject": {Raw: []byte(`{"key1":"value1","key2":"value2"}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`{"key1-modified":"value1","key2-modified":"value2"}`)},
+		},
+		// Arrays
+		{
+			name:     "Should render an array property",
+			template: `{{ .variableArray }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableArray": {Raw: []byte(`["string1","string2","string3"]`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`["string1 string2 string3"]`)}, // // Not ideal but that's go templating.
+		},
+		{
+			name: "Should render an array property with range",
+			template: `
+{
+{{ range .variableArray }}
+ "{{.}}-modified": "value",
+{{end}}
+}
+`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableArray": {Raw: []byte(`["string1","string2","string3"]`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`{"string1-modified":"value","string2-modified":"value","string3-modified":"value"}`)},
+		},
+		{
+			name:     "Should render an array property: array element",
+			template: `{{ index .variableArray 1 }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableArray": {Raw: []byte(`["string1","string2","string3"]`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"string2"`)},
+		},
+		{
+			name:     "Should render an array property: array object element field",
+			template: `{{ (index .variableArray 1).propertyA }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"variableArray": {Raw: []byte(`[{"propertyA":"A0","propertyB":"B0"},{"propertyA":"A1","propertyB":"B1"}]`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"A1"`)},
+		},
+		// Pick up config for a specific MD Class
+		{
+			name:     "Should render a object property with a lookup based on a builtin variable (class)",
+			template: `{{ (index .mdConfig .builtin.machineDeployment.class).config }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"mdConfig": {Raw: []byte(`{
+"mdClass1":{
+	"config":"configValue1"
+},
+"mdClass2":{
+	"config":"configValue2"
+}
+}`)},
+				// Schema must either support complex objects with predefined keys/mdClasses or maps with additionalProperties.
+				builtinsName: {Raw: []byte(`{
+"machineDeployment":{
+	"version":"v1.21.1",
+	"class":"mdClass2",
+	"name":"md1",
+	"topologyName":"md-topology",
+	"replicas":3
+}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
+		},
+		// Pick up config for a specific MP Class
+		{
+			name:     "Should render a object property with a lookup based on a builtin variable (class)",
+			template: `{{ (index .mpConfig .builtin.machinePool.class).config }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"mpConfig": {Raw: []byte(`{
+"mpClass1":{
+	"config":"configValue1"
+},
+"mpClass2":{
+	"config":"configValue2"
+}
+}`)},
+				// Schema must either support complex objects with predefined keys/mdClasses or maps with additionalProperties.
+				builtinsName: {Raw: []byte(`{
+"machinePool":{
+	"version":"v1.21.1",
+	"class":"mpClass2",
+	"name":"mp1",
+	"topologyName":"mp-topology",
+	"replicas":3
+}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
+		},
+		// Pick up config for a specific version
+		{
+			name:     "Should render a object property with a lookup based on a builtin variable (version)",
+			template: `{{ (index .mdConfig .builtin.machineDeployment.version).config }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"mdConfig": {Raw: []byte(`{
+"v1.21.0":{
+	"config":"configValue1"
+},
+"v1.21.1":{
+	"config":"configValue2"
+}
+}`)},
+				// Schema must either support complex objects with predefined keys/mdClasses or maps with additionalProperties.
+				builtinsName: {Raw: []byte(`{"machineDeployment":{"version":"v1.21.1","class":"mdClass2","name":"md1","topologyName":"md-topology","replicas":3}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
+		},
+		{
+			name:     "Should render a object property with a lookup based on a builtin variable (version)",
+			template: `{{ (index .mpConfig .builtin.machinePool.version).config }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"mpConfig": {Raw: []byte(`{
+"v1.21.0":{
+	"config":"configValue1"
+},
+"v1.21.1":{
+	"config":"configValue2"
+}
+}`)},
+				// Schema must either support complex objects with predefined keys/mpClasses or maps with additionalProperties.
+				builtinsName: {Raw: []byte(`{"machinePool":{"version":"v1.21.1","class":"mpClass2","name":"mp1","topologyName":"mp-topology","replicas":3}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"configValue2"`)},
+		},
+		// Functions
+		{
+			name:     "Should render with string functions",
+			template: `{{ .stringVariable | upper | trimSuffix "-SUFFIX" | quote }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"stringVariable": {Raw: []byte(`"value-suffix"`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"VALUE"`)},
+		},
+		{
+			name:     "Should render with default function",
+			template: `{{ .stringVariable | default "default-value" }}`,
+			want:     &apiextensionsv1.JSON{Raw: []byte(`"default-value"`)},
+		},
+		{
+			name:     "Should render with base64 functions",
+			template: `{{ .stringVariable | b64enc }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"stringVariable": {Raw: []byte(`"value"`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`"dmFsdWU="`)},
+		},
+		{
+			name:     "Should render with semver functions",
+			template: `{{ semverCompare ">= 1.28.0" .builtin.controlPlane.version }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				builtinsName: {Raw: []byte(`{"controlPlane":{"version":"v1.28.1"}}`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`true`)},
+		},
+		{
+			name:     "Should render with list and dict functions",
+			template: `{{ $d := dict "key" (list "a" "b" | join ",") }}{{ get $d "key" }}`,
+			want:     &apiextensionsv1.JSON{Raw: []byte(`"a,b"`)},
+		},
+		{
+			name: "Should render with toYaml function",
+			template: `
+files:
+{{ .files | toYaml | indent 2 }}`,
+			variables: map[string]apiextensionsv1.JSON{
+				"files": {Raw: []byte(`[{"path":"/etc/file","content":"value"}]`)},
+			},
+			want: &apiextensionsv1.JSON{Raw: []byte(`{"files":[{"content":"value","path":"/etc/file"}]}`)},
+		},
+		{
+			name:     "Fails if a non-hermetic function is used",
+			template: `{{ now }}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := RenderTemplate(tt.template, tt.variables)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			// Compact tt.want so we can use easily readable multi-line
+			// strings in the test definition.
+			var compactWant bytes.Buffer
+			g.Expect(json.Compact(&compactWant, tt.want.Raw)).To(Succeed())
+
+			g.Expect(string(got.Raw)).To(Equal(compactWant.String()))
+		})
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	g := NewWithT(t)
+
+	// The functions documented for ClusterClass patch templates must be available.
+	// NOTE: This ensures that upgrading Sprig does not silently change the function library.
+	documentedFuncs := []string{
+		// String functions.
+		"trim", "trimPrefix", "trimSuffix", "upper", "lower", "title", "replace", "contains",
+		"hasPrefix", "hasSuffix", "quote", "squote", "cat", "indent", "nindent", "join", "split", "splitList",
+		"regexMatch", "regexReplaceAll",
+		// List and dict functions.
+		"list", "first", "last", "append", "prepend", "concat", "has", "uniq", "without", "compact",
+		"dict", "get", "set", "hasKey", "keys", "values", "merge", "pick", "omit",
+		// Semver functions.
+		"semver", "semverCompare",
+		// Encoding functions.
+		"b64enc", "b64dec", "toJson", "toYaml",
+		// Default functions.
+		"default", "empty", "coalesce", "ternary",
+	}
+	funcs := TemplateFuncs()
+	for _, name := range documentedFuncs {
+		g.Expect(funcs).To(HaveKey(name))
+	}
+
+	// Non-hermetic functions must not be available.
+	for _, name := range []string{"now", "date", "randAlpha", "uuidv4", "env", "expandenv"} {
+		g.Expect(funcs).ToNot(HaveKey(name))
+	}
+}
+
+func TestCalculateTemplateData(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables map[string]apiextensionsv1.JSON
+		want      map[string]interface{}
+		wantErr   bool
+	}{
+		{
+			name: "Fails for invalid JSON value (missing closing quote)",
+			variables: map[string]apiextensionsv1.JSON{
+				"stringVariable": {Raw: []byte(`"cluster-name`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Fails for invalid JSON value (string without quotes)",
+			variables: map[string]apiextensionsv1.JSON{
+				"stringVariable": {Raw: []byte(`cluster-name`)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should convert basic types",
+			variables: map[string]apiextensionsv1.JSON{
+				"stringVariable":  {Raw: []byte(`"cluster-name"`)},
+				"integerVariable": {Raw: []byte("4")},
+				"numberVariable":  {Raw: []byte("2.5")},
+				"booleanVariable": {Raw: []byte("true")},
+			},
+			want: map[string]interface{}{
+				"stringVariable":  "cluster-name",
+				"integerVariable": float64(4),
+				"numberVariable":  float64(2.5),
+				"booleanVariable": true,
+			},
+		},
+		{
+			name: "Should handle nested variables correctly",
+			variables: map[string]apiextensionsv1.JSON{
+				"builtin":      {Raw: []byte(`{"cluster":{"name":"cluster-name","namespace":"default","topology":{"class":"clusterClass1","version":"v1.22.0"}},"controlPlane":{"replicas":3},"machineDeployment":{"version":"v1.21.2"},"machinePool":{"version":"v1.21.2"}}`)},
+				"userVariable": {Raw: []byte(`"value"`)},
+			},
+			want: map[string]interface{}{
+				"builtin": map[string]interface{}{
+					"cluster": map[string]interface{}{
+						"name":      "cluster-name",
+						"namespace": "default",
+						"topology": map[string]interface{}{
+							"class":   "clusterClass1",
+							"version": "v1.22.0",
+						},
+					},
+					"controlPlane": map[string]interface{}{
+						"replicas": float64(3),
+					},
+					"machineDeployment": map[string]interface{}{
+						"version": "v1.21.2",
+					},
+					"machinePool": map[string]interface{}{
+						"version": "v1.21.2",
+					},
+				},
+				"userVariable": "value",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := calculateTemplateData(tt.variables)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(got).To(BeComparableTo(tt.want))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package remediation implements helper functions for tracking the remediation of Machines.
+package remediation
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// MaxHistoryEntries is the maximum number of entries kept in a remediation history.
+const MaxHistoryEntries = 10
+
+// NewHistoryEntry returns the remediation history entry for a Machine remediated at the given time;
+// the failed health check which triggered the remediation is read from the MachineHealthCheckSucceeded
+// condition of the Machine.
+func NewHistoryEntry(machine *clusterv1.Machine, now time.Time) clusterv1.RemediationHistoryEntry {
+	entry := clusterv1.RemediationHistoryEntry{
+		Machine:   machine.Name,
+		Timestamp: metav1.NewTime(now),
+	}
+	if conditions.IsFalse(machine, clusterv1.MachineHealthCheckSucceededCondition) {
+		entry.Reason = conditions.GetReason(machine, clusterv1.MachineHealthCheckSucceededCondition)
+		entry.Message = conditions.GetMessage(machine, clusterv1.MachineHealthCheckSucceededCondition)
+	}
+	return entry
+}
+
+// AddHistoryEntry adds an entry to a remediation history, dropping the oldest entries
+// if the history exceeds MaxHistoryEntries.
+func AddHistoryEntry(history []clusterv1.RemediationHistoryEntry, entry clusterv1.RemediationHistoryEntry) []clusterv1.RemediationHistoryEntry {
+	history = append(history, entry)
+	if len(history) > MaxHistoryEntries {
+		history = history[len(history)-MaxHistoryEntries:]
+	}
+	return history
+}
+
+// NextToReplace returns the remediated Machine of the oldest entry in a remediation history
+// whose replacement has not been recorded yet, if any.
+func NextToReplace(history []clusterv1.RemediationHistoryEntry) (string, bool) {
+	for i := range history {
+		if history[i].ReplacedBy == "" {
+			return history[i].Machine, true
+		}
+	}
+	return "", false
+}
+
+// SetReplacedBy records the Machine which replaced a remediated Machine in the most recent entry
+// for the remediated Machine, if the replacement has not already been recorded.
+// It returns true if the history is modified, false otherwise.
+func SetReplacedBy(history []clusterv1.RemediationHistoryEntry, machine, replacement string) bool {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Machine != machine {
+			continue
+		}
+		if history[i].ReplacedBy != "" {
+			return false
+		}
+		history[i].ReplacedBy = replacement
+		return true
+	}
+	return false
+}
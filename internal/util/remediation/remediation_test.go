@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediation
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestNewHistoryEntry(t *testing.T) {
+	g := NewWithT(t)
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "m1"}}
+	g.Expect(NewHistoryEntry(machine, now)).To(Equal(clusterv1.RemediationHistoryEntry{
+		Machine:   "m1",
+		Timestamp: metav1.NewTime(now),
+	}))
+
+	conditions.MarkFalse(machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.UnhealthyNodeConditionReason, clusterv1.ConditionSeverityWarning, "Condition Ready on node is reporting status False for more than 5m0s")
+	g.Expect(NewHistoryEntry(machine, now)).To(Equal(clusterv1.RemediationHistoryEntry{
+		Machine:   "m1",
+		Reason:    clusterv1.UnhealthyNodeConditionReason,
+		Message:   "Condition Ready on node is reporting status False for more than 5m0s",
+		Timestamp: metav1.NewTime(now),
+	}))
+}
+
+func TestAddHistoryEntry(t *testing.T) {
+	g := NewWithT(t)
+
+	var history []clusterv1.RemediationHistoryEntry
+	for i := 0; i < MaxHistoryEntries+2; i++ {
+		history = AddHistoryEntry(history, clusterv1.RemediationHistoryEntry{Machine: fmt.Sprintf("m%d", i)})
+	}
+	g.Expect(history).To(HaveLen(MaxHistoryEntries))
+	g.Expect(history[0].Machine).To(Equal("m2"))
+	g.Expect(history[MaxHistoryEntries-1].Machine).To(Equal(fmt.Sprintf("m%d", MaxHistoryEntries+1)))
+}
+
+func TestNextToReplace(t *testing.T) {
+	g := NewWithT(t)
+
+	_, ok := NextToReplace(nil)
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = NextToReplace([]clusterv1.RemediationHistoryEntry{{Machine: "m1", ReplacedBy: "r1"}})
+	g.Expect(ok).To(BeFalse())
+
+	machine, ok := NextToReplace([]clusterv1.RemediationHistoryEntry{{Machine: "m1", ReplacedBy: "r1"}, {Machine: "m2"}, {Machine: "m3"}})
+	g.Expect(ok).To(BeTrue())
+	g.Expect(machine).To(Equal("m2"))
+}
+
+func TestSetReplacedBy(t *testing.T) {
+	tests := []struct {
+		name         string
+		history      []clusterv1.RemediationHistoryEntry
+		machine      string
+		wantHistory  []clusterv1.RemediationHistoryEntry
+		wantModified bool
+	}{
+		{
+			name:         "does nothing if the machine is not in the history",
+			history:      []clusterv1.RemediationHistoryEntry{{Machine: "m1"}},
+			machine:      "m2",
+			wantHistory:  []clusterv1.RemediationHistoryEntry{{Machine: "m1"}},
+			wantModified: false,
+		},
+		{
+			name:         "sets the replacement on the most recent entry for the machine",
+			history:      []clusterv1.RemediationHistoryEntry{{Machine: "m1", ReplacedBy: "old"}, {Machine: "m2"}, {Machine: "m1"}},
+			machine:      "m1",
+			wantHistory:  []clusterv1.RemediationHistoryEntry{{Machine: "m1", ReplacedBy: "old"}, {Machine: "m2"}, {Machine: "m1", ReplacedBy: "new"}},
+			wantModified: true,
+		},
+		{
+			name:         "does not overwrite a replacement already recorded",
+			history:      []clusterv1.RemediationHistoryEntry{{Machine: "m1", ReplacedBy: "old"}},
+			machine:      "m1",
+			wantHistory:  []clusterv1.RemediationHistoryEntry{{Machine: "m1", ReplacedBy: "old"}},
+			wantModified: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(SetReplacedBy(tt.history, tt.machine, "new")).To(Equal(tt.wantModified))
+			g.Expect(tt.history).To(Equal(tt.wantHistory))
+		})
+	}
+}
@@ -39,6 +39,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/topology/check"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
 	"sigs.k8s.io/cluster-api/internal/topology/variables"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/version"
@@ -99,9 +100,17 @@ func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 			return apierrors.NewInternalError(errors.Wrapf(err, "Cluster %s can't be defaulted. ClusterClass %s can not be retrieved", cluster.Name, cluster.Spec.Topology.Class))
 		}
 
+		// If the Cluster is pinned to a revision of the ClusterClass, default and validate variables using the
+		// ClusterClass as recorded in the revision.
+		// NOTE: If the revision can't be retrieved, the error is surfaced by the validation webhook.
+		clusterClass, err = webhook.getClusterClassAtRevision(ctx, cluster, clusterClass)
+		if err != nil {
+			return nil
+		}
+
 		// Doing both defaulting and validating here prevents a race condition where the ClusterClass could be
 		// different in the defaulting and validating webhook.
-		allErrs = append(allErrs, DefaultAndValidateVariables(cluster, clusterClass)...)
+		allErrs = append(allErrs, DefaultAndValidateVariables(ctx, cluster, clusterClass)...)
 
 		if len(allErrs) > 0 {
 			return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), cluster.Name, allErrs)
@@ -188,6 +197,10 @@ func (webhook *Cluster) validate(ctx context.Context, oldCluster, newCluster *cl
 		}
 	}
 
+	if newCluster.Spec.RemediationBudget != nil {
+		allErrs = append(allErrs, validateRemediationBudget(newCluster.Spec.RemediationBudget, specPath.Child("remediationBudget"))...)
+	}
+
 	topologyPath := specPath.Child("topology")
 
 	// Validate the managed topology, if defined.
@@ -291,8 +304,20 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 	// Add the warnings if no error was returned.
 	allWarnings = append(allWarnings, warnings...)
 
-	// If there's no error validate the Cluster based on the ClusterClass.
+	// If there's no error validate the Cluster based on the ClusterClass, or on the revision of the ClusterClass
+	// the Cluster is pinned to.
+	currentClusterClass := clusterClass
 	if clusterClassPollErr == nil {
+		var err error
+		clusterClass, err = webhook.getClusterClassAtRevision(ctx, newCluster, currentClusterClass)
+		if err != nil {
+			allErrs = append(
+				allErrs, field.Invalid(
+					fldPath.Child("classRevision"),
+					newCluster.Spec.Topology.ClassRevision,
+					err.Error()))
+			return allWarnings, allErrs
+		}
 		allErrs = append(allErrs, ValidateClusterForClusterClass(newCluster, clusterClass)...)
 	}
 	if oldCluster != nil { // On update
@@ -377,6 +402,9 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 		if oldCluster.Spec.Topology.Class != newCluster.Spec.Topology.Class {
 			// Check to see if the ClusterClass referenced in the old version of the Cluster exists.
 			oldClusterClass, err := webhook.pollClusterClassForCluster(ctx, oldCluster)
+			if err == nil {
+				oldClusterClass, err = webhook.getClusterClassAtRevision(ctx, oldCluster, oldClusterClass)
+			}
 			if err != nil {
 				allErrs = append(
 					allErrs, field.Forbidden(
@@ -391,6 +419,27 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 			// Check if the new and old ClusterClasses are compatible with one another.
 			allErrs = append(allErrs, check.ClusterClassesAreCompatible(oldClusterClass, clusterClass)...)
 		}
+
+		// If the Cluster has been rebased to another revision of the same ClusterClass compatibility checks are needed.
+		if oldCluster.Spec.Topology.Class == newCluster.Spec.Topology.Class &&
+			oldCluster.Spec.Topology.ClassRevision != newCluster.Spec.Topology.ClassRevision &&
+			clusterClassPollErr == nil {
+			// Check to see if the revision referenced in the old version of the Cluster exists.
+			oldClusterClass, err := webhook.getClusterClassAtRevision(ctx, oldCluster, currentClusterClass)
+			if err != nil {
+				allErrs = append(
+					allErrs, field.Forbidden(
+						fldPath.Child("classRevision"),
+						fmt.Sprintf("ClusterClass revision %q could not be retrieved, change from revision %[1]q to revision %q cannot be validated. Error: %s",
+							oldCluster.Spec.Topology.ClassRevision, newCluster.Spec.Topology.ClassRevision, err.Error())))
+
+				// Return early with errors if the revision can't be retrieved.
+				return allWarnings, allErrs
+			}
+
+			// Check if the old and new revisions of the ClusterClass are compatible with one another.
+			allErrs = append(allErrs, check.ClusterClassesAreCompatible(oldClusterClass, clusterClass)...)
+		}
 	}
 	return allWarnings, allErrs
 }
@@ -476,6 +525,23 @@ func machineDeploymentClassOfName(clusterClass *clusterv1.ClusterClass, name str
 	return nil
 }
 
+// validateRemediationBudget validates the remediation budget of a Cluster.
+func validateRemediationBudget(budget *clusterv1.RemediationBudget, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if budget.MaxInFlight == nil && budget.MaxPerWindow == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of maxInFlight and maxPerWindow must be set"))
+	}
+	if budget.MaxPerWindow != nil && (budget.Window == nil || budget.Window.Duration <= 0) {
+		allErrs = append(allErrs, field.Required(fldPath.Child("window"), "must be set to a duration greater than 0 when maxPerWindow is set"))
+	}
+	if budget.Window != nil && budget.MaxPerWindow == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("window"), "can be set only when maxPerWindow is set"))
+	}
+
+	return allErrs
+}
+
 // validateCIDRBlocks ensures the passed CIDR is valid.
 func validateCIDRBlocks(fldPath *field.Path, cidrs []string) field.ErrorList {
 	var allErrs field.ErrorList
@@ -492,13 +558,13 @@ func validateCIDRBlocks(fldPath *field.Path, cidrs []string) field.ErrorList {
 
 // DefaultAndValidateVariables defaults and validates variables in the Cluster and MachineDeployment/MachinePool topologies based
 // on the definitions in the ClusterClass.
-func DefaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+func DefaultAndValidateVariables(ctx context.Context, cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, DefaultVariables(cluster, clusterClass)...)
 
 	// Variables must be validated in the defaulting webhook. Variable definitions are stored in the ClusterClass status
 	// and are patched in the ClusterClass reconcile.
-	allErrs = append(allErrs, variables.ValidateClusterVariables(cluster.Spec.Topology.Variables, clusterClass.Status.Variables,
+	allErrs = append(allErrs, variables.ValidateClusterVariables(ctx, cluster.Spec.Topology.Variables, clusterClass.Status.Variables,
 		field.NewPath("spec", "topology", "variables"))...)
 	if cluster.Spec.Topology.Workers != nil {
 		for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
@@ -506,7 +572,7 @@ func DefaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clust
 			if md.Variables == nil || len(md.Variables.Overrides) == 0 {
 				continue
 			}
-			allErrs = append(allErrs, variables.ValidateMachineVariables(md.Variables.Overrides, clusterClass.Status.Variables,
+			allErrs = append(allErrs, variables.ValidateMachineVariables(ctx, md.Variables.Overrides, clusterClass.Status.Variables,
 				field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i).Child("variables", "overrides"))...)
 		}
 		for i, mp := range cluster.Spec.Topology.Workers.MachinePools {
@@ -514,7 +580,7 @@ func DefaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clust
 			if mp.Variables == nil || len(mp.Variables.Overrides) == 0 {
 				continue
 			}
-			allErrs = append(allErrs, variables.ValidateMachineVariables(mp.Variables.Overrides, clusterClass.Status.Variables,
+			allErrs = append(allErrs, variables.ValidateMachineVariables(ctx, mp.Variables.Overrides, clusterClass.Status.Variables,
 				field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("variables", "overrides"))...)
 		}
 	}
@@ -641,6 +707,15 @@ func (webhook *Cluster) pollClusterClassForCluster(ctx context.Context, cluster
 	return clusterClass, nil
 }
 
+// getClusterClassAtRevision returns the ClusterClass as recorded in the revision the Cluster is pinned to;
+// if the Cluster is not pinned to a revision, the given ClusterClass is returned.
+func (webhook *Cluster) getClusterClassAtRevision(ctx context.Context, cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) (*clusterv1.ClusterClass, error) {
+	if cluster.Spec.Topology.ClassRevision == "" {
+		return clusterClass, nil
+	}
+	return revisions.ClusterClass(ctx, webhook.Client, clusterClass, cluster.Spec.Topology.ClassRevision)
+}
+
 // clusterClassIsReconciled returns errClusterClassNotReconciled if the ClusterClass has not successfully reconciled or if the
 // ClusterClass variables have not been successfully reconciled.
 func clusterClassIsReconciled(clusterClass *clusterv1.ClusterClass) error {
@@ -19,13 +19,16 @@ package webhooks
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +38,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/internal/topology/revisions"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
@@ -1018,6 +1022,59 @@ func TestClusterValidation(t *testing.T) {
 	}
 }
 
+func TestClusterRemediationBudgetValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    *clusterv1.RemediationBudget
+		expectErr bool
+	}{
+		{
+			name:      "pass with maxInFlight",
+			budget:    &clusterv1.RemediationBudget{MaxInFlight: pointer.Int32(1)},
+			expectErr: false,
+		},
+		{
+			name:      "pass with maxPerWindow and window",
+			budget:    &clusterv1.RemediationBudget{MaxPerWindow: pointer.Int32(3), Window: &metav1.Duration{Duration: time.Hour}},
+			expectErr: false,
+		},
+		{
+			name:      "fails without maxInFlight and maxPerWindow",
+			budget:    &clusterv1.RemediationBudget{},
+			expectErr: true,
+		},
+		{
+			name:      "fails with maxPerWindow without window",
+			budget:    &clusterv1.RemediationBudget{MaxPerWindow: pointer.Int32(3)},
+			expectErr: true,
+		},
+		{
+			name:      "fails with window without maxPerWindow",
+			budget:    &clusterv1.RemediationBudget{MaxInFlight: pointer.Int32(1), Window: &metav1.Duration{Duration: time.Hour}},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster("fooNamespace", "cluster1").Build()
+			cluster.Spec.RemediationBudget = tt.budget
+
+			// Create the webhook.
+			webhook := &Cluster{}
+
+			warnings, err := webhook.validate(ctx, nil, cluster)
+			g.Expect(warnings).To(BeEmpty())
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
 func TestClusterTopologyValidation(t *testing.T) {
 	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to set Cluster.Topologies.
 	// Enabling the feature flag temporarily for this test.
@@ -2026,6 +2083,142 @@ func TestClusterTopologyValidationForTopologyClassChange(t *testing.T) {
 	}
 }
 
+// TestClusterTopologyValidationForTopologyClassRevisionChange cases where cluster.spec.topology.classRevision is altered.
+func TestClusterTopologyValidationForTopologyClassRevisionChange(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	ref := &corev1.ObjectReference{
+		APIVersion: "group.test.io/foo",
+		Kind:       "barTemplate",
+		Name:       "baz",
+		Namespace:  "default",
+	}
+	compatibleNameChangeRef := &corev1.ObjectReference{
+		APIVersion: "group.test.io/foo",
+		Kind:       "barTemplate",
+		Name:       "differentbaz",
+		Namespace:  "default",
+	}
+	incompatibleKindRef := &corev1.ObjectReference{
+		APIVersion: "group.test.io/foo",
+		Kind:       "another-barTemplate",
+		Name:       "another-baz",
+		Namespace:  "default",
+	}
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithInfrastructureClusterTemplate(refToUnstructured(ref)).
+		WithControlPlaneTemplate(refToUnstructured(ref)).
+		WithControlPlaneInfrastructureMachineTemplate(refToUnstructured(ref)).
+		Build()
+	conditions.MarkTrue(clusterClass, clusterv1.ClusterClassVariablesReconciledCondition)
+
+	// revision returns a revision of class1, recording the ClusterClass with the given infrastructureCluster ref.
+	revision := func(name, className string, infrastructureRef *corev1.ObjectReference) *appsv1.ControllerRevision {
+		revisionClusterClass := clusterClass.DeepCopy()
+		revisionClusterClass.Spec.Infrastructure.Ref = infrastructureRef
+		data, err := revisions.Data(revisionClusterClass)
+		if err != nil {
+			panic(err)
+		}
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    map[string]string{clusterv1.ClusterClassNameLabel: className},
+			},
+			Data: runtime.RawExtension{Raw: data},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		oldClassRevision string
+		newClassRevision string
+		wantErr          bool
+	}{
+		{
+			name:             "Accept pinning a Cluster to a revision with a compatible infrastructureCluster ref change",
+			newClassRevision: "class1-compatible",
+			wantErr:          false,
+		},
+		{
+			name:             "Reject pinning a Cluster to a revision with an incompatible infrastructureCluster ref change",
+			newClassRevision: "class1-incompatible",
+			wantErr:          true,
+		},
+		{
+			name:             "Accept rebasing a Cluster between compatible revisions",
+			oldClassRevision: "class1-compatible",
+			newClassRevision: "class1-current",
+			wantErr:          false,
+		},
+		{
+			name:             "Reject rebasing a Cluster between incompatible revisions",
+			oldClassRevision: "class1-incompatible",
+			newClassRevision: "class1-compatible",
+			wantErr:          true,
+		},
+		{
+			name:             "Accept unpinning a Cluster from a compatible revision",
+			oldClassRevision: "class1-compatible",
+			newClassRevision: "",
+			wantErr:          false,
+		},
+		{
+			name:             "Reject pinning a Cluster to a revision which does not exist",
+			newClassRevision: "class1-does-not-exist",
+			wantErr:          true,
+		},
+		{
+			name:             "Reject pinning a Cluster to a revision of another ClusterClass",
+			newClassRevision: "class2-current",
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Sets up the fakeClient for the test case.
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(
+					clusterClass,
+					revision("class1-current", "class1", ref),
+					revision("class1-compatible", "class1", compatibleNameChangeRef),
+					revision("class1-incompatible", "class1", incompatibleKindRef),
+					revision("class2-current", "class2", ref),
+				).
+				WithScheme(fakeScheme).
+				Build()
+
+			// Create the webhook and add the fakeClient as its client. This is required because the test uses a Managed Topology.
+			c := &Cluster{Client: fakeClient}
+
+			oldCluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("class1").
+						WithClassRevision(tt.oldClassRevision).
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						Build()).
+				Build()
+			newCluster := oldCluster.DeepCopy()
+			newCluster.Spec.Topology.ClassRevision = tt.newClassRevision
+
+			// Checks the return error.
+			warnings, err := c.ValidateUpdate(ctx, oldCluster, newCluster)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
 // TestMovingBetweenManagedAndUnmanaged cluster tests cases where a clusterClass is added or removed during a cluster update.
 func TestMovingBetweenManagedAndUnmanaged(t *testing.T) {
 	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
@@ -166,6 +166,9 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 	allErrs = append(allErrs,
 		variables.ValidateClusterClassVariables(ctx, newClusterClass.Spec.Variables, field.NewPath("spec", "variables"))...,
 	)
+	allErrs = append(allErrs,
+		variables.ValidateClusterClassDerivedVariables(newClusterClass.Spec.DerivedVariables, newClusterClass.Spec.Variables, field.NewPath("spec", "derivedVariables"))...,
+	)
 
 	// Validate patches.
 	allErrs = append(allErrs, validatePatches(newClusterClass)...)
@@ -186,6 +189,9 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 			return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind(), newClusterClass.Name, allErrs)
 		}
 
+		// Clusters pinned to a revision of the ClusterClass are not affected by changes to the ClusterClass.
+		clusters = clustersNotPinnedToRevision(clusters)
+
 		// Ensure no MachineDeploymentClass currently in use has been removed from the ClusterClass.
 		allErrs = append(allErrs,
 			webhook.validateRemovedMachineDeploymentClassesAreNotUsed(clusters, oldClusterClass, newClusterClass)...)
@@ -271,6 +277,17 @@ func validateUpdatesToMachineHealthCheckClasses(clusters []clusterv1.Cluster, ol
 	return allErrs
 }
 
+// clustersNotPinnedToRevision returns the Clusters which are not pinned to a revision of their ClusterClass.
+func clustersNotPinnedToRevision(clusters []clusterv1.Cluster) []clusterv1.Cluster {
+	res := []clusterv1.Cluster{}
+	for _, cluster := range clusters {
+		if cluster.Spec.Topology.ClassRevision == "" {
+			res = append(res, cluster)
+		}
+	}
+	return res
+}
+
 func (webhook *ClusterClass) validateRemovedMachineDeploymentClassesAreNotUsed(clusters []clusterv1.Cluster, oldClusterClass, newClusterClass *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -373,17 +390,6 @@ func (webhook *ClusterClass) getClustersUsingClusterClass(ctx context.Context, c
 	return clusters.Items, nil
 }
 
-func getClusterClassVariablesMapWithReverseIndex(clusterClassVariables []clusterv1.ClusterClassVariable) (map[string]*clusterv1.ClusterClassVariable, map[string]int) {
-	variablesMap := map[string]*clusterv1.ClusterClassVariable{}
-	variablesIndexMap := map[string]int{}
-
-	for i := range clusterClassVariables {
-		variablesMap[clusterClassVariables[i].Name] = &clusterClassVariables[i]
-		variablesIndexMap[clusterClassVariables[i].Name] = i
-	}
-	return variablesMap, variablesIndexMap
-}
-
 func validateMachineHealthCheckClasses(clusterClass *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 
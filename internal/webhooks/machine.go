@@ -166,6 +166,8 @@ func (webhook *Machine) validate(oldM, newM *clusterv1.Machine) error {
 		}
 	}
 
+	allErrs = append(allErrs, newM.Spec.NodeDrainPolicy.Validate(specPath.Child("nodeDrainPolicy"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -336,3 +336,66 @@ func TestMachineVersionValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineNodeDrainPolicyValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    *clusterv1.NodeDrainPolicy
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when the node drain policy is not set",
+			policy:    nil,
+			expectErr: false,
+		},
+		{
+			name: "should succeed when given valid selectors",
+			policy: &clusterv1.NodeDrainPolicy{
+				SkipPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"skip": "true"}},
+				EvictionOrder: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{"tier": "stateless"}},
+					{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"stateful"}}}},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "should return error when given an invalid skip pod selector",
+			policy: &clusterv1.NodeDrainPolicy{
+				SkipPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"skip": "not a valid value"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error when given an invalid eviction order selector",
+			policy: &clusterv1.NodeDrainPolicy{
+				EvictionOrder: []metav1.LabelSelector{
+					{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: metav1.LabelSelectorOpIn}}},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					NodeDrainPolicy: tt.policy,
+					Bootstrap:       clusterv1.Bootstrap{ConfigRef: nil, DataSecretName: pointer.String("test")},
+				},
+			}
+			webhook := &Machine{}
+
+			warnings, err := webhook.ValidateCreate(ctx, m)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
@@ -268,6 +268,53 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 		}
 	}
 
+	if newMD.Spec.Strategy != nil && newMD.Spec.Strategy.BlueGreen != nil {
+		if newMD.Spec.Strategy.Type != clusterv1.BlueGreenMachineDeploymentStrategyType {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					specPath.Child("strategy", "blueGreen"),
+					fmt.Sprintf("can be set only if strategy.type is %s", clusterv1.BlueGreenMachineDeploymentStrategyType),
+				),
+			)
+		}
+
+		if gate := newMD.Spec.Strategy.BlueGreen.CutOverGate; gate != nil && gate.Type == clusterv1.RuntimeHookCutOverGateType && !feature.Gates.Enabled(feature.RuntimeSDK) {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					specPath.Child("strategy", "blueGreen", "cutOverGate", "type"),
+					fmt.Sprintf("%s can be used only if the RuntimeSDK feature flag is enabled", clusterv1.RuntimeHookCutOverGateType),
+				),
+			)
+		}
+	}
+
+	if newMD.Spec.Strategy != nil && len(newMD.Spec.Strategy.Steps) > 0 {
+		if newMD.Spec.Strategy.Type != clusterv1.RollingUpdateMachineDeploymentStrategyType {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					specPath.Child("strategy", "steps"),
+					fmt.Sprintf("can be set only if strategy.type is %s", clusterv1.RollingUpdateMachineDeploymentStrategyType),
+				),
+			)
+		}
+
+		for i, step := range newMD.Spec.Strategy.Steps {
+			stepPath := specPath.Child("strategy", "steps").Index(i)
+			if (step.SetWeight == nil) == (step.Pause == nil) {
+				allErrs = append(allErrs, field.Invalid(stepPath, step, "exactly one of setWeight and pause must be set"))
+			}
+			if step.SetWeight != nil && (*step.SetWeight < 1 || *step.SetWeight > 100) {
+				allErrs = append(allErrs, field.Invalid(stepPath.Child("setWeight"), *step.SetWeight, "must be between 1 and 100"))
+			}
+			if step.Pause != nil && step.Pause.Duration != nil && step.Pause.Duration.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(stepPath.Child("pause", "duration"), step.Pause.Duration.String(), "must be greater than or equal to 0"))
+			}
+		}
+	}
+
 	if newMD.Spec.Template.Spec.Version != nil {
 		if !version.KubeSemver.MatchString(*newMD.Spec.Template.Spec.Version) {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("template", "spec", "version"), *newMD.Spec.Template.Spec.Version, "must be a valid semantic version"))
@@ -276,6 +323,7 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 
 	// Validate the metadata of the template.
 	allErrs = append(allErrs, newMD.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
+	allErrs = append(allErrs, newMD.Spec.Template.Spec.NodeDrainPolicy.Validate(specPath.Child("template", "spec", "nodeDrainPolicy"))...)
 
 	if len(allErrs) == 0 {
 		return nil
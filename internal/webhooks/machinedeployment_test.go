@@ -20,6 +20,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -378,6 +379,106 @@ func TestMachineDeploymentValidation(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name:      "should not return error for blueGreen with BlueGreen strategy type",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.BlueGreenMachineDeploymentStrategyType,
+				BlueGreen: &clusterv1.MachineBlueGreenDeployment{
+					CutOverGate: &clusterv1.MachineDeploymentCutOverGate{
+						Type: clusterv1.AnnotationCutOverGateType,
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "should return error for blueGreen with a strategy type other than BlueGreen",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type:      clusterv1.OnDeleteMachineDeploymentStrategyType,
+				BlueGreen: &clusterv1.MachineBlueGreenDeployment{},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error for RuntimeHook cut-over gate if the RuntimeSDK feature flag is disabled",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.BlueGreenMachineDeploymentStrategyType,
+				BlueGreen: &clusterv1.MachineBlueGreenDeployment{
+					CutOverGate: &clusterv1.MachineDeploymentCutOverGate{
+						Type: clusterv1.RuntimeHookCutOverGateType,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should not return error for valid steps with RollingUpdate strategy type",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: &goodMaxUnavailableInt,
+					MaxSurge:       &goodMaxSurgeInt,
+				},
+				Steps: []clusterv1.MachineDeploymentRolloutStep{
+					{SetWeight: pointer.Int32(10)},
+					{Pause: &clusterv1.MachineDeploymentRolloutPause{Duration: &metav1.Duration{Duration: time.Hour}}},
+					{Pause: &clusterv1.MachineDeploymentRolloutPause{}},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "should return error for steps with a strategy type other than RollingUpdate",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.OnDeleteMachineDeploymentStrategyType,
+				Steps: []clusterv1.MachineDeploymentRolloutStep{
+					{SetWeight: pointer.Int32(10)},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error for a step with both setWeight and pause",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: &goodMaxUnavailableInt,
+					MaxSurge:       &goodMaxSurgeInt,
+				},
+				Steps: []clusterv1.MachineDeploymentRolloutStep{
+					{SetWeight: pointer.Int32(10), Pause: &clusterv1.MachineDeploymentRolloutPause{}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error for a step with an invalid setWeight",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: &goodMaxUnavailableInt,
+					MaxSurge:       &goodMaxSurgeInt,
+				},
+				Steps: []clusterv1.MachineDeploymentRolloutStep{
+					{SetWeight: pointer.Int32(101)},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
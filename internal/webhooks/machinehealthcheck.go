@@ -87,6 +87,10 @@ func (webhook *MachineHealthCheck) Default(_ context.Context, obj runtime.Object
 		m.Spec.NodeStartupTimeout = &clusterv1.DefaultNodeStartupTimeout
 	}
 
+	if m.Spec.UnhealthyChecksOperator == "" {
+		m.Spec.UnhealthyChecksOperator = clusterv1.OrUnhealthyChecksOperator
+	}
+
 	if m.Spec.RemediationTemplate != nil && m.Spec.RemediationTemplate.Namespace == "" {
 		m.Spec.RemediationTemplate.Namespace = m.Namespace
 	}
@@ -157,6 +161,21 @@ func (webhook *MachineHealthCheck) validate(oldMHC, newMHC *clusterv1.MachineHea
 		)
 	}
 
+	if newMHC.Spec.SoftRemediation != nil {
+		if newMHC.Spec.RemediationTemplate != nil {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(specPath.Child("softRemediation"), "cannot be set together with remediationTemplate"),
+			)
+		}
+		if newMHC.Spec.SoftRemediation.Timeout.Duration <= 0 {
+			allErrs = append(
+				allErrs,
+				field.Invalid(specPath.Child("softRemediation", "timeout"), newMHC.Spec.SoftRemediation.Timeout.String(), "must be greater than 0"),
+			)
+		}
+	}
+
 	allErrs = append(allErrs, webhook.validateCommonFields(newMHC, specPath)...)
 
 	if len(allErrs) == 0 {
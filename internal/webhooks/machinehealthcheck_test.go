@@ -57,6 +57,7 @@ func TestMachineHealthCheckDefault(t *testing.T) {
 	g.Expect(mhc.Spec.MaxUnhealthy.String()).To(Equal("100%"))
 	g.Expect(mhc.Spec.NodeStartupTimeout).ToNot(BeNil())
 	g.Expect(*mhc.Spec.NodeStartupTimeout).To(BeComparableTo(metav1.Duration{Duration: 10 * time.Minute}))
+	g.Expect(mhc.Spec.UnhealthyChecksOperator).To(Equal(clusterv1.OrUnhealthyChecksOperator))
 	g.Expect(mhc.Spec.RemediationTemplate.Namespace).To(Equal(mhc.Namespace))
 }
 
@@ -496,3 +497,63 @@ func TestMachineHealthCheckRemediationTemplateNamespaceValidation(t *testing.T)
 		})
 	}
 }
+
+func TestMachineHealthCheckSoftRemediationValidation(t *testing.T) {
+	valid := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+		},
+		Spec: clusterv1.MachineHealthCheckSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+			SoftRemediation: &clusterv1.SoftRemediation{
+				Action:  clusterv1.RebootSoftRemediationAction,
+				Timeout: metav1.Duration{Duration: 5 * time.Minute},
+			},
+			UnhealthyConditions: []clusterv1.UnhealthyCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+	withRemediationTemplate := valid.DeepCopy()
+	withRemediationTemplate.Spec.RemediationTemplate = &corev1.ObjectReference{Namespace: "foo"}
+	withoutTimeout := valid.DeepCopy()
+	withoutTimeout.Spec.SoftRemediation.Timeout = metav1.Duration{}
+
+	tests := []struct {
+		name      string
+		expectErr bool
+		c         *clusterv1.MachineHealthCheck
+	}{
+		{
+			name:      "should succeed when soft remediation is valid",
+			expectErr: false,
+			c:         valid,
+		},
+		{
+			name:      "should return error when soft remediation is set together with RemediationTemplate",
+			expectErr: true,
+			c:         withRemediationTemplate,
+		},
+		{
+			name:      "should return error when soft remediation timeout is not set",
+			expectErr: true,
+			c:         withoutTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			webhook := &MachineHealthCheck{}
+
+			if tt.expectErr {
+				g.Expect(webhook.validate(nil, tt.c)).NotTo(Succeed())
+			} else {
+				g.Expect(webhook.validate(nil, tt.c)).To(Succeed())
+			}
+		})
+	}
+}
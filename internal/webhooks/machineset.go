@@ -178,6 +178,7 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 
 	// Validate the metadata of the template.
 	allErrs = append(allErrs, newMS.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
+	allErrs = append(allErrs, newMS.Spec.Template.Spec.NodeDrainPolicy.Validate(specPath.Child("template", "spec", "nodeDrainPolicy"))...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -23,7 +23,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/Masterminds/sprig/v3"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -32,6 +31,7 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/topology/variables"
 )
 
 // validatePatches returns errors if the Patches in the ClusterClass violate any validation rules.
@@ -115,7 +115,7 @@ func validatePatchDefinitions(patch clusterv1.ClusterClassPatch, clusterClass *c
 	if patch.Definitions != nil {
 		for i, definition := range patch.Definitions {
 			allErrs = append(allErrs,
-				validateJSONPatches(definition.JSONPatches, clusterClass.Spec.Variables, path.Child("definitions").Index(i).Child("jsonPatches"))...)
+				validatePatchDefinitionPatches(definition, clusterClass, path.Child("definitions").Index(i))...)
 			allErrs = append(allErrs,
 				validateSelectors(definition.Selector, clusterClass, path.Child("definitions").Index(i).Child("selector"))...)
 		}
@@ -146,7 +146,7 @@ func validateEnabledIf(enabledIf *string, path *field.Path) field.ErrorList {
 
 	if enabledIf != nil {
 		// Error if template can not be parsed.
-		_, err := template.New("enabledIf").Funcs(sprig.HermeticTxtFuncMap()).Parse(*enabledIf)
+		_, err := template.New("enabledIf").Funcs(variables.TemplateFuncs()).Parse(*enabledIf)
 		if err != nil {
 			allErrs = append(allErrs,
 				field.Invalid(
@@ -160,6 +160,47 @@ func validateEnabledIf(enabledIf *string, path *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// validatePatchDefinitionPatches validates that exactly one of jsonPatches or mergePatchTemplate is set
+// and that they are valid.
+func validatePatchDefinitionPatches(definition clusterv1.PatchDefinition, clusterClass *clusterv1.ClusterClass, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if definition.JSONPatches == nil && definition.MergePatchTemplate == nil {
+		allErrs = append(allErrs,
+			field.Required(
+				path,
+				"one of jsonPatches or mergePatchTemplate must be defined",
+			))
+	}
+
+	if definition.JSONPatches != nil && definition.MergePatchTemplate != nil {
+		allErrs = append(allErrs,
+			field.Invalid(
+				path,
+				definition,
+				"only one of jsonPatches or mergePatchTemplate can be defined",
+			))
+	}
+
+	allErrs = append(allErrs,
+		validateJSONPatches(definition.JSONPatches, clusterClass, path.Child("jsonPatches"))...)
+
+	if definition.MergePatchTemplate != nil {
+		// Error if template can not be parsed.
+		_, err := template.New("mergePatchTemplate").Funcs(variables.TemplateFuncs()).Parse(*definition.MergePatchTemplate)
+		if err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					path.Child("mergePatchTemplate"),
+					*definition.MergePatchTemplate,
+					fmt.Sprintf("template can not be parsed: %v", err),
+				))
+		}
+	}
+
+	return allErrs
+}
+
 // validateSelectors tests to see if the selector matches any template in the ClusterClass.
 // It returns nil as soon as it finds any matching template and an error if there is no match.
 func validateSelectors(selector clusterv1.PatchSelector, class *clusterv1.ClusterClass, path *field.Path) field.ErrorList {
@@ -320,9 +361,17 @@ func selectorMatchTemplate(selector clusterv1.PatchSelector, reference *corev1.O
 
 var validOps = sets.Set[string]{}.Insert("add", "replace", "remove")
 
-func validateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables []clusterv1.ClusterClassVariable, path *field.Path) field.ErrorList {
+func validateJSONPatches(jsonPatches []clusterv1.JSONPatch, clusterClass *clusterv1.ClusterClass, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
-	variableSet, _ := getClusterClassVariablesMapWithReverseIndex(variables)
+
+	// Inline patches can use both ClusterClass variables and derived variables.
+	variableSet := sets.Set[string]{}
+	for _, variable := range clusterClass.Spec.Variables {
+		variableSet.Insert(variable.Name)
+	}
+	for _, derivedVariable := range clusterClass.Spec.DerivedVariables {
+		variableSet.Insert(derivedVariable.Name)
+	}
 
 	for i, jsonPatch := range jsonPatches {
 		if !validOps.Has(jsonPatch.Op) {
@@ -356,7 +405,7 @@ func validateJSONPatches(jsonPatches []clusterv1.JSONPatch, variables []clusterv
 	return allErrs
 }
 
-func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[string]*clusterv1.ClusterClassVariable, path *field.Path) field.ErrorList {
+func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet sets.Set[string], path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
 	// move to the next variable if the jsonPatch does not have "replace" or "add" op. Additional validation is not needed.
@@ -414,7 +463,7 @@ func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[stri
 
 	if jsonPatch.ValueFrom != nil && jsonPatch.ValueFrom.Template != nil {
 		// Error if template can not be parsed.
-		_, err := template.New("valueFrom.template").Funcs(sprig.HermeticTxtFuncMap()).Parse(*jsonPatch.ValueFrom.Template)
+		_, err := template.New("valueFrom.template").Funcs(variables.TemplateFuncs()).Parse(*jsonPatch.ValueFrom.Template)
 		if err != nil {
 			allErrs = append(allErrs,
 				field.Invalid(
@@ -443,7 +492,7 @@ func validateJSONPatchValues(jsonPatch clusterv1.JSONPatch, variableSet map[stri
 			// This could be done by re-using getVariableValue of the json patch
 			// generator but requires a refactoring first.
 			variableName := getVariableName(*jsonPatch.ValueFrom.Variable)
-			if _, ok := variableSet[variableName]; !ok {
+			if !variableSet.Has(variableName) {
 				allErrs = append(allErrs,
 					field.Invalid(
 						path.Child("valueFrom", "variable"),
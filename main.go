@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -285,6 +286,8 @@ func main() {
 
 	req, _ := labels.NewRequirement(clusterv1.ClusterNameLabel, selection.Exists, nil)
 	clusterSecretCacheSelector := labels.NewSelector().Add(*req)
+	req, _ = labels.NewRequirement(clusterv1.ClusterClassNameLabel, selection.Exists, nil)
+	clusterClassRevisionCacheSelector := labels.NewSelector().Add(*req)
 
 	ctrlOptions := ctrl.Options{
 		Scheme:                     scheme,
@@ -307,6 +310,10 @@ func main() {
 				&corev1.Secret{}: {
 					Label: clusterSecretCacheSelector,
 				},
+				// Note: Only ControllerRevisions recording the revisions of a ClusterClass are cached.
+				&appsv1.ControllerRevision{}: {
+					Label: clusterClassRevisionCacheSelector,
+				},
 			},
 		},
 		Client: client.Options{
@@ -495,6 +502,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		UnstructuredCachingClient: unstructuredCachingClient,
 		APIReader:                 mgr.GetAPIReader(),
 		Tracker:                   tracker,
+		RuntimeClient:             runtimeClient,
 		WatchFilterValue:          watchFilterValue,
 		NodeDrainClientTimeout:    nodeDrainClientTimeout,
 	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
@@ -515,6 +523,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		Client:                    mgr.GetClient(),
 		UnstructuredCachingClient: unstructuredCachingClient,
 		APIReader:                 mgr.GetAPIReader(),
+		RuntimeClient:             runtimeClient,
 		WatchFilterValue:          watchFilterValue,
 	}).SetupWithManager(ctx, mgr, concurrency(machineDeploymentConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineDeployment")